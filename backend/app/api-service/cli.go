@@ -0,0 +1,220 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gin-gonic/gin"
+
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	coreService "github.com/STLeee/mediation-platform/backend/core/service"
+)
+
+// DefaultSeedDir is the directory of seed files for local deployment, relative to the app directory
+const DefaultSeedDir = "../../mongodb/document"
+
+// command is a subcommand of api service, run parses arguments after the command name
+type command struct {
+	name        string
+	description string
+	run         func(configPath string, args []string, stdout io.Writer) error
+}
+
+// Commands of api service, the first one runs if no command is given
+var commands = []command{
+	{name: "serve", description: "Run the API server until it is stopped by signal", run: runServe},
+	{name: "check-config", description: "Validate the config without connecting to dependencies", run: runCheckConfig},
+	{name: "print-routes", description: "Print the route table", run: runPrintRoutes},
+	{name: "migrate", description: "Create indexes of MongoDB collections", run: runMigrate},
+	{name: "seed", description: "Write documents of seed files into MongoDB collections, not allowed in prod", run: runSeed},
+}
+
+// Print usage of api service
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [-config path] [command] [flags]\n\nCommands:\n", filepath.Base(os.Args[0]))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.description)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRun '%s [command] -h' for flags of the command.\n", filepath.Base(os.Args[0]))
+}
+
+// Run the command named by the first argument, config path is the default of -config flag of the command
+func runCommand(args []string, configPath string, stdout io.Writer) error {
+	cmd := commands[0]
+	if len(args) > 0 {
+		index := slices.IndexFunc(commands, func(cmd command) bool {
+			return cmd.name == args[0]
+		})
+		if index < 0 {
+			return fmt.Errorf("unknown command %q", args[0])
+		}
+		cmd, args = commands[index], args[1:]
+	}
+	return cmd.run(configPath, args, stdout)
+}
+
+// New flag set of the command with -config flag, so config path can be given before or after the command name
+func newFlagSet(name string, configPath *string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.StringVar(configPath, "config", *configPath, "Config file path")
+	return flagSet
+}
+
+// Run the API server
+func runServe(configPath string, args []string, stdout io.Writer) error {
+	if err := newFlagSet("serve", &configPath).Parse(args); err != nil {
+		return err
+	}
+	return run(configPath)
+}
+
+// Validate the config, configs of components are validated by their constructors as well
+func runCheckConfig(configPath string, args []string, stdout io.Writer) error {
+	if err := newFlagSet("check-config", &configPath).Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config %s: %w", configPath, err)
+	}
+	notifier, err := initNotifier(cfg, map[coreRepository.RepositoryName]any{})
+	if err != nil {
+		return fmt.Errorf("invalid notification config: %w", err)
+	}
+	notifier.Close(context.Background())
+	if _, err := initCommentSuggester(cfg); err != nil {
+		return fmt.Errorf("invalid AI config: %w", err)
+	}
+	if _, err := initAuthzPolicy(cfg); err != nil {
+		return fmt.Errorf("invalid authorization config: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "Config %s is valid\n", configPath)
+	return nil
+}
+
+// Print the route table, routes are registered without dependencies since handlers are not called
+func runPrintRoutes(configPath string, args []string, stdout io.Writer) error {
+	if err := newFlagSet("print-routes", &configPath).Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Gin prints registered routes in debug mode
+	gin.SetMode(gin.ReleaseMode)
+	engine := gin.New()
+	registerAPIRouters(engine, cfg.Authentication, apiDependencies{})
+	registerSwaggerRouter(engine, cfg)
+
+	printRoutes(stdout, engine.Routes())
+	return nil
+}
+
+// Print routes sorted by path and method
+func printRoutes(w io.Writer, routes gin.RoutesInfo) {
+	routes = slices.Clone(routes)
+	slices.SortFunc(routes, func(a, b gin.RouteInfo) int {
+		return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method))
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tHANDLER")
+	for _, route := range routes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", route.Method, route.Path, route.Handler)
+	}
+	tw.Flush()
+}
+
+// Create indexes of MongoDB collections, existing indexes are kept
+func runMigrate(configPath string, args []string, stdout io.Writer) error {
+	if err := newFlagSet("migrate", &configPath).Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	mongoDB, err := initMongoDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init MongoDB: %w", err)
+	}
+	defer mongoDB.Close()
+
+	indexNames, err := coreRepository.Migrate(context.Background(), mongoDB, &cfg.Repositories)
+	if err != nil {
+		return fmt.Errorf("failed to migrate MongoDB: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(indexNames)) {
+		fmt.Fprintf(stdout, "%s: %s\n", name, strings.Join(indexNames[name], ", "))
+	}
+	return nil
+}
+
+// Write documents of seed files into MongoDB collections, seed file of a collection is named by collection name
+func runSeed(configPath string, args []string, stdout io.Writer) error {
+	flagSet := newFlagSet("seed", &configPath)
+	dir := flagSet.String("dir", DefaultSeedDir, "Directory of seed files")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Service.Environment == coreService.Production {
+		return fmt.Errorf("seed is not allowed in %s environment", cfg.Service.Environment)
+	}
+
+	// Find seed files before connecting to MongoDB
+	mongoDBConfigs := cfg.Repositories.MongoDBConfigs()
+	seedFiles := map[coreRepository.RepositoryName]string{}
+	for name, repositoryCfg := range mongoDBConfigs {
+		seedFile := filepath.Join(*dir, repositoryCfg.Collection+".json")
+		if _, err := os.Stat(seedFile); err == nil {
+			seedFiles[name] = seedFile
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to stat seed file: %w", err)
+		}
+	}
+	if len(seedFiles) == 0 {
+		return fmt.Errorf("no seed file found in %s", *dir)
+	}
+
+	mongoDB, err := initMongoDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init MongoDB: %w", err)
+	}
+	defer mongoDB.Close()
+
+	for _, name := range slices.Sorted(maps.Keys(seedFiles)) {
+		data, err := os.ReadFile(seedFiles[name])
+		if err != nil {
+			return fmt.Errorf("failed to read seed file: %w", err)
+		}
+		count, err := coreRepository.Seed(context.Background(), mongoDB, mongoDBConfigs[name], data)
+		if err != nil {
+			return fmt.Errorf("failed to seed %s: %w", name, err)
+		}
+		fmt.Fprintf(stdout, "%s: %d documents written from %s\n", name, count, seedFiles[name])
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeConfigFile writes the config into a temporary file and returns its path
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "app.conf.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUsage(t *testing.T) {
+	output := &bytes.Buffer{}
+	usage(output)
+	for _, cmd := range commands {
+		assert.Contains(t, output.String(), cmd.name)
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+
+	validConfigPath := writeConfigFile(t, "ai:\n  fake: {}\n")
+	invalidConfigPath := writeConfigFile(t, "server:\n  port: 70000\n")
+	invalidPolicyConfigPath := writeConfigFile(t, "ai:\n  fake: {}\nauthz:\n  rules:\n    - role: user\n")
+	prodConfigPath := writeConfigFile(t, "service:\n  env: prod\n")
+
+	testCases := []struct {
+		name           string
+		args           []string
+		configPath     string
+		expectedOutput []string
+		isError        bool
+	}{
+		{
+			name:           "check-config",
+			args:           []string{"check-config"},
+			configPath:     validConfigPath,
+			expectedOutput: []string{"is valid"},
+		},
+		{
+			name:           "check-config/config-flag",
+			args:           []string{"check-config", "-config", validConfigPath},
+			configPath:     invalidConfigPath,
+			expectedOutput: []string{"is valid"},
+		},
+		{
+			name:       "check-config/invalid-config",
+			args:       []string{"check-config"},
+			configPath: invalidConfigPath,
+			isError:    true,
+		},
+		{
+			name:       "check-config/invalid-policy",
+			args:       []string{"check-config"},
+			configPath: invalidPolicyConfigPath,
+			isError:    true,
+		},
+		{
+			name:       "check-config/file-not-found",
+			args:       []string{"check-config"},
+			configPath: "non_existent_file.yaml",
+			isError:    true,
+		},
+		{
+			name:           "print-routes",
+			args:           []string{"print-routes"},
+			configPath:     validConfigPath,
+			expectedOutput: []string{"METHOD", "GET     /api/health/liveness", "POST    /api/v1/issue/:issue_id/close", "/swagger/*any"},
+		},
+		{
+			name:       "migrate/no-mongodb-config",
+			args:       []string{"migrate"},
+			configPath: validConfigPath,
+			isError:    true,
+		},
+		{
+			name:       "seed/prod",
+			args:       []string{"seed"},
+			configPath: prodConfigPath,
+			isError:    true,
+		},
+		{
+			name:       "seed/no-seed-file",
+			args:       []string{"seed", "-dir", t.TempDir()},
+			configPath: validConfigPath,
+			isError:    true,
+		},
+		{
+			name:       "unknown-command",
+			args:       []string{"unknown"},
+			configPath: validConfigPath,
+			isError:    true,
+		},
+		{
+			name:       "unknown-flag",
+			args:       []string{"check-config", "-unknown"},
+			configPath: validConfigPath,
+			isError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := runCommand(testCase.args, testCase.configPath, output)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				for _, expectedOutput := range testCase.expectedOutput {
+					assert.Contains(t, output.String(), expectedOutput)
+				}
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRunCommand_Help(t *testing.T) {
+	err := runCommand([]string{"seed", "-h"}, "non_existent_file.yaml", &bytes.Buffer{})
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+func TestPrintRoutes(t *testing.T) {
+	output := &bytes.Buffer{}
+	printRoutes(output, gin.RoutesInfo{
+		{Method: "POST", Path: "/b", Handler: "handlerB"},
+		{Method: "GET", Path: "/b", Handler: "handlerB"},
+		{Method: "GET", Path: "/a", Handler: "handlerA"},
+	})
+	assert.Equal(t, []string{
+		"METHOD  PATH  HANDLER",
+		"GET     /a    handlerA",
+		"GET     /b    handlerB",
+		"POST    /b    handlerB",
+	}, strings.Split(strings.TrimSpace(output.String()), "\n"))
+}
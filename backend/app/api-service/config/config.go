@@ -1,23 +1,33 @@
 package config
 
 import (
-	"os"
-
-	"gopkg.in/yaml.v3"
+	"time"
 
+	coreAI "github.com/STLeee/mediation-platform/backend/core/ai"
 	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
 	coreCache "github.com/STLeee/mediation-platform/backend/core/cache"
+	coreConfig "github.com/STLeee/mediation-platform/backend/core/config"
 	coreDB "github.com/STLeee/mediation-platform/backend/core/db"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreHealth "github.com/STLeee/mediation-platform/backend/core/health"
+	coreLifecycle "github.com/STLeee/mediation-platform/backend/core/lifecycle"
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
 	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 	coreService "github.com/STLeee/mediation-platform/backend/core/service"
+	coreTracing "github.com/STLeee/mediation-platform/backend/core/tracing"
 )
 
 const DefaultConfigPath = "conf/app.conf.yaml"
 
 type ServerConfig struct {
-	Host    string `yaml:"host" default:"localhost"`
-	Port    int    `yaml:"port" default:"8080"`
-	GinMode string `yaml:"gin_mode" default:"release" validate:"oneof=debug release test"`
+	Host string `yaml:"host" default:"localhost"`
+	Port int    `yaml:"port" default:"8080" validate:"min=1,max=65535"`
+	// MetricsPort is the port of internal metrics server, 0 disables it
+	MetricsPort int                  `yaml:"metrics_port" default:"9091" validate:"min=0,max=65535"`
+	GinMode     string               `yaml:"gin_mode" default:"release" validate:"oneof=debug release test"`
+	LogLevel    coreLogging.LogLevel `yaml:"log_level" default:"info" validate:"oneof=debug info warn error"`
 }
 
 type ServiceConfig struct {
@@ -25,25 +35,37 @@ type ServiceConfig struct {
 	Environment coreService.ServiceEnvironment `yaml:"env" default:"test" validate:"oneof=test stag prod"`
 }
 
+// AuthenticationConfig is a config of token authentication, zero DisabledSyncInterval never re-syncs disabled flag of user,
+// ProviderFields are profile fields authoritative from auth service, they are synced into database on login, none by
+// default since syncing them gets user info from auth service on every login missing the token cache
+type AuthenticationConfig struct {
+	DisabledSyncInterval time.Duration `yaml:"disabled_sync_interval" default:"10m"`
+	ProviderFields       []string      `yaml:"provider_fields" validate:"dive,oneof=display_name email phone_number photo_url"`
+}
+
 type Config struct {
-	Server       ServerConfig                     `yaml:"server"`
-	Service      ServiceConfig                    `yaml:"service"`
-	AuthService  coreAuth.AuthServiceConfig       `yaml:"auth_service"`
-	MongoDB      coreDB.MongoDBConfig             `yaml:"mongodb"`
-	RedisCache   coreCache.RedisCacheConfig       `yaml:"redis"`
-	Repositories coreRepository.RepositoryConfigs `yaml:"repositories"`
+	Server         ServerConfig                        `yaml:"server"`
+	Service        ServiceConfig                       `yaml:"service"`
+	Authentication AuthenticationConfig                `yaml:"authentication"`
+	AuthService    coreAuth.AuthServiceConfig          `yaml:"auth_service"`
+	MongoDB        coreDB.MongoDBConfig                `yaml:"mongodb"`
+	RedisCache     coreCache.RedisCacheConfig          `yaml:"redis"`
+	Repositories   coreRepository.RepositoryConfigs    `yaml:"repositories"`
+	Notification   coreNotification.NotificationConfig `yaml:"notification"`
+	AI             coreAI.CommentSuggesterConfig       `yaml:"ai"`
+	Authz          coreAuthz.PolicyConfig              `yaml:"authz"`
+	ErrorReport    coreErrReport.ErrorReporterConfig   `yaml:"error_report"`
+	Tracing        coreTracing.TracingConfig           `yaml:"tracing"`
+	HealthCheck    coreHealth.HealthCheckerConfig      `yaml:"health_check"`
+	Lifecycle      coreLifecycle.LifecycleConfig       `yaml:"lifecycle"`
 }
 
 var cfg *Config
 
+// LoadConfig loads config from the YAML file, with defaults, validation and overrides by MP_ environment variables
 func LoadConfig(path string) (*Config, error) {
 	loadedCfg := &Config{}
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	err = yaml.Unmarshal(data, loadedCfg)
-	if err != nil {
+	if err := coreConfig.Load(path, loadedCfg); err != nil {
 		return nil, err
 	}
 	cfg = loadedCfg
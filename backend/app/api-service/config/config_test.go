@@ -3,9 +3,14 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	coreConfig "github.com/STLeee/mediation-platform/backend/core/config"
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
 	coreService "github.com/STLeee/mediation-platform/backend/core/service"
 )
 
@@ -20,9 +25,38 @@ func TestLoadAndGetConfig(t *testing.T) {
 server:
   port: 9090
   gin_mode: debug
+  log_level: debug
 service:
   name: test-service
   env: test
+authentication:
+  disabled_sync_interval: 5m
+  provider_fields: [display_name, email]
+notification:
+  channels:
+    webhook:
+      url: http://localhost:8081/notification
+  routes:
+    issue_invited: [in_app, webhook]
+ai:
+  http:
+    url: http://localhost:8000/suggest
+    timeout: 10s
+error_report:
+  log:
+    with_stack: true
+  file:
+    path: /var/log/api-service/errors.log
+tracing:
+  otlp:
+    endpoint: localhost:4318
+    insecure: true
+    timeout: 5s
+health_check:
+  timeout: 1s
+  cache_ttl: 10s
+lifecycle:
+  shutdown_timeout: 20s
 `
 	_, err = tempFile.Write([]byte(configData))
 	assert.NoError(t, err)
@@ -34,8 +68,26 @@ service:
 	assert.NotNil(t, loadedCfg)
 	assert.Equal(t, 9090, loadedCfg.Server.Port)
 	assert.Equal(t, "debug", loadedCfg.Server.GinMode)
+	assert.Equal(t, coreLogging.LogLevelDebug, loadedCfg.Server.LogLevel)
 	assert.Equal(t, "test-service", loadedCfg.Service.Name)
 	assert.Equal(t, coreService.Testing, loadedCfg.Service.Environment)
+	assert.Equal(t, 5*time.Minute, loadedCfg.Authentication.DisabledSyncInterval)
+	assert.Equal(t, []string{"display_name", "email"}, loadedCfg.Authentication.ProviderFields)
+	assert.Equal(t, "http://localhost:8081/notification", loadedCfg.Notification.Channels.Webhook.URL)
+	assert.Nil(t, loadedCfg.Notification.Channels.Email)
+	assert.Equal(t, "http://localhost:8000/suggest", loadedCfg.AI.HTTPCommentSuggesterConfig.URL)
+	assert.Equal(t, 10*time.Second, loadedCfg.AI.HTTPCommentSuggesterConfig.Timeout)
+	assert.Nil(t, loadedCfg.AI.FakeCommentSuggesterConfig)
+	assert.True(t, loadedCfg.ErrorReport.Log.WithStack)
+	assert.Equal(t, "/var/log/api-service/errors.log", loadedCfg.ErrorReport.File.Path)
+	assert.Nil(t, loadedCfg.ErrorReport.HTTP)
+	assert.Equal(t, "localhost:4318", loadedCfg.Tracing.OTLP.Endpoint)
+	assert.True(t, loadedCfg.Tracing.OTLP.Insecure)
+	assert.Equal(t, 5*time.Second, loadedCfg.Tracing.OTLP.Timeout)
+	assert.Equal(t, 1*time.Second, loadedCfg.HealthCheck.Timeout)
+	assert.Equal(t, 10*time.Second, loadedCfg.HealthCheck.CacheTTL)
+	assert.Equal(t, 20*time.Second, loadedCfg.Lifecycle.ShutdownTimeout)
+	assert.Equal(t, []coreNotification.ChannelName{coreNotification.ChannelNameInApp, coreNotification.ChannelNameWebhook}, loadedCfg.Notification.Routes[coreModel.NotificationTypeIssueInvited])
 
 	// Ensure GetConfig returns the loaded config
 	gotCfg := GetConfig()
@@ -70,3 +122,87 @@ service:
 	_, err = LoadConfig(tempFile.Name())
 	assert.Error(t, err)
 }
+
+func TestLoadConfig_DefaultsAndEnv(t *testing.T) {
+	t.Setenv("TEST_REDIS_PASSWORD", "test-password")
+	t.Setenv("MP_MONGODB_URI", "mongodb://mongodb:27017")
+	t.Setenv("MP_SERVER_PORT", "9090")
+
+	// Create a temporary config file
+	tempFile, err := os.CreateTemp("", "test_config_*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+
+	// Secret is interpolated from environment variable
+	configData := `
+mongodb:
+  uri: mongodb://localhost:27017
+redis:
+  password: ${TEST_REDIS_PASSWORD}
+`
+	_, err = tempFile.Write([]byte(configData))
+	assert.NoError(t, err)
+	tempFile.Close()
+
+	// Defaults are applied and environment variables override the file
+	loadedCfg, err := LoadConfig(tempFile.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, ServerConfig{Host: "localhost", Port: 9090, MetricsPort: 9091, GinMode: "release", LogLevel: coreLogging.LogLevelInfo}, loadedCfg.Server)
+	assert.Equal(t, ServiceConfig{Name: "api-service", Environment: coreService.Testing}, loadedCfg.Service)
+	assert.Equal(t, 10*time.Minute, loadedCfg.Authentication.DisabledSyncInterval)
+	assert.Equal(t, "mongodb://mongodb:27017", loadedCfg.MongoDB.URI)
+	assert.Equal(t, "test-password", loadedCfg.RedisCache.Password)
+}
+
+func TestLoadConfig_ExplicitZero(t *testing.T) {
+	// Create a temporary config file
+	tempFile, err := os.CreateTemp("", "test_config_*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+
+	// Zero interval never re-syncs disabled flag and zero metrics port disables metrics server, they are not replaced
+	// by defaults
+	configData := `
+server:
+  metrics_port: 0
+service:
+  env: test
+authentication:
+  disabled_sync_interval: 0s
+`
+	_, err = tempFile.Write([]byte(configData))
+	assert.NoError(t, err)
+	tempFile.Close()
+
+	loadedCfg, err := LoadConfig(tempFile.Name())
+	assert.NoError(t, err)
+	assert.Zero(t, loadedCfg.Authentication.DisabledSyncInterval)
+	assert.Zero(t, loadedCfg.Server.MetricsPort)
+}
+
+func TestLoadConfig_ValidationError(t *testing.T) {
+	// Create a temporary config file
+	tempFile, err := os.CreateTemp("", "test_config_*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+
+	configData := `
+server:
+  gin_mode: verbose
+service:
+  env: dev
+authentication:
+  provider_fields: [role]
+`
+	_, err = tempFile.Write([]byte(configData))
+	assert.NoError(t, err)
+	tempFile.Close()
+
+	// All invalid fields are reported
+	_, err = LoadConfig(tempFile.Name())
+	assert.ErrorAs(t, err, &coreConfig.ConfigError{})
+	assert.Equal(t, coreConfig.ConfigErrorTypeValidationError, err.(coreConfig.ConfigError).ErrType)
+	assert.ErrorContains(t, err, "server.gin_mode must be one of [debug release test]")
+	assert.ErrorContains(t, err, "service.env must be one of [test stag prod]")
+	assert.ErrorContains(t, err, "authentication.provider_fields[0] must be one of [display_name email phone_number photo_url]")
+}
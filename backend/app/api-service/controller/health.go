@@ -1,21 +1,32 @@
 package controller
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreHealth "github.com/STLeee/mediation-platform/backend/core/health"
 )
 
 // HealthController is a controller for health check
 type HealthController struct {
 	BaseController
+	healthChecker *coreHealth.HealthChecker
 }
 
-// NewHealthController creates a new HealthController
-func NewHealthController() *HealthController {
-	return &HealthController{}
+// NewHealthController creates a new HealthController, readiness checks no dependency if health checker is nil
+func NewHealthController(healthChecker *coreHealth.HealthChecker) *HealthController {
+	if healthChecker == nil {
+		healthChecker = coreHealth.NewHealthChecker(nil)
+	}
+	return &HealthController{
+		healthChecker: healthChecker,
+	}
 }
 
 // @Summary Liveness check
-// @Description Liveness check
+// @Description Liveness check, it does not check dependencies
 // @Tags health
 // @Router /health/liveness [get]
 // @Produce json
@@ -25,11 +36,33 @@ func (hc *HealthController) Liveness(c *gin.Context) {
 }
 
 // @Summary Readiness check
-// @Description Readiness check
+// @Description Readiness check with status and latency of every dependency, results are cached briefly, it is down
+// @Description during shutdown
 // @Tags health
 // @Router /health/readiness [get]
 // @Produce json
-// @Success 200 {object} model.MessageResponse
+// @Success 200 {object} model.ReadinessResponse
+// @Failure 503 {object} model.ReadinessResponse
 func (hc *HealthController) Readiness(c *gin.Context) {
-	hc.ResponseOK(c)
+	report := hc.healthChecker.Check(c)
+
+	response := model.ReadinessResponse{
+		Status:       string(report.Status),
+		ShuttingDown: report.ShuttingDown,
+		CheckedAt:    report.CheckedAt,
+		Checks:       make(map[string]model.DependencyCheckResponse, len(report.Checks)),
+	}
+	for name, result := range report.Checks {
+		response.Checks[name] = model.DependencyCheckResponse{
+			Status:    string(result.Status),
+			Critical:  result.Critical,
+			LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+		}
+	}
+
+	statusCode := http.StatusOK
+	if report.Status != coreHealth.StatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+	c.JSON(statusCode, response)
 }
@@ -1,18 +1,23 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreHealth "github.com/STLeee/mediation-platform/backend/core/health"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
 )
 
 func TestHealthControllerLiveness(t *testing.T) {
 	routerRegisterFunc := func(r *gin.RouterGroup) {
-		healthController := NewHealthController()
+		healthController := NewHealthController(nil)
 		r.GET("/liveness", healthController.Liveness)
 	}
 	httpRecorder := utils.RegisterAndRecordHttpRequest(routerRegisterFunc, "GET", "/liveness", nil)
@@ -21,14 +26,96 @@ func TestHealthControllerLiveness(t *testing.T) {
 	expectedResponse := model.MessageResponse{Message: "ok"}
 	assert.Equal(t, utils.ConvertToJSONString(expectedResponse), httpRecorder.Body.String())
 }
+
 func TestHealthControllerReadiness(t *testing.T) {
-	routerRegisterFunc := func(r *gin.RouterGroup) {
-		healthController := NewHealthController()
-		r.GET("/readiness", healthController.Readiness)
+	up := coreHealth.CheckerFunc(func(ctx context.Context) error { return nil })
+	down := coreHealth.CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+
+	testCases := []struct {
+		name           string
+		dependencies   []coreHealth.Dependency
+		shuttingDown   bool
+		expectedCode   int
+		expectedStatus string
+		expectedChecks map[string]string
+	}{
+		{
+			name:           "no-dependencies",
+			dependencies:   nil,
+			expectedCode:   http.StatusOK,
+			expectedStatus: "up",
+			expectedChecks: map[string]string{},
+		},
+		{
+			name: "all-up",
+			dependencies: []coreHealth.Dependency{
+				{Name: "mongodb", Checker: up, Critical: true},
+				{Name: "redis", Checker: up},
+			},
+			expectedCode:   http.StatusOK,
+			expectedStatus: "up",
+			expectedChecks: map[string]string{"mongodb": "up", "redis": "up"},
+		},
+		{
+			name: "non-critical-down",
+			dependencies: []coreHealth.Dependency{
+				{Name: "mongodb", Checker: up, Critical: true},
+				{Name: "redis", Checker: down},
+			},
+			expectedCode:   http.StatusOK,
+			expectedStatus: "up",
+			expectedChecks: map[string]string{"mongodb": "up", "redis": "down"},
+		},
+		{
+			name: "critical-down",
+			dependencies: []coreHealth.Dependency{
+				{Name: "mongodb", Checker: down, Critical: true},
+				{Name: "redis", Checker: up},
+			},
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: "down",
+			expectedChecks: map[string]string{"mongodb": "down", "redis": "up"},
+		},
+		{
+			name: "shutting-down",
+			dependencies: []coreHealth.Dependency{
+				{Name: "mongodb", Checker: up, Critical: true},
+			},
+			shuttingDown:   true,
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: "down",
+			expectedChecks: map[string]string{},
+		},
 	}
-	httpRecorder := utils.RegisterAndRecordHttpRequest(routerRegisterFunc, "GET", "/readiness", nil)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			routerRegisterFunc := func(r *gin.RouterGroup) {
+				var healthChecker *coreHealth.HealthChecker
+				if testCase.dependencies != nil {
+					healthChecker = coreHealth.NewHealthChecker(nil, testCase.dependencies...)
+					if testCase.shuttingDown {
+						healthChecker.Shutdown(context.Background())
+					}
+				}
+				healthController := NewHealthController(healthChecker)
+				r.GET("/readiness", healthController.Readiness)
+			}
+			httpRecorder := utils.RegisterAndRecordHttpRequest(routerRegisterFunc, "GET", "/readiness", nil)
+			assert.Equal(t, testCase.expectedCode, httpRecorder.Code)
 
-	assert.Equal(t, 200, httpRecorder.Code)
-	expectedResponse := model.MessageResponse{Message: "ok"}
-	assert.Equal(t, utils.ConvertToJSONString(expectedResponse), httpRecorder.Body.String())
+			response := model.ReadinessResponse{}
+			assert.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+			assert.Equal(t, testCase.expectedStatus, response.Status)
+			assert.Equal(t, testCase.shuttingDown, response.ShuttingDown)
+			assert.False(t, response.CheckedAt.IsZero())
+			checks := map[string]string{}
+			for name, check := range response.Checks {
+				checks[name] = check.Status
+			}
+			assert.Equal(t, testCase.expectedChecks, checks)
+
+			// Errors of dependencies are logged rather than exposed
+			assert.NotContains(t, httpRecorder.Body.String(), "connection refused")
+		})
+	}
 }
@@ -0,0 +1,267 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+const (
+	DefaultListUsersLimit     = 20
+	DefaultListAuditLogsLimit = 20
+)
+
+// AdminController is a controller for administrators to manage users
+type AdminController struct {
+	authService    coreAuth.BaseAuthService
+	userDBRepo     coreRepository.UserDBRepository
+	userCacheRepo  coreRepository.UserCacheRepository
+	auditLogDBRepo coreRepository.AuditLogDBRepository
+}
+
+// NewAdminController creates a new AdminController
+func NewAdminController(authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, userCacheRepo coreRepository.UserCacheRepository, auditLogDBRepo coreRepository.AuditLogDBRepository) *AdminController {
+	return &AdminController{
+		authService:    authService,
+		userDBRepo:     userDBRepo,
+		userCacheRepo:  userCacheRepo,
+		auditLogDBRepo: auditLogDBRepo,
+	}
+}
+
+// newAdminUserResponse converts a user to response for administrators
+func newAdminUserResponse(user *coreModel.User) model.AdminUserResponse {
+	return model.AdminUserResponse{
+		UserID:      user.UserID,
+		FirebaseUID: user.FirebaseUID,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		PhotoURL:    user.PhotoURL,
+		Role:        string(user.GetRole()),
+		Disabled:    user.Disabled,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		LastLoginAt: user.LastLoginAt,
+	}
+}
+
+// newAuditLogResponse converts an audit log to response
+func newAuditLogResponse(auditLog *coreModel.AuditLog) model.AuditLogResponse {
+	return model.AuditLogResponse{
+		AuditLogID: auditLog.AuditLogID,
+		ActorID:    auditLog.ActorID,
+		Action:     string(auditLog.Action),
+		TargetID:   auditLog.TargetID,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+}
+
+// evictUserCache deletes all cached auth token users of the user
+func (ac *AdminController) evictUserCache(c *gin.Context, userID string) error {
+	if ac.userCacheRepo == nil {
+		return nil
+	}
+	return ac.userCacheRepo.InvalidateUser(c, userID)
+}
+
+// recordAuditLog records the action of administrator, failures do not fail the request
+func (ac *AdminController) recordAuditLog(c *gin.Context, actor *coreModel.User, action coreModel.AuditAction, targetID string) {
+	_, err := ac.auditLogDBRepo.CreateAuditLog(c, &coreModel.AuditLog{
+		ActorID:  actor.UserID,
+		Action:   action,
+		TargetID: targetID,
+	})
+	if err != nil {
+		coreErrReport.Record(c, fmt.Errorf("failed to record audit log %s of user %s: %w", action, targetID, err))
+	}
+}
+
+// @Summary List users
+// @Description List users for administrators, search by exact email, phone number or Firebase UID
+// @Tags admin
+// @Router /v1/admin/user [get]
+// @Security TokenAuth
+// @Param email query string false "Email"
+// @Param phone_number query string false "Phone number"
+// @Param firebase_uid query string false "Firebase UID"
+// @Param limit query int false "Limit" minimum(1) maximum(100) default(20)
+// @Param offset query int false "Offset" minimum(0) default(0)
+// @Param cursor query string false "Cursor of next page, can not be used with offset"
+// @Param with_total query bool false "Count total"
+// @Produce json
+// @Success 200 {object} model.PageResponse[model.AdminUserResponse]
+func (ac *AdminController) ListUsers(c *gin.Context) {
+	var request model.ListUsersRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+	pageOptions, err := bindPageRequest(c, DefaultListUsersLimit, coreRepository.DefaultPageSortField, false)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	page, err := ac.userDBRepo.ListUsers(c, &coreRepository.UserFilter{
+		Email:       request.Email,
+		PhoneNumber: request.PhoneNumber,
+		FirebaseUID: request.FirebaseUID,
+	}, pageOptions)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to list users"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(page, newAdminUserResponse))
+}
+
+// @Summary Disable user
+// @Description Disable user in database and auth service, cached tokens of the user are evicted
+// @Tags admin
+// @Router /v1/admin/user/{user_id}/disable [post]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Produce json
+// @Success 200 {object} model.AdminUserResponse
+func (ac *AdminController) DisableUser(c *gin.Context) {
+	ac.setUserDisabled(c, true)
+}
+
+// @Summary Enable user
+// @Description Enable user in database and auth service, cached failures of the user are evicted
+// @Tags admin
+// @Router /v1/admin/user/{user_id}/enable [post]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Produce json
+// @Success 200 {object} model.AdminUserResponse
+func (ac *AdminController) EnableUser(c *gin.Context) {
+	ac.setUserDisabled(c, false)
+}
+
+// setUserDisabled sets disabled of the user in path, auth service first so database follows the source of sign-in
+func (ac *AdminController) setUserDisabled(c *gin.Context, disabled bool) {
+	actor := c.MustGet("user").(*coreModel.User)
+
+	targetUserID := c.Param("user_id")
+	if disabled && targetUserID == actor.UserID {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, "administrator can not disable themselves", nil))
+		c.Abort()
+		return
+	}
+
+	user, err := ac.userDBRepo.GetUserByID(c, targetUserID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to get user"))
+		c.Abort()
+		return
+	}
+
+	if err := ac.authService.SetUserDisabled(c, user.FirebaseUID, disabled); err != nil {
+		c.Error(model.WrapError(err, "failed to set user disabled in auth service"))
+		c.Abort()
+		return
+	}
+	if err := ac.userDBRepo.UpdateUserByID(c, user.UserID, map[string]any{
+		"disabled":           disabled,
+		"disabled_synced_at": time.Now(),
+	}); err != nil {
+		c.Error(model.WrapError(err, "failed to update user"))
+		c.Abort()
+		return
+	}
+	if err := ac.evictUserCache(c, user.UserID); err != nil {
+		coreErrReport.Record(c, fmt.Errorf("failed to invalidate user cache: %w", err))
+	}
+
+	action := coreModel.AuditActionUserEnabled
+	if disabled {
+		action = coreModel.AuditActionUserDisabled
+	}
+	ac.recordAuditLog(c, actor, action, user.UserID)
+
+	user.Disabled = disabled
+	c.JSON(http.StatusOK, newAdminUserResponse(user))
+}
+
+// @Summary Force logout user
+// @Description Evict all cached tokens of the user, so every token is verified by auth service again
+// @Tags admin
+// @Router /v1/admin/user/{user_id}/logout [post]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Produce json
+// @Success 200 {object} model.MessageResponse
+func (ac *AdminController) ForceLogoutUser(c *gin.Context) {
+	actor := c.MustGet("user").(*coreModel.User)
+
+	user, err := ac.userDBRepo.GetUserByID(c, c.Param("user_id"))
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to get user"))
+		c.Abort()
+		return
+	}
+
+	if err := ac.evictUserCache(c, user.UserID); err != nil {
+		c.Error(model.WrapError(err, "failed to evict user cache"))
+		c.Abort()
+		return
+	}
+	ac.recordAuditLog(c, actor, coreModel.AuditActionUserForceLogout, user.UserID)
+
+	c.JSON(http.StatusOK, model.MessageResponse{Message: "ok"})
+}
+
+// @Summary List audit logs
+// @Description List audit logs of administrator actions, newest first
+// @Tags admin
+// @Router /v1/admin/audit-log [get]
+// @Security TokenAuth
+// @Param actor_id query string false "Actor user ID"
+// @Param target_id query string false "Target user ID"
+// @Param action query string false "Action" Enums(user_disabled, user_enabled, user_force_logout)
+// @Param limit query int false "Limit" minimum(1) maximum(100) default(20)
+// @Param offset query int false "Offset" minimum(0) default(0)
+// @Param cursor query string false "Cursor of next page, can not be used with offset"
+// @Param with_total query bool false "Count total"
+// @Produce json
+// @Success 200 {object} model.PageResponse[model.AuditLogResponse]
+func (ac *AdminController) ListAuditLogs(c *gin.Context) {
+	var request model.ListAuditLogsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+	pageOptions, err := bindPageRequest(c, DefaultListAuditLogsLimit, coreRepository.DefaultPageSortField, true)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	page, err := ac.auditLogDBRepo.ListAuditLogs(c, &coreRepository.AuditLogFilter{
+		ActorID:  request.ActorID,
+		TargetID: request.TargetID,
+		Action:   coreModel.AuditAction(request.Action),
+	}, pageOptions)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to list audit logs"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(page, newAuditLogResponse))
+}
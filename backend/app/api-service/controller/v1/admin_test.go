@@ -0,0 +1,372 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+type MockAuditLogDBRepository struct {
+	CreateAuditLogFunc func(ctx context.Context, auditLog *coreModel.AuditLog) (string, error)
+	ListAuditLogsFunc  func(ctx context.Context, filter *coreRepository.AuditLogFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.AuditLog], error)
+}
+
+func (repo *MockAuditLogDBRepository) CreateAuditLog(ctx context.Context, auditLog *coreModel.AuditLog) (string, error) {
+	return repo.CreateAuditLogFunc(ctx, auditLog)
+}
+
+func (repo *MockAuditLogDBRepository) ListAuditLogs(ctx context.Context, filter *coreRepository.AuditLogFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.AuditLog], error) {
+	return repo.ListAuditLogsFunc(ctx, filter, pageOptions)
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+func newMockAdmin() *coreModel.User {
+	return &coreModel.User{
+		UserID:      "test-admin-id",
+		FirebaseUID: "test-admin-firebase-uid",
+		DisplayName: "test-admin",
+		Role:        coreModel.UserRoleAdmin,
+	}
+}
+
+func recordAdminControllerRequest(t *testing.T, authService *MockAuthService, userDBRepo *MockUserDBRepository, userCacheRepo *MockUserCacheRepository, auditLogDBRepo *MockAuditLogDBRepository, method, path, body string) (int, string) {
+	adminController := NewAdminController(authService, userDBRepo, userCacheRepo, auditLogDBRepo)
+	var statusCode int
+	httpRecorder := utils.RegisterAndRecordHttpRequest(
+		func(router *gin.RouterGroup) {
+			router.Use(func(ctx *gin.Context) {
+				// Set admin to context
+				ctx.Set("user", newMockAdmin())
+				ctx.Next()
+
+				// Get status code from error
+				if err := ctx.Errors.Last(); err != nil {
+					statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+				}
+			})
+			router.GET("/user", adminController.ListUsers)
+			router.POST("/user/:user_id/disable", adminController.DisableUser)
+			router.POST("/user/:user_id/enable", adminController.EnableUser)
+			router.POST("/user/:user_id/logout", adminController.ForceLogoutUser)
+			router.GET("/audit-log", adminController.ListAuditLogs)
+		},
+		method,
+		path,
+		strings.NewReader(body),
+	)
+	if statusCode == 0 {
+		statusCode = httpRecorder.Code
+	}
+	return statusCode, httpRecorder.Body.String()
+}
+
+func TestListUsers(t *testing.T) {
+	testCases := []struct {
+		name                string
+		query               string
+		expectedFilter      *coreRepository.UserFilter
+		expectedPageOptions *coreRepository.PageOptions
+		listUsersErr        error
+		statusCode          int
+	}{
+		{
+			name:                "list-all",
+			query:               "",
+			expectedFilter:      &coreRepository.UserFilter{},
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListUsersLimit, SortField: coreRepository.DefaultPageSortField},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:  "search",
+			query: "?email=test@mediation-platform.com&phone_number=%2B886912345678&firebase_uid=test-firebase-uid&limit=5&cursor=test-cursor",
+			expectedFilter: &coreRepository.UserFilter{
+				Email:       "test@mediation-platform.com",
+				PhoneNumber: "+886912345678",
+				FirebaseUID: "test-firebase-uid",
+			},
+			expectedPageOptions: &coreRepository.PageOptions{Limit: 5, Cursor: "test-cursor", SortField: coreRepository.DefaultPageSortField},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:       "invalid-request/invalid-email",
+			query:      "?email=not-email",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-request/invalid-limit",
+			query:      "?limit=0&offset=-1",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:                "db-error/invalid-cursor",
+			query:               "?cursor=invalid-cursor",
+			expectedFilter:      &coreRepository.UserFilter{},
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListUsersLimit, Cursor: "invalid-cursor", SortField: coreRepository.DefaultPageSortField},
+			listUsersErr:        coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeInvalidCursor},
+			statusCode:          http.StatusBadRequest,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			userDBRepo := &MockUserDBRepository{
+				ListUsersFunc: func(ctx context.Context, filter *coreRepository.UserFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.User], error) {
+					assert.Equal(t, testCase.expectedFilter, filter)
+					assert.Equal(t, testCase.expectedPageOptions, pageOptions)
+					if testCase.listUsersErr != nil {
+						return nil, testCase.listUsersErr
+					}
+					return &coreRepository.Page[*coreModel.User]{
+						Items:      []*coreModel.User{newMockUser()},
+						NextCursor: "next-cursor",
+					}, nil
+				},
+			}
+			statusCode, body := recordAdminControllerRequest(t, nil, userDBRepo, nil, nil, "GET", "/user"+testCase.query, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				expected := model.PageResponse[model.AdminUserResponse]{
+					Items:      []model.AdminUserResponse{newAdminUserResponse(newMockUser())},
+					NextCursor: "next-cursor",
+				}
+				assert.Equal(t, utils.ConvertToJSONString(expected), body)
+			}
+		})
+	}
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	testCases := []struct {
+		name             string
+		path             string
+		getUserErr       error
+		setDisabledErr   error
+		updateUserErr    error
+		expectedDisabled *bool
+		expectedAction   coreModel.AuditAction
+		statusCode       int
+	}{
+		{
+			name:             "disable",
+			path:             "/user/test-user-id/disable",
+			expectedDisabled: boolPointer(true),
+			expectedAction:   coreModel.AuditActionUserDisabled,
+			statusCode:       http.StatusOK,
+		},
+		{
+			name:             "enable",
+			path:             "/user/test-user-id/enable",
+			expectedDisabled: boolPointer(false),
+			expectedAction:   coreModel.AuditActionUserEnabled,
+			statusCode:       http.StatusOK,
+		},
+		{
+			name:       "disable-self",
+			path:       "/user/test-admin-id/disable",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "user-not-found",
+			path:       "/user/test-user-id/disable",
+			getUserErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:           "auth-service-error",
+			path:           "/user/test-user-id/disable",
+			setDisabledErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
+			statusCode:     http.StatusInternalServerError,
+		},
+		{
+			name:             "db-error",
+			path:             "/user/test-user-id/disable",
+			updateUserErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			expectedDisabled: boolPointer(true),
+			statusCode:       http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			user := newMockUser()
+			var disabled *bool
+			authService := &MockAuthService{
+				SetUserDisabledFunc: func(ctx context.Context, uid string, isDisabled bool) error {
+					assert.Equal(t, user.FirebaseUID, uid)
+					if testCase.setDisabledErr != nil {
+						return testCase.setDisabledErr
+					}
+					disabled = &isDisabled
+					return nil
+				},
+			}
+			userDBRepo := &MockUserDBRepository{
+				GetUserByIDFunc: func(ctx context.Context, userID string) (*coreModel.User, error) {
+					assert.Equal(t, user.UserID, userID)
+					if testCase.getUserErr != nil {
+						return nil, testCase.getUserErr
+					}
+					return user, nil
+				},
+				UpdateUserByIDFunc: func(ctx context.Context, userID string, updateData map[string]any) error {
+					assert.Equal(t, user.UserID, userID)
+					assert.Equal(t, *disabled, updateData["disabled"])
+					assert.True(t, utils.SimplyValidTimestamp(updateData["disabled_synced_at"].(time.Time)))
+					return testCase.updateUserErr
+				},
+			}
+			userCacheRepo := &MockUserCacheRepository{}
+			auditLogs := []*coreModel.AuditLog{}
+			auditLogDBRepo := &MockAuditLogDBRepository{
+				CreateAuditLogFunc: func(ctx context.Context, auditLog *coreModel.AuditLog) (string, error) {
+					auditLogs = append(auditLogs, auditLog)
+					return "test-audit-log-id", nil
+				},
+			}
+			statusCode, body := recordAdminControllerRequest(t, authService, userDBRepo, userCacheRepo, auditLogDBRepo, "POST", testCase.path, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.Equal(t, testCase.expectedDisabled, disabled)
+			if statusCode == http.StatusOK {
+				assert.Contains(t, body, `"disabled":`+utils.ConvertToJSONString(*testCase.expectedDisabled))
+				assert.Equal(t, []string{user.UserID}, userCacheRepo.InvalidatedUserIDs)
+				assert.Equal(t, []*coreModel.AuditLog{{
+					ActorID:  "test-admin-id",
+					Action:   testCase.expectedAction,
+					TargetID: user.UserID,
+				}}, auditLogs)
+			} else {
+				assert.Empty(t, userCacheRepo.InvalidatedUserIDs)
+				assert.Empty(t, auditLogs)
+			}
+		})
+	}
+}
+
+func TestForceLogoutUser(t *testing.T) {
+	testCases := []struct {
+		name              string
+		getUserErr        error
+		invalidateUserErr error
+		statusCode        int
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "user-not-found",
+			getUserErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:              "cache-error",
+			invalidateUserErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:        http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			user := newMockUser()
+			userDBRepo := &MockUserDBRepository{
+				GetUserByIDFunc: func(ctx context.Context, userID string) (*coreModel.User, error) {
+					if testCase.getUserErr != nil {
+						return nil, testCase.getUserErr
+					}
+					return user, nil
+				},
+			}
+			userCacheRepo := &MockUserCacheRepository{InvalidateUserErr: testCase.invalidateUserErr}
+			auditLogs := []*coreModel.AuditLog{}
+			auditLogDBRepo := &MockAuditLogDBRepository{
+				CreateAuditLogFunc: func(ctx context.Context, auditLog *coreModel.AuditLog) (string, error) {
+					auditLogs = append(auditLogs, auditLog)
+					return "test-audit-log-id", nil
+				},
+			}
+			statusCode, _ := recordAdminControllerRequest(t, nil, userDBRepo, userCacheRepo, auditLogDBRepo, "POST", "/user/"+user.UserID+"/logout", "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, []string{user.UserID}, userCacheRepo.InvalidatedUserIDs)
+				assert.Len(t, auditLogs, 1)
+				assert.Equal(t, coreModel.AuditActionUserForceLogout, auditLogs[0].Action)
+			} else {
+				assert.Empty(t, userCacheRepo.InvalidatedUserIDs)
+				assert.Empty(t, auditLogs)
+			}
+		})
+	}
+}
+
+func TestListAuditLogs(t *testing.T) {
+	testCases := []struct {
+		name                string
+		query               string
+		expectedFilter      *coreRepository.AuditLogFilter
+		expectedPageOptions *coreRepository.PageOptions
+		statusCode          int
+	}{
+		{
+			name:                "list-all",
+			query:               "",
+			expectedFilter:      &coreRepository.AuditLogFilter{},
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListAuditLogsLimit, SortField: coreRepository.DefaultPageSortField, Descending: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:  "filter",
+			query: "?actor_id=test-admin-id&target_id=test-user-id&action=user_disabled&with_total=true",
+			expectedFilter: &coreRepository.AuditLogFilter{
+				ActorID:  "test-admin-id",
+				TargetID: "test-user-id",
+				Action:   coreModel.AuditActionUserDisabled,
+			},
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListAuditLogsLimit, SortField: coreRepository.DefaultPageSortField, Descending: true, WithTotal: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:       "invalid-request/invalid-action",
+			query:      "?action=unknown",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			auditLog := &coreModel.AuditLog{
+				AuditLogID: "test-audit-log-id",
+				ActorID:    "test-admin-id",
+				Action:     coreModel.AuditActionUserDisabled,
+				TargetID:   "test-user-id",
+				CreatedAt:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			}
+			auditLogDBRepo := &MockAuditLogDBRepository{
+				ListAuditLogsFunc: func(ctx context.Context, filter *coreRepository.AuditLogFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.AuditLog], error) {
+					assert.Equal(t, testCase.expectedFilter, filter)
+					assert.Equal(t, testCase.expectedPageOptions, pageOptions)
+					return &coreRepository.Page[*coreModel.AuditLog]{
+						Items: []*coreModel.AuditLog{auditLog},
+					}, nil
+				},
+			}
+			statusCode, body := recordAdminControllerRequest(t, nil, nil, nil, auditLogDBRepo, "GET", "/audit-log"+testCase.query, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				expected := model.PageResponse[model.AuditLogResponse]{
+					Items: []model.AuditLogResponse{newAuditLogResponse(auditLog)},
+				}
+				assert.Equal(t, utils.ConvertToJSONString(expected), body)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAI "github.com/STLeee/mediation-platform/backend/core/ai"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// AICommentThreadLimit is the max number of latest comments sent to the comment suggester
+const AICommentThreadLimit = 100
+
+// AICommentController is a controller for AI generated mediator comments
+type AICommentController struct {
+	commentDBRepo    coreRepository.CommentDBRepository
+	commentSuggester coreAI.CommentSuggester
+	notifier         coreNotification.Notifier
+}
+
+// NewAICommentController creates a new AICommentController
+func NewAICommentController(commentDBRepo coreRepository.CommentDBRepository, commentSuggester coreAI.CommentSuggester, notifier coreNotification.Notifier) *AICommentController {
+	return &AICommentController{
+		commentDBRepo:    commentDBRepo,
+		commentSuggester: commentSuggester,
+		notifier:         notifier,
+	}
+}
+
+// @Summary Generate AI comment
+// @Description Generate a neutral mediator comment from the issue and its comments, and store it as an AI generated comment
+// @Tags comment
+// @Router /v1/issue/{issue_id}/ai-comment [post]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Produce json
+// @Success 201 {object} model.CommentResponse
+func (acc *AICommentController) GenerateAIComment(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+	issue := c.MustGet("issue").(*coreModel.Issue)
+
+	if issue.Status == coreModel.IssueStatusClosed {
+		c.Error(model.NewError(model.ErrorCodeConflict, "issue is closed", nil))
+		c.Abort()
+		return
+	}
+
+	// Get the latest comments as thread in chronological order, deleted comments have no body to share
+	comments, err := acc.commentDBRepo.ListLatestCommentsByIssueID(c, issue.IssueID, AICommentThreadLimit)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to list comments"))
+		c.Abort()
+		return
+	}
+	slices.Reverse(comments)
+	thread := make([]*coreModel.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.Deleted {
+			thread = append(thread, comment)
+		}
+	}
+
+	// Suggest comment
+	body, err := acc.commentSuggester.SuggestComment(c, issue, thread)
+	if err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadGateway, "failed to generate AI comment", err))
+		c.Abort()
+		return
+	}
+
+	// The requester is recorded as author, the comment is flagged so it can be rendered differently
+	comment := &coreModel.Comment{
+		IssueID:       issue.IssueID,
+		AuthorID:      user.UserID,
+		Body:          body,
+		IsAIGenerated: true,
+	}
+	commentID, err := acc.commentDBRepo.CreateComment(c, comment)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to create comment"))
+		c.Abort()
+		return
+	}
+	comment.CommentID = commentID
+
+	notifyParticipants(c, acc.notifier, issue, user.UserID, coreModel.NotificationTypeCommentCreated, map[string]any{
+		"issue_id":        issue.IssueID,
+		"comment_id":      comment.CommentID,
+		"actor_id":        user.UserID,
+		"is_ai_generated": true,
+	})
+
+	c.JSON(http.StatusCreated, newCommentResponse(comment))
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAI "github.com/STLeee/mediation-platform/backend/core/ai"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+type MockCommentSuggester struct {
+	SuggestCommentFunc func(ctx context.Context, issue *coreModel.Issue, comments []*coreModel.Comment) (string, error)
+}
+
+func (suggester *MockCommentSuggester) GetName() coreAI.CommentSuggesterName {
+	return coreAI.CommentSuggesterNameFake
+}
+
+func (suggester *MockCommentSuggester) SuggestComment(ctx context.Context, issue *coreModel.Issue, comments []*coreModel.Comment) (string, error) {
+	return suggester.SuggestCommentFunc(ctx, issue, comments)
+}
+
+func TestGenerateAIComment(t *testing.T) {
+	testCases := []struct {
+		name             string
+		issueStatus      coreModel.IssueStatus
+		listCommentsErr  error
+		suggestErr       error
+		createCommentErr error
+		statusCode       int
+	}{
+		{
+			name:        "success",
+			issueStatus: coreModel.IssueStatusOpen,
+			statusCode:  http.StatusCreated,
+		},
+		{
+			name:        "issue-closed",
+			issueStatus: coreModel.IssueStatusClosed,
+			statusCode:  http.StatusConflict,
+		},
+		{
+			name:            "list-comments-error",
+			issueStatus:     coreModel.IssueStatusOpen,
+			listCommentsErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:      http.StatusInternalServerError,
+		},
+		{
+			name:        "suggest-error",
+			issueStatus: coreModel.IssueStatusOpen,
+			suggestErr:  coreAI.AIError{ErrType: coreAI.AIErrorTypeProviderError},
+			statusCode:  http.StatusBadGateway,
+		},
+		{
+			name:             "create-comment-error",
+			issueStatus:      coreModel.IssueStatusOpen,
+			createCommentErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:       http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(testCase.issueStatus)
+			olderComment := newMockComment(issue.IssueID)
+			deletedComment := newMockComment(issue.IssueID)
+			deletedComment.CommentID = "200000000000000000000002"
+			deletedComment.Body = ""
+			deletedComment.Deleted = true
+			newerComment := newMockComment(issue.IssueID)
+			newerComment.CommentID = "200000000000000000000004"
+			newerComment.CreatedAt = olderComment.CreatedAt.Add(time.Hour)
+
+			repo := &MockCommentDBRepository{
+				ListLatestCommentsByIssueIDFunc: func(ctx context.Context, issueID string, limit int64) ([]*coreModel.Comment, error) {
+					assert.Equal(t, issue.IssueID, issueID)
+					assert.Equal(t, int64(AICommentThreadLimit), limit)
+					return []*coreModel.Comment{newerComment, deletedComment, olderComment}, testCase.listCommentsErr
+				},
+				CreateCommentFunc: func(ctx context.Context, comment *coreModel.Comment) (string, error) {
+					assert.Equal(t, issue.IssueID, comment.IssueID)
+					assert.Equal(t, mockIssueParty.UserID, comment.AuthorID)
+					assert.Equal(t, "test-ai-comment", comment.Body)
+					assert.True(t, comment.IsAIGenerated)
+					return "200000000000000000000003", testCase.createCommentErr
+				},
+			}
+			suggester := &MockCommentSuggester{
+				SuggestCommentFunc: func(ctx context.Context, suggestIssue *coreModel.Issue, thread []*coreModel.Comment) (string, error) {
+					assert.Equal(t, issue, suggestIssue)
+					// Thread is in chronological order without deleted comments
+					assert.Equal(t, []*coreModel.Comment{olderComment, newerComment}, thread)
+					return "test-ai-comment", testCase.suggestErr
+				},
+			}
+			notifier := &MockNotifier{}
+
+			aiCommentController := NewAICommentController(repo, suggester, notifier)
+			var statusCode int
+			httpRecorder := utils.RegisterAndRecordHttpRequest(
+				func(router *gin.RouterGroup) {
+					router.Use(func(ctx *gin.Context) {
+						// Set user and issue to context
+						ctx.Set("user", mockIssueParty)
+						ctx.Set("issue", issue)
+						ctx.Next()
+
+						// Get status code from error
+						if err := ctx.Errors.Last(); err != nil {
+							statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+						}
+					})
+					router.POST("", aiCommentController.GenerateAIComment)
+				},
+				"POST",
+				"/",
+				strings.NewReader(""),
+			)
+			if statusCode == 0 {
+				statusCode = httpRecorder.Code
+			}
+
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusCreated {
+				body := httpRecorder.Body.String()
+				assert.Contains(t, body, `"comment_id":"200000000000000000000003"`)
+				assert.Contains(t, body, `"is_ai_generated":true`)
+				assert.Equal(t, []string{mockIssueCreator.UserID}, notifier.RecipientIDs())
+			} else {
+				assert.Empty(t, notifier.Notifications)
+			}
+		})
+	}
+}
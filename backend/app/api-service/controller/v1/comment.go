@@ -0,0 +1,240 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+const DefaultListCommentsLimit = 50
+
+// CommentController is a controller for comments on issue
+type CommentController struct {
+	commentDBRepo coreRepository.CommentDBRepository
+	notifier      coreNotification.Notifier
+}
+
+// NewCommentController creates a new CommentController
+func NewCommentController(commentDBRepo coreRepository.CommentDBRepository, notifier coreNotification.Notifier) *CommentController {
+	return &CommentController{
+		commentDBRepo: commentDBRepo,
+		notifier:      notifier,
+	}
+}
+
+// newCommentResponse converts a comment to response
+func newCommentResponse(comment *coreModel.Comment) model.CommentResponse {
+	return model.CommentResponse{
+		CommentID:       comment.CommentID,
+		IssueID:         comment.IssueID,
+		AuthorID:        comment.AuthorID,
+		Body:            comment.Body,
+		ParentCommentID: comment.ParentCommentID,
+		Edited:          comment.Edited,
+		Deleted:         comment.Deleted,
+		IsAIGenerated:   comment.IsAIGenerated,
+		CreatedAt:       comment.CreatedAt,
+		UpdatedAt:       comment.UpdatedAt,
+	}
+}
+
+// getCommentOfIssue gets the comment in path and checks if it belongs to the issue
+func (cc *CommentController) getCommentOfIssue(c *gin.Context, issue *coreModel.Issue, commentID string) (*coreModel.Comment, error) {
+	comment, err := cc.commentDBRepo.GetCommentByID(c, commentID)
+	if err != nil {
+		return nil, model.WrapError(err, "failed to get comment")
+	}
+	if comment.IssueID != issue.IssueID {
+		return nil, model.NewError(model.ErrorCodeNotFound, "", nil)
+	}
+	return comment, nil
+}
+
+// getEditableComment gets the comment in path and checks if the user can modify it
+func (cc *CommentController) getEditableComment(c *gin.Context, issue *coreModel.Issue, user *coreModel.User) (*coreModel.Comment, error) {
+	comment, err := cc.getCommentOfIssue(c, issue, c.Param("comment_id"))
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != user.UserID {
+		return nil, model.NewError(model.ErrorCodeForbidden, "only the author can modify the comment", nil)
+	}
+	if comment.Deleted {
+		return nil, model.NewError(model.ErrorCodeConflict, "comment is deleted", nil)
+	}
+	return comment, nil
+}
+
+// @Summary Create comment
+// @Description Create a comment on the issue, set parent comment ID to reply to a comment
+// @Tags comment
+// @Router /v1/issue/{issue_id}/comment [post]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Param request body model.CreateCommentRequest true "Comment"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.CommentResponse
+func (cc *CommentController) CreateComment(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+	issue := c.MustGet("issue").(*coreModel.Issue)
+
+	var request model.CreateCommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+
+	if issue.Status == coreModel.IssueStatusClosed {
+		c.Error(model.NewError(model.ErrorCodeConflict, "issue is closed", nil))
+		c.Abort()
+		return
+	}
+
+	// Check parent comment
+	if request.ParentCommentID != "" {
+		if _, err := cc.getCommentOfIssue(c, issue, request.ParentCommentID); err != nil {
+			c.Error(model.NewError(model.ErrorCodeBadRequest, "parent comment not found", err))
+			c.Abort()
+			return
+		}
+	}
+
+	comment := &coreModel.Comment{
+		IssueID:         issue.IssueID,
+		AuthorID:        user.UserID,
+		Body:            request.Body,
+		ParentCommentID: request.ParentCommentID,
+	}
+	commentID, err := cc.commentDBRepo.CreateComment(c, comment)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to create comment"))
+		c.Abort()
+		return
+	}
+	comment.CommentID = commentID
+
+	notifyParticipants(c, cc.notifier, issue, user.UserID, coreModel.NotificationTypeCommentCreated, map[string]any{
+		"issue_id":   issue.IssueID,
+		"comment_id": comment.CommentID,
+		"actor_id":   user.UserID,
+	})
+
+	c.JSON(http.StatusCreated, newCommentResponse(comment))
+}
+
+// @Summary List comments
+// @Description List comments of the issue, oldest first
+// @Tags comment
+// @Router /v1/issue/{issue_id}/comment [get]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Param limit query int false "Limit" minimum(1) maximum(100) default(50)
+// @Param offset query int false "Offset" minimum(0) default(0)
+// @Param cursor query string false "Cursor of next page, can not be used with offset"
+// @Param with_total query bool false "Count total"
+// @Produce json
+// @Success 200 {object} model.PageResponse[model.CommentResponse]
+func (cc *CommentController) ListComments(c *gin.Context) {
+	issue := c.MustGet("issue").(*coreModel.Issue)
+
+	pageOptions, err := bindPageRequest(c, DefaultListCommentsLimit, "created_at", false)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	page, err := cc.commentDBRepo.ListCommentsByIssueID(c, issue.IssueID, pageOptions)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to list comments"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(page, newCommentResponse))
+}
+
+// @Summary Edit comment
+// @Description Edit a comment, only the author can edit it, AI generated comments cannot be edited
+// @Tags comment
+// @Router /v1/issue/{issue_id}/comment/{comment_id} [patch]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Param comment_id path string true "Comment ID"
+// @Param request body model.EditCommentRequest true "Comment"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.CommentResponse
+func (cc *CommentController) EditComment(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+	issue := c.MustGet("issue").(*coreModel.Issue)
+
+	var request model.EditCommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+
+	comment, err := cc.getEditableComment(c, issue, user)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	if comment.IsAIGenerated {
+		c.Error(model.NewError(model.ErrorCodeConflict, "AI generated comment cannot be edited", nil))
+		c.Abort()
+		return
+	}
+
+	if err := cc.commentDBRepo.EditCommentByID(c, comment.CommentID, request.Body); err != nil {
+		c.Error(model.WrapError(err, "failed to edit comment"))
+		c.Abort()
+		return
+	}
+
+	comment, err = cc.commentDBRepo.GetCommentByID(c, comment.CommentID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to get comment"))
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, newCommentResponse(comment))
+}
+
+// @Summary Delete comment
+// @Description Soft delete a comment, only the author can delete it, replies are kept
+// @Tags comment
+// @Router /v1/issue/{issue_id}/comment/{comment_id} [delete]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Param comment_id path string true "Comment ID"
+// @Produce json
+// @Success 200 {object} model.MessageResponse
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+	issue := c.MustGet("issue").(*coreModel.Issue)
+
+	comment, err := cc.getEditableComment(c, issue, user)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if err := cc.commentDBRepo.SoftDeleteCommentByID(c, comment.CommentID); err != nil {
+		c.Error(model.WrapError(err, "failed to delete comment"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, model.MessageResponse{Message: "ok"})
+}
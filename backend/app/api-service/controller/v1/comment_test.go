@@ -0,0 +1,398 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+type MockCommentDBRepository struct {
+	CreateCommentFunc               func(ctx context.Context, comment *coreModel.Comment) (string, error)
+	GetCommentByIDFunc              func(ctx context.Context, commentID string) (*coreModel.Comment, error)
+	ListCommentsByIssueIDFunc       func(ctx context.Context, issueID string, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Comment], error)
+	ListLatestCommentsByIssueIDFunc func(ctx context.Context, issueID string, limit int64) ([]*coreModel.Comment, error)
+	EditCommentByIDFunc             func(ctx context.Context, commentID string, body string) error
+	SoftDeleteCommentByIDFunc       func(ctx context.Context, commentID string) error
+}
+
+func (repo *MockCommentDBRepository) CreateComment(ctx context.Context, comment *coreModel.Comment) (string, error) {
+	return repo.CreateCommentFunc(ctx, comment)
+}
+
+func (repo *MockCommentDBRepository) GetCommentByID(ctx context.Context, commentID string) (*coreModel.Comment, error) {
+	return repo.GetCommentByIDFunc(ctx, commentID)
+}
+
+func (repo *MockCommentDBRepository) ListCommentsByIssueID(ctx context.Context, issueID string, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Comment], error) {
+	return repo.ListCommentsByIssueIDFunc(ctx, issueID, pageOptions)
+}
+
+func (repo *MockCommentDBRepository) ListLatestCommentsByIssueID(ctx context.Context, issueID string, limit int64) ([]*coreModel.Comment, error) {
+	return repo.ListLatestCommentsByIssueIDFunc(ctx, issueID, limit)
+}
+
+func (repo *MockCommentDBRepository) EditCommentByID(ctx context.Context, commentID string, body string) error {
+	return repo.EditCommentByIDFunc(ctx, commentID, body)
+}
+
+func (repo *MockCommentDBRepository) SoftDeleteCommentByID(ctx context.Context, commentID string) error {
+	return repo.SoftDeleteCommentByIDFunc(ctx, commentID)
+}
+
+func newMockComment(issueID string) *coreModel.Comment {
+	return &coreModel.Comment{
+		CommentID: "200000000000000000000001",
+		IssueID:   issueID,
+		AuthorID:  mockIssueCreator.UserID,
+		Body:      "test-comment",
+		CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func recordCommentControllerRequest(t *testing.T, tokenUser *coreModel.User, issue *coreModel.Issue, repo *MockCommentDBRepository, notifier *MockNotifier, method, path, body string) (int, string) {
+	commentController := NewCommentController(repo, notifier)
+	var statusCode int
+	httpRecorder := utils.RegisterAndRecordHttpRequest(
+		func(router *gin.RouterGroup) {
+			router.Use(func(ctx *gin.Context) {
+				// Set user and issue to context
+				ctx.Set("user", tokenUser)
+				ctx.Set("issue", issue)
+				ctx.Next()
+
+				// Get status code from error
+				if err := ctx.Errors.Last(); err != nil {
+					statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+				}
+			})
+			router.POST("", commentController.CreateComment)
+			router.GET("", commentController.ListComments)
+			router.PATCH("/:comment_id", commentController.EditComment)
+			router.DELETE("/:comment_id", commentController.DeleteComment)
+		},
+		method,
+		path,
+		strings.NewReader(body),
+	)
+	if statusCode == 0 {
+		statusCode = httpRecorder.Code
+	}
+	return statusCode, httpRecorder.Body.String()
+}
+
+func TestCreateComment(t *testing.T) {
+	testCases := []struct {
+		name             string
+		issueStatus      coreModel.IssueStatus
+		body             string
+		parentIssueID    string
+		getParentErr     error
+		createCommentErr error
+		statusCode       int
+	}{
+		{
+			name:        "success",
+			issueStatus: coreModel.IssueStatusOpen,
+			body:        `{"body":"test-comment"}`,
+			statusCode:  http.StatusCreated,
+		},
+		{
+			name:          "success/reply",
+			issueStatus:   coreModel.IssueStatusOpen,
+			body:          `{"body":"test-reply","parent_comment_id":"200000000000000000000001"}`,
+			parentIssueID: "100000000000000000000001",
+			statusCode:    http.StatusCreated,
+		},
+		{
+			name:          "reply/parent-in-other-issue",
+			issueStatus:   coreModel.IssueStatusOpen,
+			body:          `{"body":"test-reply","parent_comment_id":"200000000000000000000001"}`,
+			parentIssueID: "100000000000000000000002",
+			statusCode:    http.StatusBadRequest,
+		},
+		{
+			name:         "reply/parent-not-found",
+			issueStatus:  coreModel.IssueStatusOpen,
+			body:         `{"body":"test-reply","parent_comment_id":"200000000000000000000001"}`,
+			getParentErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:        "invalid-request/no-body",
+			issueStatus: coreModel.IssueStatusOpen,
+			body:        `{}`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "issue-closed",
+			issueStatus: coreModel.IssueStatusClosed,
+			body:        `{"body":"test-comment"}`,
+			statusCode:  http.StatusConflict,
+		},
+		{
+			name:             "db-error",
+			issueStatus:      coreModel.IssueStatusOpen,
+			body:             `{"body":"test-comment"}`,
+			createCommentErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:       http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(testCase.issueStatus)
+			repo := &MockCommentDBRepository{
+				GetCommentByIDFunc: func(ctx context.Context, commentID string) (*coreModel.Comment, error) {
+					if testCase.getParentErr != nil {
+						return nil, testCase.getParentErr
+					}
+					return newMockComment(testCase.parentIssueID), nil
+				},
+				CreateCommentFunc: func(ctx context.Context, comment *coreModel.Comment) (string, error) {
+					assert.Equal(t, issue.IssueID, comment.IssueID)
+					assert.Equal(t, mockIssueParty.UserID, comment.AuthorID)
+					return "200000000000000000000002", testCase.createCommentErr
+				},
+			}
+			notifier := &MockNotifier{}
+			statusCode, body := recordCommentControllerRequest(t, mockIssueParty, issue, repo, notifier, "POST", "/", testCase.body)
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusCreated {
+				assert.Contains(t, body, `"comment_id":"200000000000000000000002"`)
+
+				// Check if other participants are notified
+				assert.Equal(t, []string{mockIssueCreator.UserID}, notifier.RecipientIDs())
+				assert.Equal(t, coreModel.NotificationTypeCommentCreated, notifier.Notifications[0].Type)
+				assert.Equal(t, "200000000000000000000002", notifier.Notifications[0].Payload["comment_id"])
+			} else {
+				assert.Empty(t, notifier.Notifications)
+			}
+		})
+	}
+}
+
+func TestListComments(t *testing.T) {
+	testCases := []struct {
+		name                string
+		query               string
+		listCommentsErr     error
+		expectedPageOptions *coreRepository.PageOptions
+		statusCode          int
+	}{
+		{
+			name:                "default",
+			query:               "",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListCommentsLimit, SortField: "created_at"},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:                "limit-and-offset",
+			query:               "?limit=5&offset=10",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: 5, Offset: 10, SortField: "created_at"},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:                "cursor",
+			query:               "?limit=5&cursor=test-cursor",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: 5, Cursor: "test-cursor", SortField: "created_at"},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:       "invalid-limit",
+			query:      "?limit=1000",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:                "db-error",
+			query:               "",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListCommentsLimit, SortField: "created_at"},
+			listCommentsErr:     coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:          http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(coreModel.IssueStatusOpen)
+			comments := []*coreModel.Comment{newMockComment(issue.IssueID)}
+			repo := &MockCommentDBRepository{
+				ListCommentsByIssueIDFunc: func(ctx context.Context, issueID string, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Comment], error) {
+					assert.Equal(t, issue.IssueID, issueID)
+					assert.Equal(t, testCase.expectedPageOptions, pageOptions)
+					if testCase.listCommentsErr != nil {
+						return nil, testCase.listCommentsErr
+					}
+					return &coreRepository.Page[*coreModel.Comment]{Items: comments, NextCursor: "next-cursor"}, nil
+				},
+			}
+			statusCode, body := recordCommentControllerRequest(t, mockIssueCreator, issue, repo, &MockNotifier{}, "GET", "/"+testCase.query, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, utils.ConvertToJSONString(model.PageResponse[model.CommentResponse]{
+					Items:      []model.CommentResponse{newCommentResponse(comments[0])},
+					NextCursor: "next-cursor",
+				}), body)
+			}
+		})
+	}
+}
+
+func TestEditComment(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tokenUser      *coreModel.User
+		commentIssueID string
+		commentDeleted bool
+		commentIsAI    bool
+		getCommentErr  error
+		body           string
+		editCommentErr error
+		statusCode     int
+	}{
+		{
+			name:       "success",
+			tokenUser:  mockIssueCreator,
+			body:       `{"body":"test-comment-edited"}`,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "not-author",
+			tokenUser:  mockIssueParty,
+			body:       `{"body":"test-comment-edited"}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:           "comment-deleted",
+			tokenUser:      mockIssueCreator,
+			commentDeleted: true,
+			body:           `{"body":"test-comment-edited"}`,
+			statusCode:     http.StatusConflict,
+		},
+		{
+			name:        "ai-generated-comment",
+			tokenUser:   mockIssueCreator,
+			commentIsAI: true,
+			body:        `{"body":"test-comment-edited"}`,
+			statusCode:  http.StatusConflict,
+		},
+		{
+			name:           "comment-in-other-issue",
+			tokenUser:      mockIssueCreator,
+			commentIssueID: "100000000000000000000002",
+			body:           `{"body":"test-comment-edited"}`,
+			statusCode:     http.StatusNotFound,
+		},
+		{
+			name:          "comment-not-found",
+			tokenUser:     mockIssueCreator,
+			getCommentErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			body:          `{"body":"test-comment-edited"}`,
+			statusCode:    http.StatusNotFound,
+		},
+		{
+			name:       "invalid-request/empty-body",
+			tokenUser:  mockIssueCreator,
+			body:       `{"body":""}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "db-error",
+			tokenUser:      mockIssueCreator,
+			body:           `{"body":"test-comment-edited"}`,
+			editCommentErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(coreModel.IssueStatusOpen)
+			comment := newMockComment(issue.IssueID)
+			if testCase.commentIssueID != "" {
+				comment.IssueID = testCase.commentIssueID
+			}
+			comment.Deleted = testCase.commentDeleted
+			comment.IsAIGenerated = testCase.commentIsAI
+			repo := &MockCommentDBRepository{
+				GetCommentByIDFunc: func(ctx context.Context, commentID string) (*coreModel.Comment, error) {
+					assert.Equal(t, comment.CommentID, commentID)
+					if testCase.getCommentErr != nil {
+						return nil, testCase.getCommentErr
+					}
+					return comment, nil
+				},
+				EditCommentByIDFunc: func(ctx context.Context, commentID string, body string) error {
+					assert.Equal(t, "test-comment-edited", body)
+					comment.Body = body
+					comment.Edited = true
+					return testCase.editCommentErr
+				},
+			}
+			statusCode, body := recordCommentControllerRequest(t, testCase.tokenUser, issue, repo, &MockNotifier{}, "PATCH", "/"+comment.CommentID, testCase.body)
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Contains(t, body, `"body":"test-comment-edited"`)
+				assert.Contains(t, body, `"edited":true`)
+			}
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	testCases := []struct {
+		name             string
+		tokenUser        *coreModel.User
+		commentDeleted   bool
+		deleteCommentErr error
+		statusCode       int
+	}{
+		{
+			name:       "success",
+			tokenUser:  mockIssueCreator,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "not-author",
+			tokenUser:  mockIssueParty,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:           "already-deleted",
+			tokenUser:      mockIssueCreator,
+			commentDeleted: true,
+			statusCode:     http.StatusConflict,
+		},
+		{
+			name:             "db-error",
+			tokenUser:        mockIssueCreator,
+			deleteCommentErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:       http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(coreModel.IssueStatusOpen)
+			comment := newMockComment(issue.IssueID)
+			comment.Deleted = testCase.commentDeleted
+			repo := &MockCommentDBRepository{
+				GetCommentByIDFunc: func(ctx context.Context, commentID string) (*coreModel.Comment, error) {
+					return comment, nil
+				},
+				SoftDeleteCommentByIDFunc: func(ctx context.Context, commentID string) error {
+					assert.Equal(t, comment.CommentID, commentID)
+					return testCase.deleteCommentErr
+				},
+			}
+			statusCode, _ := recordCommentControllerRequest(t, testCase.tokenUser, issue, repo, &MockNotifier{}, "DELETE", "/"+comment.CommentID, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
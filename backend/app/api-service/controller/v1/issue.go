@@ -0,0 +1,341 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+const DefaultListIssuesLimit = 20
+
+// IssueController is a controller for issue management
+type IssueController struct {
+	issueDBRepo coreRepository.IssueDBRepository
+	userDBRepo  coreRepository.UserDBRepository
+	notifier    coreNotification.Notifier
+	policy      *coreAuthz.Policy
+}
+
+// NewIssueController creates a new IssueController
+func NewIssueController(issueDBRepo coreRepository.IssueDBRepository, userDBRepo coreRepository.UserDBRepository, notifier coreNotification.Notifier, policy *coreAuthz.Policy) *IssueController {
+	return &IssueController{
+		issueDBRepo: issueDBRepo,
+		userDBRepo:  userDBRepo,
+		notifier:    notifier,
+		policy:      policy,
+	}
+}
+
+// newIssueResponse converts an issue to response
+func newIssueResponse(issue *coreModel.Issue) model.IssueResponse {
+	response := model.IssueResponse{
+		IssueID:     issue.IssueID,
+		Title:       issue.Title,
+		Description: issue.Description,
+		Parties:     issue.Parties,
+		Status:      string(issue.Status),
+		CreatedBy:   issue.CreatedBy,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+	}
+	if response.Parties == nil {
+		response.Parties = []string{}
+	}
+	if !issue.ClosedAt.IsZero() {
+		closedAt := issue.ClosedAt
+		response.ClosedAt = &closedAt
+	}
+	return response
+}
+
+// normalizeParties removes duplicated parties and makes sure the creator is one of them
+func normalizeParties(creatorID string, parties []string) []string {
+	normalizedParties := []string{creatorID}
+	for _, party := range parties {
+		if !slices.Contains(normalizedParties, party) {
+			normalizedParties = append(normalizedParties, party)
+		}
+	}
+	return normalizedParties
+}
+
+// checkPartiesExist checks that the parties are existing users, so issues do not name unknown users and they are
+// not notified
+func (ic *IssueController) checkPartiesExist(c *gin.Context, parties []string) error {
+	for _, party := range parties {
+		if _, err := ic.userDBRepo.GetUserByID(c, party); err != nil {
+			var repositoryError coreRepository.RepositoryError
+			if errors.As(err, &repositoryError) && (repositoryError.ErrType == coreRepository.RepositoryErrorTypeRecordNotFound || repositoryError.ErrType == coreRepository.RepositoryErrorTypeInvalidID) {
+				return model.NewError(model.ErrorCodeBadRequest, fmt.Sprintf("party %s is not a user", party), err)
+			}
+			return model.WrapError(err, "failed to get party")
+		}
+	}
+	return nil
+}
+
+// getIssueForUser gets the issue and checks if the user is a participant or can moderate issues
+func (ic *IssueController) getIssueForUser(c *gin.Context, user *coreModel.User) (*coreModel.Issue, error) {
+	issue, err := ic.issueDBRepo.GetIssueByID(c, c.Param("issue_id"))
+	if err != nil {
+		return nil, model.WrapError(err, "failed to get issue")
+	}
+	if !issue.IsParticipant(user.UserID) && !ic.policy.IsUserAllowed(user, coreAuthz.ResourceIssue, coreAuthz.ActionModerate) {
+		return nil, model.NewError(model.ErrorCodeForbidden, "user is not a participant of the issue", nil)
+	}
+	return issue, nil
+}
+
+// @Summary Create issue
+// @Description Create an issue, the creator is always one of the parties, and other parties must be existing users
+// @Tags issue
+// @Router /v1/issue [post]
+// @Security TokenAuth
+// @Param request body model.CreateIssueRequest true "Issue"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.IssueResponse
+func (ic *IssueController) CreateIssue(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	var request model.CreateIssueRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+
+	issue := &coreModel.Issue{
+		Title:       request.Title,
+		Description: request.Description,
+		Parties:     normalizeParties(user.UserID, request.Parties),
+		Status:      coreModel.IssueStatusOpen,
+		CreatedBy:   user.UserID,
+	}
+
+	// Parties other than the creator must be existing users
+	if err := ic.checkPartiesExist(c, issue.Parties[1:]); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	issueID, err := ic.issueDBRepo.CreateIssue(c, issue)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to create issue"))
+		c.Abort()
+		return
+	}
+	issue.IssueID = issueID
+
+	notifyParticipants(c, ic.notifier, issue, user.UserID, coreModel.NotificationTypeIssueInvited, map[string]any{
+		"issue_id": issue.IssueID,
+		"title":    issue.Title,
+		"actor_id": user.UserID,
+	})
+
+	c.JSON(http.StatusCreated, newIssueResponse(issue))
+}
+
+// @Summary Get issue
+// @Description Get issue, only participants of the issue can get it
+// @Tags issue
+// @Router /v1/issue/{issue_id} [get]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Produce json
+// @Success 200 {object} model.IssueResponse
+func (ic *IssueController) GetIssue(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	issue, err := ic.getIssueForUser(c, user)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, newIssueResponse(issue))
+}
+
+// @Summary List issues
+// @Description List issues the user participates in, newest first
+// @Tags issue
+// @Router /v1/issue [get]
+// @Security TokenAuth
+// @Param limit query int false "Limit" minimum(1) maximum(100) default(20)
+// @Param offset query int false "Offset" minimum(0) default(0)
+// @Param cursor query string false "Cursor of next page, can not be used with offset"
+// @Param with_total query bool false "Count total"
+// @Produce json
+// @Success 200 {object} model.PageResponse[model.IssueResponse]
+func (ic *IssueController) ListIssues(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	pageOptions, err := bindPageRequest(c, DefaultListIssuesLimit, "created_at", true)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	page, err := ic.issueDBRepo.ListIssuesByUserID(c, user.UserID, pageOptions)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to list issues"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(page, newIssueResponse))
+}
+
+// @Summary Update issue
+// @Description Update issue, only the creator can update an open issue, and added parties must be existing users
+// @Tags issue
+// @Router /v1/issue/{issue_id} [patch]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Param request body model.UpdateIssueRequest true "Fields to update"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.IssueResponse
+func (ic *IssueController) UpdateIssue(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	var request model.UpdateIssueRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+
+	issue, err := ic.getIssueForUser(c, user)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	if err := checkIssueEditable(issue, user); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	// Set update data
+	updateData := map[string]any{}
+	if request.Title != nil {
+		issue.Title = *request.Title
+		updateData["title"] = issue.Title
+	}
+	if request.Description != nil {
+		issue.Description = *request.Description
+		updateData["description"] = issue.Description
+	}
+	var addedParties []string
+	if request.Parties != nil {
+		parties := normalizeParties(issue.CreatedBy, *request.Parties)
+		for _, party := range parties {
+			if !issue.IsParticipant(party) {
+				addedParties = append(addedParties, party)
+			}
+		}
+		if err := ic.checkPartiesExist(c, addedParties); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		issue.Parties = parties
+		updateData["parties"] = issue.Parties
+	}
+	if len(updateData) == 0 {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, "no field to update", nil))
+		c.Abort()
+		return
+	}
+
+	if err := ic.issueDBRepo.UpdateIssueByID(c, issue.IssueID, updateData); err != nil {
+		c.Error(model.WrapError(err, "failed to update issue"))
+		c.Abort()
+		return
+	}
+
+	issue, err = ic.issueDBRepo.GetIssueByID(c, issue.IssueID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to get issue"))
+		c.Abort()
+		return
+	}
+
+	// Parties added by the update are invited like parties of a new issue
+	notifyUsers(c, ic.notifier, addedParties, user.UserID, coreModel.NotificationTypeIssueInvited, map[string]any{
+		"issue_id": issue.IssueID,
+		"title":    issue.Title,
+		"actor_id": user.UserID,
+	})
+
+	c.JSON(http.StatusOK, newIssueResponse(issue))
+}
+
+// @Summary Close issue
+// @Description Close issue, only the creator can close an open issue
+// @Tags issue
+// @Router /v1/issue/{issue_id}/close [post]
+// @Security TokenAuth
+// @Param issue_id path string true "Issue ID"
+// @Produce json
+// @Success 200 {object} model.IssueResponse
+func (ic *IssueController) CloseIssue(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	issue, err := ic.getIssueForUser(c, user)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	if err := checkIssueEditable(issue, user); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if err := ic.issueDBRepo.CloseIssueByID(c, issue.IssueID); err != nil {
+		c.Error(model.WrapError(err, "failed to close issue"))
+		c.Abort()
+		return
+	}
+
+	issue, err = ic.issueDBRepo.GetIssueByID(c, issue.IssueID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to get issue"))
+		c.Abort()
+		return
+	}
+
+	notifyParticipants(c, ic.notifier, issue, user.UserID, coreModel.NotificationTypeIssueClosed, map[string]any{
+		"issue_id": issue.IssueID,
+		"title":    issue.Title,
+		"actor_id": user.UserID,
+	})
+
+	c.JSON(http.StatusOK, newIssueResponse(issue))
+}
+
+// checkIssueEditable checks if the issue is open and the user is the creator
+func checkIssueEditable(issue *coreModel.Issue, user *coreModel.User) error {
+	if issue.CreatedBy != user.UserID {
+		return model.NewError(model.ErrorCodeForbidden, "only the creator can modify the issue", nil)
+	}
+	if issue.Status == coreModel.IssueStatusClosed {
+		return model.NewError(model.ErrorCodeConflict, "issue is closed", nil)
+	}
+	return nil
+}
@@ -0,0 +1,522 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+type MockIssueDBRepository struct {
+	CreateIssueFunc        func(ctx context.Context, issue *coreModel.Issue) (string, error)
+	GetIssueByIDFunc       func(ctx context.Context, issueID string) (*coreModel.Issue, error)
+	ListIssuesByUserIDFunc func(ctx context.Context, userID string, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Issue], error)
+	UpdateIssueByIDFunc    func(ctx context.Context, issueID string, updateData map[string]any) error
+	CloseIssueByIDFunc     func(ctx context.Context, issueID string) error
+}
+
+func (repo *MockIssueDBRepository) CreateIssue(ctx context.Context, issue *coreModel.Issue) (string, error) {
+	return repo.CreateIssueFunc(ctx, issue)
+}
+
+func (repo *MockIssueDBRepository) GetIssueByID(ctx context.Context, issueID string) (*coreModel.Issue, error) {
+	return repo.GetIssueByIDFunc(ctx, issueID)
+}
+
+func (repo *MockIssueDBRepository) ListIssuesByUserID(ctx context.Context, userID string, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Issue], error) {
+	return repo.ListIssuesByUserIDFunc(ctx, userID, pageOptions)
+}
+
+func (repo *MockIssueDBRepository) UpdateIssueByID(ctx context.Context, issueID string, updateData map[string]any) error {
+	return repo.UpdateIssueByIDFunc(ctx, issueID, updateData)
+}
+
+func (repo *MockIssueDBRepository) CloseIssueByID(ctx context.Context, issueID string) error {
+	return repo.CloseIssueByIDFunc(ctx, issueID)
+}
+
+var mockIssueCreator = &coreModel.User{
+	UserID:      "000000000000000000000001",
+	DisplayName: "test-creator",
+}
+
+var mockIssueParty = &coreModel.User{
+	UserID:      "000000000000000000000002",
+	DisplayName: "test-party",
+}
+
+var mockIssueStranger = &coreModel.User{
+	UserID:      "000000000000000000000003",
+	DisplayName: "test-stranger",
+}
+
+var mockIssueMediator = &coreModel.User{
+	UserID:      "000000000000000000000004",
+	DisplayName: "test-mediator",
+	Role:        coreModel.UserRoleMediator,
+}
+
+var mockPolicy, _ = coreAuthz.NewPolicy(nil)
+
+func newMockIssue(status coreModel.IssueStatus) *coreModel.Issue {
+	return &coreModel.Issue{
+		IssueID:     "100000000000000000000001",
+		Title:       "test-title",
+		Description: "test-description",
+		Parties:     []string{mockIssueCreator.UserID, mockIssueParty.UserID},
+		Status:      status,
+		CreatedBy:   mockIssueCreator.UserID,
+		CreatedAt:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// newMockIssueUserDBRepository creates a user repository of mock users of issues, getUserErr fails getting any user
+func newMockIssueUserDBRepository(getUserErr error) *MockUserDBRepository {
+	return &MockUserDBRepository{
+		GetUserByIDFunc: func(ctx context.Context, userID string) (*coreModel.User, error) {
+			if getUserErr != nil {
+				return nil, getUserErr
+			}
+			for _, user := range []*coreModel.User{mockIssueCreator, mockIssueParty, mockIssueStranger, mockIssueMediator} {
+				if user.UserID == userID {
+					return user, nil
+				}
+			}
+			return nil, coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound}
+		},
+	}
+}
+
+func recordIssueControllerRequest(t *testing.T, tokenUser *coreModel.User, repo *MockIssueDBRepository, userDBRepo *MockUserDBRepository, notifier *MockNotifier, method, path, body string) (int, string) {
+	issueController := NewIssueController(repo, userDBRepo, notifier, mockPolicy)
+	var statusCode int
+	httpRecorder := utils.RegisterAndRecordHttpRequest(
+		func(router *gin.RouterGroup) {
+			router.Use(func(ctx *gin.Context) {
+				// Set user to context
+				ctx.Set("user", tokenUser)
+				ctx.Next()
+
+				// Get status code from error
+				if err := ctx.Errors.Last(); err != nil {
+					statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+				}
+			})
+			router.POST("", issueController.CreateIssue)
+			router.GET("", issueController.ListIssues)
+			router.GET("/:issue_id", issueController.GetIssue)
+			router.PATCH("/:issue_id", issueController.UpdateIssue)
+			router.POST("/:issue_id/close", issueController.CloseIssue)
+		},
+		method,
+		path,
+		strings.NewReader(body),
+	)
+	if statusCode == 0 {
+		statusCode = httpRecorder.Code
+	}
+	return statusCode, httpRecorder.Body.String()
+}
+
+func TestCreateIssue(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            string
+		getUserErr      error
+		createIssueErr  error
+		expectedParties []string
+		statusCode      int
+	}{
+		{
+			name:            "success",
+			body:            `{"title":"test-title","description":"test-description","parties":["000000000000000000000002"]}`,
+			expectedParties: []string{mockIssueCreator.UserID, mockIssueParty.UserID},
+			statusCode:      http.StatusCreated,
+		},
+		{
+			name:            "success/duplicated-parties",
+			body:            `{"title":"test-title","parties":["000000000000000000000001","000000000000000000000002","000000000000000000000002"]}`,
+			expectedParties: []string{mockIssueCreator.UserID, mockIssueParty.UserID},
+			statusCode:      http.StatusCreated,
+		},
+		{
+			name:       "invalid-request/no-title",
+			body:       `{"description":"test-description"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-request/invalid-party",
+			body:       `{"title":"test-title","parties":["invalid-party"]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-request/unknown-party",
+			body:       `{"title":"test-title","parties":["000000000000000000000009"]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "db-error/get-party",
+			body:       `{"title":"test-title","parties":["000000000000000000000002"]}`,
+			getUserErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "db-error",
+			body:           `{"title":"test-title"}`,
+			createIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &MockIssueDBRepository{
+				CreateIssueFunc: func(ctx context.Context, issue *coreModel.Issue) (string, error) {
+					assert.Equal(t, mockIssueCreator.UserID, issue.CreatedBy)
+					assert.Equal(t, coreModel.IssueStatusOpen, issue.Status)
+					if testCase.expectedParties != nil {
+						assert.Equal(t, testCase.expectedParties, issue.Parties)
+					}
+					return "100000000000000000000001", testCase.createIssueErr
+				},
+			}
+			notifier := &MockNotifier{}
+			statusCode, body := recordIssueControllerRequest(t, mockIssueCreator, repo, newMockIssueUserDBRepository(testCase.getUserErr), notifier, "POST", "/", testCase.body)
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusCreated {
+				assert.Contains(t, body, `"issue_id":"100000000000000000000001"`)
+				assert.Contains(t, body, `"status":"open"`)
+
+				// Check if other parties are invited
+				assert.Equal(t, testCase.expectedParties[1:], notifier.RecipientIDs())
+				for _, notification := range notifier.Notifications {
+					assert.Equal(t, coreModel.NotificationTypeIssueInvited, notification.Type)
+				}
+			} else {
+				assert.Empty(t, notifier.Notifications)
+			}
+		})
+	}
+}
+
+func TestGetIssue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tokenUser   *coreModel.User
+		getIssueErr error
+		statusCode  int
+	}{
+		{
+			name:       "creator",
+			tokenUser:  mockIssueCreator,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "party",
+			tokenUser:  mockIssueParty,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "stranger",
+			tokenUser:  mockIssueStranger,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "mediator",
+			tokenUser:  mockIssueMediator,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:        "not-found",
+			tokenUser:   mockIssueCreator,
+			getIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			statusCode:  http.StatusNotFound,
+		},
+		{
+			name:        "db-error",
+			tokenUser:   mockIssueCreator,
+			getIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:  http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(coreModel.IssueStatusOpen)
+			repo := &MockIssueDBRepository{
+				GetIssueByIDFunc: func(ctx context.Context, issueID string) (*coreModel.Issue, error) {
+					assert.Equal(t, issue.IssueID, issueID)
+					if testCase.getIssueErr != nil {
+						return nil, testCase.getIssueErr
+					}
+					return issue, nil
+				},
+			}
+			statusCode, body := recordIssueControllerRequest(t, testCase.tokenUser, repo, newMockIssueUserDBRepository(nil), &MockNotifier{}, "GET", "/"+issue.IssueID, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, utils.ConvertToJSONString(newIssueResponse(issue)), body)
+			}
+		})
+	}
+}
+
+func TestListIssues(t *testing.T) {
+	testCases := []struct {
+		name                string
+		query               string
+		listIssuesErr       error
+		expectedPageOptions *coreRepository.PageOptions
+		statusCode          int
+	}{
+		{
+			name:                "default",
+			query:               "",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListIssuesLimit, SortField: "created_at", Descending: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:                "limit-and-offset",
+			query:               "?limit=5&offset=10",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: 5, Offset: 10, SortField: "created_at", Descending: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:                "cursor",
+			query:               "?cursor=test-cursor&with_total=true",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListIssuesLimit, Cursor: "test-cursor", SortField: "created_at", Descending: true, WithTotal: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:       "invalid-limit",
+			query:      "?limit=1000",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:                "db-error/invalid-cursor",
+			query:               "?cursor=invalid-cursor",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListIssuesLimit, Cursor: "invalid-cursor", SortField: "created_at", Descending: true},
+			listIssuesErr:       coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeInvalidCursor},
+			statusCode:          http.StatusBadRequest,
+		},
+		{
+			name:                "db-error",
+			query:               "",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListIssuesLimit, SortField: "created_at", Descending: true},
+			listIssuesErr:       coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:          http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issues := []*coreModel.Issue{newMockIssue(coreModel.IssueStatusOpen)}
+			repo := &MockIssueDBRepository{
+				ListIssuesByUserIDFunc: func(ctx context.Context, userID string, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Issue], error) {
+					assert.Equal(t, mockIssueParty.UserID, userID)
+					assert.Equal(t, testCase.expectedPageOptions, pageOptions)
+					if testCase.listIssuesErr != nil {
+						return nil, testCase.listIssuesErr
+					}
+					return &coreRepository.Page[*coreModel.Issue]{Items: issues, NextCursor: "next-cursor"}, nil
+				},
+			}
+			statusCode, body := recordIssueControllerRequest(t, mockIssueParty, repo, newMockIssueUserDBRepository(nil), &MockNotifier{}, "GET", "/"+testCase.query, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				expected := model.PageResponse[model.IssueResponse]{
+					Items:      []model.IssueResponse{newIssueResponse(issues[0])},
+					NextCursor: "next-cursor",
+				}
+				assert.Equal(t, utils.ConvertToJSONString(expected), body)
+			}
+		})
+	}
+}
+
+func TestUpdateIssue(t *testing.T) {
+	testCases := []struct {
+		name               string
+		tokenUser          *coreModel.User
+		status             coreModel.IssueStatus
+		body               string
+		expectedUpdateData map[string]any
+		updateIssueErr     error
+		expectedRecipients []string
+		statusCode         int
+	}{
+		{
+			name:      "success",
+			tokenUser: mockIssueCreator,
+			status:    coreModel.IssueStatusOpen,
+			body:      `{"title":"test-title-updated","parties":[]}`,
+			expectedUpdateData: map[string]any{
+				"title":   "test-title-updated",
+				"parties": []string{mockIssueCreator.UserID},
+			},
+			expectedRecipients: []string{},
+			statusCode:         http.StatusOK,
+		},
+		{
+			name:      "success/parties-added",
+			tokenUser: mockIssueCreator,
+			status:    coreModel.IssueStatusOpen,
+			body:      `{"title":"test-title-updated","parties":["` + mockIssueParty.UserID + `","` + mockIssueStranger.UserID + `"]}`,
+			expectedUpdateData: map[string]any{
+				"title":   "test-title-updated",
+				"parties": []string{mockIssueCreator.UserID, mockIssueParty.UserID, mockIssueStranger.UserID},
+			},
+			expectedRecipients: []string{mockIssueStranger.UserID},
+			statusCode:         http.StatusOK,
+		},
+		{
+			name:       "unknown-party-added",
+			tokenUser:  mockIssueCreator,
+			status:     coreModel.IssueStatusOpen,
+			body:       `{"parties":["` + mockIssueParty.UserID + `","000000000000000000000009"]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "no-field",
+			tokenUser:  mockIssueCreator,
+			status:     coreModel.IssueStatusOpen,
+			body:       `{}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-request",
+			tokenUser:  mockIssueCreator,
+			status:     coreModel.IssueStatusOpen,
+			body:       `{"title":""}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "not-creator",
+			tokenUser:  mockIssueParty,
+			status:     coreModel.IssueStatusOpen,
+			body:       `{"title":"test-title-updated"}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "closed",
+			tokenUser:  mockIssueCreator,
+			status:     coreModel.IssueStatusClosed,
+			body:       `{"title":"test-title-updated"}`,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:      "db-error",
+			tokenUser: mockIssueCreator,
+			status:    coreModel.IssueStatusOpen,
+			body:      `{"description":"test-description-updated"}`,
+			expectedUpdateData: map[string]any{
+				"description": "test-description-updated",
+			},
+			updateIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(testCase.status)
+			repo := &MockIssueDBRepository{
+				GetIssueByIDFunc: func(ctx context.Context, issueID string) (*coreModel.Issue, error) {
+					return issue, nil
+				},
+				UpdateIssueByIDFunc: func(ctx context.Context, issueID string, updateData map[string]any) error {
+					assert.Equal(t, issue.IssueID, issueID)
+					assert.Equal(t, testCase.expectedUpdateData, updateData)
+					return testCase.updateIssueErr
+				},
+			}
+			notifier := &MockNotifier{}
+			statusCode, body := recordIssueControllerRequest(t, testCase.tokenUser, repo, newMockIssueUserDBRepository(nil), notifier, "PATCH", "/"+issue.IssueID, testCase.body)
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Contains(t, body, `"title":"test-title-updated"`)
+				assert.Equal(t, testCase.expectedRecipients, notifier.RecipientIDs())
+				for _, notification := range notifier.Notifications {
+					assert.Equal(t, coreModel.NotificationTypeIssueInvited, notification.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestCloseIssue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tokenUser     *coreModel.User
+		status        coreModel.IssueStatus
+		closeIssueErr error
+		statusCode    int
+	}{
+		{
+			name:       "success",
+			tokenUser:  mockIssueCreator,
+			status:     coreModel.IssueStatusOpen,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "not-creator",
+			tokenUser:  mockIssueParty,
+			status:     coreModel.IssueStatusOpen,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "stranger",
+			tokenUser:  mockIssueStranger,
+			status:     coreModel.IssueStatusOpen,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "already-closed",
+			tokenUser:  mockIssueCreator,
+			status:     coreModel.IssueStatusClosed,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:          "db-error",
+			tokenUser:     mockIssueCreator,
+			status:        coreModel.IssueStatusOpen,
+			closeIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:    http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue := newMockIssue(testCase.status)
+			repo := &MockIssueDBRepository{
+				GetIssueByIDFunc: func(ctx context.Context, issueID string) (*coreModel.Issue, error) {
+					return issue, nil
+				},
+				CloseIssueByIDFunc: func(ctx context.Context, issueID string) error {
+					assert.Equal(t, issue.IssueID, issueID)
+					if testCase.closeIssueErr == nil {
+						issue.Status = coreModel.IssueStatusClosed
+						issue.ClosedAt = time.Now()
+					}
+					return testCase.closeIssueErr
+				},
+			}
+			notifier := &MockNotifier{}
+			statusCode, body := recordIssueControllerRequest(t, testCase.tokenUser, repo, newMockIssueUserDBRepository(nil), notifier, "POST", "/"+issue.IssueID+"/close", "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Contains(t, body, `"status":"closed"`)
+				assert.Contains(t, body, `"closed_at"`)
+				assert.Equal(t, []string{mockIssueParty.UserID}, notifier.RecipientIDs())
+			} else {
+				assert.Empty(t, notifier.Notifications)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+const DefaultListNotificationsLimit = 20
+
+// NotificationController is a controller for notification inbox of user
+type NotificationController struct {
+	notificationDBRepo coreRepository.NotificationDBRepository
+}
+
+// NewNotificationController creates a new NotificationController
+func NewNotificationController(notificationDBRepo coreRepository.NotificationDBRepository) *NotificationController {
+	return &NotificationController{
+		notificationDBRepo: notificationDBRepo,
+	}
+}
+
+// newNotificationResponse converts a notification to response
+func newNotificationResponse(notification *coreModel.Notification) model.NotificationResponse {
+	response := model.NotificationResponse{
+		NotificationID: notification.NotificationID,
+		Type:           string(notification.Type),
+		Payload:        notification.Payload,
+		ReadAt:         notification.ReadAt,
+		CreatedAt:      notification.CreatedAt,
+	}
+	if response.Payload == nil {
+		response.Payload = map[string]any{}
+	}
+	return response
+}
+
+// notifyParticipants notifies participants of the issue except the actor, failures do not fail the request
+func notifyParticipants(c *gin.Context, notifier coreNotification.Notifier, issue *coreModel.Issue, actorID string, notificationType coreModel.NotificationType, payload map[string]any) {
+	notifyUsers(c, notifier, normalizeParties(issue.CreatedBy, issue.Parties), actorID, notificationType, payload)
+}
+
+// notifyUsers notifies users except the actor, failures do not fail the request, notifier gets the request context
+// since deliveries to slow channels are queued and outlive the gin context
+func notifyUsers(c *gin.Context, notifier coreNotification.Notifier, userIDs []string, actorID string, notificationType coreModel.NotificationType, payload map[string]any) {
+	for _, userID := range userIDs {
+		if userID == actorID {
+			continue
+		}
+		err := notifier.Notify(c.Request.Context(), &coreModel.Notification{
+			RecipientID: userID,
+			Type:        notificationType,
+			Payload:     payload,
+		})
+		if err != nil {
+			coreErrReport.Record(c, fmt.Errorf("failed to notify user %s: %w", userID, err))
+		}
+	}
+}
+
+// @Summary List notifications
+// @Description List notifications of the user, newest first
+// @Tags notification
+// @Router /v1/user/{user_id}/notification [get]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Limit" minimum(1) maximum(100) default(20)
+// @Param offset query int false "Offset" minimum(0) default(0)
+// @Param cursor query string false "Cursor of next page, can not be used with offset"
+// @Param with_total query bool false "Count total"
+// @Produce json
+// @Success 200 {object} model.PageResponse[model.NotificationResponse]
+func (nc *NotificationController) ListNotifications(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	var request model.ListNotificationsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+	pageOptions, err := bindPageRequest(c, DefaultListNotificationsLimit, "created_at", true)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	page, err := nc.notificationDBRepo.ListNotificationsByRecipientID(c, user.UserID, request.Unread, pageOptions)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to list notifications"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(page, newNotificationResponse))
+}
+
+// @Summary Get unread notification count
+// @Description Get the number of unread notifications of the user
+// @Tags notification
+// @Router /v1/user/{user_id}/notification/unread-count [get]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Produce json
+// @Success 200 {object} model.UnreadNotificationCountResponse
+func (nc *NotificationController) GetUnreadCount(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	count, err := nc.notificationDBRepo.CountUnreadNotifications(c, user.UserID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to count unread notifications"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, model.UnreadNotificationCountResponse{UnreadCount: count})
+}
+
+// @Summary Mark notification as read
+// @Description Mark a notification of the user as read
+// @Tags notification
+// @Router /v1/user/{user_id}/notification/{notification_id}/read [post]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Param notification_id path string true "Notification ID"
+// @Produce json
+// @Success 200 {object} model.MessageResponse
+func (nc *NotificationController) MarkAsRead(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	if err := nc.notificationDBRepo.MarkNotificationAsRead(c, user.UserID, c.Param("notification_id")); err != nil {
+		c.Error(model.WrapError(err, "failed to mark notification as read"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, model.MessageResponse{Message: "ok"})
+}
+
+// @Summary Mark all notifications as read
+// @Description Mark all unread notifications of the user as read
+// @Tags notification
+// @Router /v1/user/{user_id}/notification/read-all [post]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Produce json
+// @Success 200 {object} model.MarkAllNotificationsReadResponse
+func (nc *NotificationController) MarkAllAsRead(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	count, err := nc.notificationDBRepo.MarkAllNotificationsAsRead(c, user.UserID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to mark all notifications as read"))
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, model.MarkAllNotificationsReadResponse{UpdatedCount: count})
+}
@@ -0,0 +1,311 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+type MockNotifier struct {
+	Notifications []*coreModel.Notification
+	NotifyErr     error
+}
+
+func (notifier *MockNotifier) Notify(ctx context.Context, notification *coreModel.Notification) error {
+	notifier.Notifications = append(notifier.Notifications, notification)
+	return notifier.NotifyErr
+}
+
+func (notifier *MockNotifier) Close(ctx context.Context) error {
+	return nil
+}
+
+// RecipientIDs returns recipient IDs of notified notifications
+func (notifier *MockNotifier) RecipientIDs() []string {
+	recipientIDs := []string{}
+	for _, notification := range notifier.Notifications {
+		recipientIDs = append(recipientIDs, notification.RecipientID)
+	}
+	return recipientIDs
+}
+
+type MockNotificationDBRepository struct {
+	CreateNotificationFunc             func(ctx context.Context, notification *coreModel.Notification) (string, error)
+	ListNotificationsByRecipientIDFunc func(ctx context.Context, recipientID string, unreadOnly bool, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Notification], error)
+	MarkNotificationAsReadFunc         func(ctx context.Context, recipientID, notificationID string) error
+	MarkAllNotificationsAsReadFunc     func(ctx context.Context, recipientID string) (int64, error)
+	CountUnreadNotificationsFunc       func(ctx context.Context, recipientID string) (int64, error)
+}
+
+func (repo *MockNotificationDBRepository) CreateNotification(ctx context.Context, notification *coreModel.Notification) (string, error) {
+	return repo.CreateNotificationFunc(ctx, notification)
+}
+
+func (repo *MockNotificationDBRepository) ListNotificationsByRecipientID(ctx context.Context, recipientID string, unreadOnly bool, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Notification], error) {
+	return repo.ListNotificationsByRecipientIDFunc(ctx, recipientID, unreadOnly, pageOptions)
+}
+
+func (repo *MockNotificationDBRepository) MarkNotificationAsRead(ctx context.Context, recipientID, notificationID string) error {
+	return repo.MarkNotificationAsReadFunc(ctx, recipientID, notificationID)
+}
+
+func (repo *MockNotificationDBRepository) MarkAllNotificationsAsRead(ctx context.Context, recipientID string) (int64, error) {
+	return repo.MarkAllNotificationsAsReadFunc(ctx, recipientID)
+}
+
+func (repo *MockNotificationDBRepository) CountUnreadNotifications(ctx context.Context, recipientID string) (int64, error) {
+	return repo.CountUnreadNotificationsFunc(ctx, recipientID)
+}
+
+func recordNotificationControllerRequest(t *testing.T, tokenUser *coreModel.User, repo *MockNotificationDBRepository, method, path string) (int, string) {
+	notificationController := NewNotificationController(repo)
+	var statusCode int
+	httpRecorder := utils.RegisterAndRecordHttpRequest(
+		func(router *gin.RouterGroup) {
+			router.Use(func(ctx *gin.Context) {
+				// Set user to context
+				ctx.Set("user", tokenUser)
+				ctx.Next()
+
+				// Get status code from error
+				if err := ctx.Errors.Last(); err != nil {
+					statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+				}
+			})
+			router.GET("", notificationController.ListNotifications)
+			router.GET("/unread-count", notificationController.GetUnreadCount)
+			router.POST("/read-all", notificationController.MarkAllAsRead)
+			router.POST("/:notification_id/read", notificationController.MarkAsRead)
+		},
+		method,
+		path,
+		strings.NewReader(""),
+	)
+	if statusCode == 0 {
+		statusCode = httpRecorder.Code
+	}
+	return statusCode, httpRecorder.Body.String()
+}
+
+func TestNotifyParticipants(t *testing.T) {
+	issue := newMockIssue(coreModel.IssueStatusOpen)
+	issue.Parties = append(issue.Parties, mockIssueStranger.UserID)
+
+	notifier := &MockNotifier{NotifyErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError}}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("POST", "/", nil)
+	notifyParticipants(ctx, notifier, issue, mockIssueParty.UserID, coreModel.NotificationTypeIssueClosed, map[string]any{"issue_id": issue.IssueID})
+
+	// Failure of one recipient does not stop the others, and the actor is not notified
+	assert.Equal(t, []string{mockIssueCreator.UserID, mockIssueStranger.UserID}, notifier.RecipientIDs())
+	for _, notification := range notifier.Notifications {
+		assert.Equal(t, coreModel.NotificationTypeIssueClosed, notification.Type)
+		assert.Equal(t, issue.IssueID, notification.Payload["issue_id"])
+	}
+}
+
+func TestListNotifications(t *testing.T) {
+	readAt := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	notifications := []*coreModel.Notification{
+		{
+			NotificationID: "300000000000000000000001",
+			RecipientID:    mockIssueCreator.UserID,
+			Type:           coreModel.NotificationTypeCommentCreated,
+			Payload:        map[string]any{"issue_id": "100000000000000000000001"},
+			CreatedAt:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			NotificationID: "300000000000000000000002",
+			RecipientID:    mockIssueCreator.UserID,
+			Type:           coreModel.NotificationTypeIssueClosed,
+			ReadAt:         &readAt,
+			CreatedAt:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		query               string
+		listErr             error
+		expectedUnreadOnly  bool
+		expectedPageOptions *coreRepository.PageOptions
+		statusCode          int
+	}{
+		{
+			name:                "default",
+			query:               "",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListNotificationsLimit, SortField: "created_at", Descending: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:                "unread-with-limit-and-offset",
+			query:               "?unread=true&limit=5&offset=10",
+			expectedUnreadOnly:  true,
+			expectedPageOptions: &coreRepository.PageOptions{Limit: 5, Offset: 10, SortField: "created_at", Descending: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:                "unread-with-cursor",
+			query:               "?unread=true&cursor=test-cursor",
+			expectedUnreadOnly:  true,
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListNotificationsLimit, Cursor: "test-cursor", SortField: "created_at", Descending: true},
+			statusCode:          http.StatusOK,
+		},
+		{
+			name:       "invalid-limit",
+			query:      "?limit=0&offset=-1",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-unread",
+			query:      "?unread=not-bool",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:                "db-error",
+			query:               "",
+			expectedPageOptions: &coreRepository.PageOptions{Limit: DefaultListNotificationsLimit, SortField: "created_at", Descending: true},
+			listErr:             coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:          http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &MockNotificationDBRepository{
+				ListNotificationsByRecipientIDFunc: func(ctx context.Context, recipientID string, unreadOnly bool, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.Notification], error) {
+					assert.Equal(t, mockIssueCreator.UserID, recipientID)
+					assert.Equal(t, testCase.expectedUnreadOnly, unreadOnly)
+					assert.Equal(t, testCase.expectedPageOptions, pageOptions)
+					if testCase.listErr != nil {
+						return nil, testCase.listErr
+					}
+					return &coreRepository.Page[*coreModel.Notification]{Items: notifications}, nil
+				},
+			}
+			statusCode, body := recordNotificationControllerRequest(t, mockIssueCreator, repo, "GET", "/"+testCase.query)
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, utils.ConvertToJSONString(model.PageResponse[model.NotificationResponse]{
+					Items: []model.NotificationResponse{
+						newNotificationResponse(notifications[0]),
+						newNotificationResponse(notifications[1]),
+					},
+				}), body)
+				assert.Contains(t, body, `"payload":{}`)
+			}
+		})
+	}
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		countErr   error
+		statusCode int
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "db-error",
+			countErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &MockNotificationDBRepository{
+				CountUnreadNotificationsFunc: func(ctx context.Context, recipientID string) (int64, error) {
+					assert.Equal(t, mockIssueCreator.UserID, recipientID)
+					return 3, testCase.countErr
+				},
+			}
+			statusCode, body := recordNotificationControllerRequest(t, mockIssueCreator, repo, "GET", "/unread-count")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, `{"unread_count":3}`, body)
+			}
+		})
+	}
+}
+
+func TestMarkAsRead(t *testing.T) {
+	testCases := []struct {
+		name       string
+		markErr    error
+		statusCode int
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "not-found",
+			markErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "db-error",
+			markErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &MockNotificationDBRepository{
+				MarkNotificationAsReadFunc: func(ctx context.Context, recipientID, notificationID string) error {
+					assert.Equal(t, mockIssueCreator.UserID, recipientID)
+					assert.Equal(t, "300000000000000000000001", notificationID)
+					return testCase.markErr
+				},
+			}
+			statusCode, _ := recordNotificationControllerRequest(t, mockIssueCreator, repo, "POST", "/300000000000000000000001/read")
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
+
+func TestMarkAllAsRead(t *testing.T) {
+	testCases := []struct {
+		name       string
+		markErr    error
+		statusCode int
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "db-error",
+			markErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &MockNotificationDBRepository{
+				MarkAllNotificationsAsReadFunc: func(ctx context.Context, recipientID string) (int64, error) {
+					assert.Equal(t, mockIssueCreator.UserID, recipientID)
+					return 2, testCase.markErr
+				},
+			}
+			statusCode, body := recordNotificationControllerRequest(t, mockIssueCreator, repo, "POST", "/read-all")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, `{"updated_count":2}`, body)
+			}
+		})
+	}
+}
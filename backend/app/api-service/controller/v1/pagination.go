@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// bindPageRequest binds pagination query parameters to page options of repository
+func bindPageRequest(c *gin.Context, defaultLimit int64, sortField string, descending bool) (*coreRepository.PageOptions, error) {
+	var request model.PageRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		return nil, model.NewError(model.ErrorCodeBadRequest, err.Error(), err)
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultLimit
+	}
+	return &coreRepository.PageOptions{
+		Limit:      request.Limit,
+		Offset:     request.Offset,
+		Cursor:     request.Cursor,
+		SortField:  sortField,
+		Descending: descending,
+		WithTotal:  request.WithTotal,
+	}, nil
+}
+
+// newPageResponse converts a page of repository to response by the item converter
+func newPageResponse[D any, T any](page *coreRepository.Page[D], convert func(D) T) model.PageResponse[T] {
+	mapped := coreRepository.MapPage(page, convert)
+	return model.PageResponse[T]{
+		Items:      mapped.Items,
+		NextCursor: mapped.NextCursor,
+		Total:      mapped.Total,
+	}
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func TestBindPageRequest(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		expectedOptions    *coreRepository.PageOptions
+		expectedStatusCode int
+	}{
+		{
+			name:  "default",
+			query: "",
+			expectedOptions: &coreRepository.PageOptions{
+				Limit:      20,
+				SortField:  "created_at",
+				Descending: true,
+			},
+		},
+		{
+			name:  "offset",
+			query: "?limit=10&offset=30&with_total=true",
+			expectedOptions: &coreRepository.PageOptions{
+				Limit:      10,
+				Offset:     30,
+				SortField:  "created_at",
+				Descending: true,
+				WithTotal:  true,
+			},
+		},
+		{
+			name:  "cursor",
+			query: "?cursor=test-cursor",
+			expectedOptions: &coreRepository.PageOptions{
+				Limit:      20,
+				Cursor:     "test-cursor",
+				SortField:  "created_at",
+				Descending: true,
+			},
+		},
+		{
+			name:               "both-offset-and-cursor",
+			query:              "?offset=10&cursor=test-cursor",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "invalid-limit",
+			query:              "?limit=101",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var pageOptions *coreRepository.PageOptions
+			var statusCode int
+			utils.RegisterAndRecordHttpRequest(func(router *gin.RouterGroup) {
+				router.GET("", func(c *gin.Context) {
+					var err error
+					pageOptions, err = bindPageRequest(c, 20, "created_at", true)
+					if err != nil {
+						statusCode = err.(model.HttpStatusCodeError).StatusCode
+					}
+				})
+			}, http.MethodGet, "/"+testCase.query, nil)
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+			assert.Equal(t, testCase.expectedOptions, pageOptions)
+		})
+	}
+}
+
+func TestNewPageResponse(t *testing.T) {
+	total := int64(3)
+	page := &coreRepository.Page[int]{
+		Items:      []int{1, 2},
+		NextCursor: "test-cursor",
+		Total:      &total,
+	}
+	response := newPageResponse(page, func(item int) string {
+		return string(rune('a' + item))
+	})
+	assert.Equal(t, model.PageResponse[string]{
+		Items:      []string{"b", "c"},
+		NextCursor: "test-cursor",
+		Total:      &total,
+	}, response)
+
+	// Empty page should have empty items instead of null
+	response = newPageResponse(&coreRepository.Page[int]{}, func(item int) string { return "" })
+	assert.NotNil(t, response.Items)
+	assert.Empty(t, response.Items)
+}
@@ -1,20 +1,62 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
 	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 )
 
 // UserController is a controller for user management
-type UserController struct{}
+type UserController struct {
+	authService   coreAuth.BaseAuthService
+	userDBRepo    coreRepository.UserDBRepository
+	userCacheRepo coreRepository.UserCacheRepository
+}
 
 // NewUserController creates a new UserController
-func NewUserController() *UserController {
-	return &UserController{}
+func NewUserController(authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, userCacheRepo coreRepository.UserCacheRepository) *UserController {
+	return &UserController{
+		authService:   authService,
+		userDBRepo:    userDBRepo,
+		userCacheRepo: userCacheRepo,
+	}
+}
+
+// newGetUserResponse converts a user to response
+func newGetUserResponse(user *coreModel.User) model.GetUserResponse {
+	return model.GetUserResponse{
+		UserID:      user.UserID,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		PhotoURL:    user.PhotoURL,
+		Role:        string(user.GetRole()),
+	}
+}
+
+// checkUserOwner checks if the user in path is the token user
+func checkUserOwner(c *gin.Context, user *coreModel.User) error {
+	if c.Param("user_id") != user.UserID {
+		return model.NewError(model.ErrorCodeForbidden, "user ID does not match", nil)
+	}
+	return nil
+}
+
+// invalidateUserCache deletes all cached auth token users of the user, so the next request gets the latest user
+func (hc *UserController) invalidateUserCache(c *gin.Context, userID string) {
+	if hc.userCacheRepo == nil {
+		return
+	}
+	if err := hc.userCacheRepo.InvalidateUser(c, userID); err != nil {
+		coreErrReport.Record(c, fmt.Errorf("failed to invalidate user cache: %w", err))
+	}
 }
 
 // @Summary Get user
@@ -27,22 +69,104 @@ func NewUserController() *UserController {
 // @Success 200 {object} model.GetUserResponse
 func (hc *UserController) GetUser(c *gin.Context) {
 	user := c.MustGet("user").(*coreModel.User)
-	userID := c.Param("user_id")
 
-	if userID != user.UserID {
-		c.Error(model.HttpStatusCodeError{
-			StatusCode: http.StatusForbidden,
-			Message:    "User ID does not match",
-		})
+	if err := checkUserOwner(c, user); err != nil {
+		c.Error(err)
 		c.Abort()
 		return
 	}
 
-	c.JSON(200, model.GetUserResponse{
-		UserID:      user.UserID,
-		DisplayName: user.DisplayName,
-		Email:       user.Email,
-		PhoneNumber: user.PhoneNumber,
-		PhotoURL:    user.PhotoURL,
-	})
+	c.JSON(200, newGetUserResponse(user))
+}
+
+// @Summary Update user
+// @Description Update user profile, only display name, photo URL and phone number are editable
+// @Tags user
+// @Router /v1/user/{user_id} [patch]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Param request body model.UpdateUserRequest true "User"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.GetUserResponse
+func (hc *UserController) UpdateUser(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	if err := checkUserOwner(c, user); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	var request model.UpdateUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, err.Error(), err))
+		c.Abort()
+		return
+	}
+
+	updateData := map[string]any{}
+	if request.DisplayName != nil {
+		updateData["display_name"] = *request.DisplayName
+	}
+	if request.PhotoURL != nil {
+		updateData["photo_url"] = *request.PhotoURL
+	}
+	if request.PhoneNumber != nil {
+		updateData["phone_number"] = *request.PhoneNumber
+	}
+	if len(updateData) == 0 {
+		c.Error(model.NewError(model.ErrorCodeBadRequest, "no field to update", nil))
+		c.Abort()
+		return
+	}
+
+	if err := hc.userDBRepo.UpdateUserByID(c, user.UserID, updateData); err != nil {
+		c.Error(model.WrapError(err, "failed to update user"))
+		c.Abort()
+		return
+	}
+	hc.invalidateUserCache(c, user.UserID)
+
+	user, err := hc.userDBRepo.GetUserByID(c, user.UserID)
+	if err != nil {
+		c.Error(model.WrapError(err, "failed to get user"))
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, newGetUserResponse(user))
+}
+
+// @Summary Delete user
+// @Description Delete user account, the account in auth service is disabled as well
+// @Tags user
+// @Router /v1/user/{user_id} [delete]
+// @Security TokenAuth
+// @Param user_id path string true "User ID"
+// @Produce json
+// @Success 200 {object} model.MessageResponse
+func (hc *UserController) DeleteUser(c *gin.Context) {
+	user := c.MustGet("user").(*coreModel.User)
+
+	if err := checkUserOwner(c, user); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	// Disable auth account first, so the user cannot sign in again if deleting fails
+	if err := hc.authService.SetUserDisabled(c, user.FirebaseUID, true); err != nil {
+		c.Error(model.WrapError(err, "failed to disable user in auth service"))
+		c.Abort()
+		return
+	}
+
+	if err := hc.userDBRepo.DeleteUserByID(c, user.UserID); err != nil {
+		c.Error(model.WrapError(err, "failed to delete user"))
+		c.Abort()
+		return
+	}
+	hc.invalidateUserCache(c, user.UserID)
+
+	c.JSON(http.StatusOK, model.MessageResponse{Message: "ok"})
 }
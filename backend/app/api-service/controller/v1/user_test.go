@@ -1,35 +1,121 @@
 package v1
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
 	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
 )
 
+type MockAuthService struct {
+	coreAuth.BaseAuthService
+	SetUserDisabledFunc func(ctx context.Context, uid string, disabled bool) error
+}
+
+func (auth *MockAuthService) GetName() coreAuth.AuthServiceName {
+	return coreAuth.AuthServiceNameFirebase
+}
+
+func (auth *MockAuthService) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	return auth.SetUserDisabledFunc(ctx, uid, disabled)
+}
+
+type MockUserDBRepository struct {
+	coreRepository.UserDBRepository
+	GetUserByIDFunc    func(ctx context.Context, userID string) (*coreModel.User, error)
+	UpdateUserByIDFunc func(ctx context.Context, userID string, updateData map[string]any) error
+	DeleteUserByIDFunc func(ctx context.Context, userID string) error
+	ListUsersFunc      func(ctx context.Context, filter *coreRepository.UserFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.User], error)
+}
+
+func (repo *MockUserDBRepository) GetUserByID(ctx context.Context, userID string) (*coreModel.User, error) {
+	return repo.GetUserByIDFunc(ctx, userID)
+}
+
+func (repo *MockUserDBRepository) UpdateUserByID(ctx context.Context, userID string, updateData map[string]any) error {
+	return repo.UpdateUserByIDFunc(ctx, userID, updateData)
+}
+
+func (repo *MockUserDBRepository) DeleteUserByID(ctx context.Context, userID string) error {
+	return repo.DeleteUserByIDFunc(ctx, userID)
+}
+
+func (repo *MockUserDBRepository) ListUsers(ctx context.Context, filter *coreRepository.UserFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.User], error) {
+	return repo.ListUsersFunc(ctx, filter, pageOptions)
+}
+
+type MockUserCacheRepository struct {
+	coreRepository.UserCacheRepository
+	InvalidatedUserIDs []string
+	InvalidateUserErr  error
+}
+
+func (repo *MockUserCacheRepository) InvalidateUser(ctx context.Context, userID string) error {
+	if repo.InvalidateUserErr != nil {
+		return repo.InvalidateUserErr
+	}
+	repo.InvalidatedUserIDs = append(repo.InvalidatedUserIDs, userID)
+	return nil
+}
+
+func newMockUser() *coreModel.User {
+	return &coreModel.User{
+		UserID:      "test-user-id",
+		FirebaseUID: "test-firebase-uid",
+		DisplayName: "test-display-name",
+		Email:       "test-email",
+		PhoneNumber: "test-phone-number",
+		PhotoURL:    "test-photo-url",
+	}
+}
+
+func recordUserControllerRequest(t *testing.T, tokenUser *coreModel.User, authService *MockAuthService, userDBRepo *MockUserDBRepository, userCacheRepo *MockUserCacheRepository, method, path, body string) (int, string) {
+	userController := NewUserController(authService, userDBRepo, userCacheRepo)
+	var statusCode int
+	httpRecorder := utils.RegisterAndRecordHttpRequest(
+		func(router *gin.RouterGroup) {
+			router.Use(func(ctx *gin.Context) {
+				// Set user to context
+				ctx.Set("user", tokenUser)
+				ctx.Next()
+
+				// Get status code from error
+				if err := ctx.Errors.Last(); err != nil {
+					statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+				}
+			})
+			router.GET("/:user_id", userController.GetUser)
+			router.PATCH("/:user_id", userController.UpdateUser)
+			router.DELETE("/:user_id", userController.DeleteUser)
+		},
+		method,
+		path,
+		strings.NewReader(body),
+	)
+	if statusCode == 0 {
+		statusCode = httpRecorder.Code
+	}
+	return statusCode, httpRecorder.Body.String()
+}
+
 func TestGetUser(t *testing.T) {
 	testCases := []struct {
 		name        string
-		tokenUser   *coreModel.User
 		queryUserID string
 		statusCode  int
 		expected    *model.GetUserResponse
-		isErr       bool
 	}{
 		{
-			name: "user-owner",
-			tokenUser: &coreModel.User{
-				UserID:      "test-user-id",
-				DisplayName: "test-display-name",
-				Email:       "test-email",
-				PhoneNumber: "test-phone-number",
-				PhotoURL:    "test-photo-url",
-			},
+			name:        "user-owner",
 			queryUserID: "test-user-id",
 			statusCode:  http.StatusOK,
 			expected: &model.GetUserResponse{
@@ -38,51 +124,187 @@ func TestGetUser(t *testing.T) {
 				Email:       "test-email",
 				PhoneNumber: "test-phone-number",
 				PhotoURL:    "test-photo-url",
+				Role:        "user",
 			},
 		},
 		{
-			name: "user-not-owner",
-			tokenUser: &coreModel.User{
-				UserID:      "test-user-id",
-				DisplayName: "test-display-name",
-				Email:       "test-email",
-				PhoneNumber: "test-phone-number",
-				PhotoURL:    "test-photo-url",
-			},
+			name:        "user-not-owner",
 			queryUserID: "test-user-id-2",
 			statusCode:  http.StatusForbidden,
-			isErr:       true,
 		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			userController := NewUserController()
-			httpRecorder := utils.RegisterAndRecordHttpRequest(
-				func(router *gin.RouterGroup) {
-					router.Use(func(ctx *gin.Context) {
-						// Set user to context
-						ctx.Set("user", testCase.tokenUser)
-						ctx.Next()
-
-						// Check error
-						if testCase.isErr {
-							err := ctx.Errors.Last()
-							assert.NotNil(t, err)
-							assert.Equal(t, testCase.statusCode, err.Err.(model.HttpStatusCodeError).StatusCode)
-						}
-					})
-					router.GET("/:user_id", userController.GetUser)
-				},
-				"GET",
-				"/"+testCase.queryUserID,
-				nil,
-			)
+			statusCode, body := recordUserControllerRequest(t, newMockUser(), nil, nil, nil, "GET", "/"+testCase.queryUserID, "")
 
 			// Check response
-			if !testCase.isErr {
-				assert.Equal(t, testCase.statusCode, httpRecorder.Code)
-				assert.Equal(t, utils.ConvertToJSONString(testCase.expected), httpRecorder.Body.String())
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if testCase.expected != nil {
+				assert.Equal(t, utils.ConvertToJSONString(testCase.expected), body)
+			}
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	testCases := []struct {
+		name               string
+		queryUserID        string
+		body               string
+		expectedUpdateData map[string]any
+		updateUserErr      error
+		statusCode         int
+	}{
+		{
+			name:        "success",
+			queryUserID: "test-user-id",
+			body:        `{"display_name":"new-display-name","photo_url":"https://example.com/new.jpg","phone_number":"+886912345678"}`,
+			expectedUpdateData: map[string]any{
+				"display_name": "new-display-name",
+				"photo_url":    "https://example.com/new.jpg",
+				"phone_number": "+886912345678",
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:        "success/partial",
+			queryUserID: "test-user-id",
+			body:        `{"display_name":"new-display-name","email":"ignored@mediation-platform.com"}`,
+			expectedUpdateData: map[string]any{
+				"display_name": "new-display-name",
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:        "user-not-owner",
+			queryUserID: "test-user-id-2",
+			body:        `{"display_name":"new-display-name"}`,
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "invalid-request/no-field",
+			queryUserID: "test-user-id",
+			body:        `{}`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid-request/empty-display-name",
+			queryUserID: "test-user-id",
+			body:        `{"display_name":""}`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid-request/invalid-photo-url",
+			queryUserID: "test-user-id",
+			body:        `{"photo_url":"not-url"}`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid-request/invalid-phone-number",
+			queryUserID: "test-user-id",
+			body:        `{"phone_number":"0912345678"}`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:               "db-error",
+			queryUserID:        "test-user-id",
+			body:               `{"display_name":"new-display-name"}`,
+			expectedUpdateData: map[string]any{"display_name": "new-display-name"},
+			updateUserErr:      coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:         http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			user := newMockUser()
+			userDBRepo := &MockUserDBRepository{
+				UpdateUserByIDFunc: func(ctx context.Context, userID string, updateData map[string]any) error {
+					assert.Equal(t, user.UserID, userID)
+					assert.Equal(t, testCase.expectedUpdateData, updateData)
+					return testCase.updateUserErr
+				},
+				GetUserByIDFunc: func(ctx context.Context, userID string) (*coreModel.User, error) {
+					updatedUser := newMockUser()
+					updatedUser.DisplayName = "new-display-name"
+					return updatedUser, nil
+				},
+			}
+			userCacheRepo := &MockUserCacheRepository{}
+			statusCode, body := recordUserControllerRequest(t, user, &MockAuthService{}, userDBRepo, userCacheRepo, "PATCH", "/"+testCase.queryUserID, testCase.body)
+			assert.Equal(t, testCase.statusCode, statusCode)
+			if statusCode == http.StatusOK {
+				assert.Contains(t, body, `"display_name":"new-display-name"`)
+				assert.Equal(t, []string{user.UserID}, userCacheRepo.InvalidatedUserIDs)
+			} else {
+				assert.Empty(t, userCacheRepo.InvalidatedUserIDs)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	testCases := []struct {
+		name             string
+		queryUserID      string
+		disableUserErr   error
+		deleteUserErr    error
+		expectedDisabled bool
+		statusCode       int
+	}{
+		{
+			name:             "success",
+			queryUserID:      "test-user-id",
+			expectedDisabled: true,
+			statusCode:       http.StatusOK,
+		},
+		{
+			name:        "user-not-owner",
+			queryUserID: "test-user-id-2",
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:           "auth-service-error",
+			queryUserID:    "test-user-id",
+			disableUserErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
+			statusCode:     http.StatusInternalServerError,
+		},
+		{
+			name:             "db-error",
+			queryUserID:      "test-user-id",
+			deleteUserErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			expectedDisabled: true,
+			statusCode:       http.StatusInternalServerError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			user := newMockUser()
+			disabled := false
+			authService := &MockAuthService{
+				SetUserDisabledFunc: func(ctx context.Context, uid string, isDisabled bool) error {
+					assert.Equal(t, user.FirebaseUID, uid)
+					if testCase.disableUserErr != nil {
+						return testCase.disableUserErr
+					}
+					disabled = isDisabled
+					return nil
+				},
+			}
+			userDBRepo := &MockUserDBRepository{
+				DeleteUserByIDFunc: func(ctx context.Context, userID string) error {
+					assert.Equal(t, user.UserID, userID)
+					return testCase.deleteUserErr
+				},
+			}
+			userCacheRepo := &MockUserCacheRepository{}
+			statusCode, _ := recordUserControllerRequest(t, user, authService, userDBRepo, userCacheRepo, "DELETE", "/"+testCase.queryUserID, "")
+			assert.Equal(t, testCase.statusCode, statusCode)
+			assert.Equal(t, testCase.expectedDisabled, disabled)
+			if statusCode == http.StatusOK {
+				assert.Equal(t, []string{user.UserID}, userCacheRepo.InvalidatedUserIDs)
+			} else {
+				assert.Empty(t, userCacheRepo.InvalidatedUserIDs)
 			}
 		})
 	}
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/health/liveness": {
             "get": {
-                "description": "Liveness check",
+                "description": "Liveness check, it does not check dependencies",
                 "produces": [
                     "application/json"
                 ],
@@ -37,7 +37,7 @@ const docTemplate = `{
         },
         "/health/readiness": {
             "get": {
-                "description": "Readiness check",
+                "description": "Readiness check with status and latency of every dependency, results are cached briefly, it is down\nduring shutdown",
                 "produces": [
                     "application/json"
                 ],
@@ -45,6 +45,771 @@ const docTemplate = `{
                     "health"
                 ],
                 "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/audit-log": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "List audit logs of administrator actions, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List audit logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Actor user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user_disabled",
+                            "user_enabled",
+                            "user_force_logout"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of next page, can not be used with offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageResponse-model_AuditLogResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/user": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "List users for administrators, search by exact email, phone number or Firebase UID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone number",
+                        "name": "phone_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Firebase UID",
+                        "name": "firebase_uid",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of next page, can not be used with offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageResponse-model_AdminUserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/user/{user_id}/disable": {
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Disable user in database and auth service, cached tokens of the user are evicted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Disable user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AdminUserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/user/{user_id}/enable": {
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Enable user in database and auth service, cached failures of the user are evicted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Enable user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AdminUserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/user/{user_id}/logout": {
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Evict all cached tokens of the user, so every token is verified by auth service again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Force logout user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MessageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/issue": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "List issues the user participates in, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "List issues",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of next page, can not be used with offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageResponse-model_IssueResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Create an issue, the creator is always one of the parties, and other parties must be existing users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Create issue",
+                "parameters": [
+                    {
+                        "description": "Issue",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.IssueResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/issue/{issue_id}": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Get issue, only participants of the issue can get it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Get issue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.IssueResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Update issue, only the creator can update an open issue, and added parties must be existing users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Update issue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.IssueResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/issue/{issue_id}/ai-comment": {
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Generate a neutral mediator comment from the issue and its comments, and store it as an AI generated comment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Generate AI comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/issue/{issue_id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Close issue, only the creator can close an open issue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Close issue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.IssueResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/issue/{issue_id}/comment": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "List comments of the issue, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "List comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of next page, can not be used with offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageResponse-model_CommentResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Create a comment on the issue, set parent comment ID to reply to a comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Create comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/issue/{issue_id}/comment/{comment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Soft delete a comment, only the author can delete it, replies are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Delete comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MessageResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Edit a comment, only the author can edit it, AI generated comments cannot be edited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Edit comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EditCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Get user info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetUserResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Delete user account, the account in auth service is disabled as well",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -53,23 +818,204 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Update user profile, only display name, photo URL and phone number are editable",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetUserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/{user_id}/notification": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "List notifications of the user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of next page, can not be used with offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageResponse-model_NotificationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/{user_id}/notification/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Mark all unread notifications of the user as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Mark all notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MarkAllNotificationsReadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/{user_id}/notification/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "TokenAuth": []
+                    }
+                ],
+                "description": "Get the number of unread notifications of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Get unread notification count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnreadNotificationCountResponse"
+                        }
+                    }
+                }
             }
         },
-        "/v1/user/{user_id}": {
-            "get": {
+        "/v1/user/{user_id}/notification/{notification_id}/read": {
+            "post": {
                 "security": [
                     {
                         "TokenAuth": []
                     }
                 ],
-                "description": "Get user info",
+                "description": "Mark a notification of the user as read",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "notification"
                 ],
-                "summary": "Get user",
+                "summary": "Mark notification as read",
                 "parameters": [
                     {
                         "type": "string",
@@ -77,13 +1023,20 @@ const docTemplate = `{
                         "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "notification_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetUserResponse"
+                            "$ref": "#/definitions/model.MessageResponse"
                         }
                     }
                 }
@@ -91,6 +1044,199 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "model.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "disabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "Scott Li"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "example@mediation-platform.com"
+                },
+                "firebase_uid": {
+                    "type": "string",
+                    "example": "LRgwDJoRP7BCYJBNmNrNL4rxhvgR"
+                },
+                "last_login_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+886987654321"
+                },
+                "photo_url": {
+                    "type": "string",
+                    "example": "https://example.com/photo.jpg"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                }
+            }
+        },
+        "model.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "user_disabled"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "audit_log_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                }
+            }
+        },
+        "model.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "example": "000000000000000000000001"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "I would like to propose quiet hours after 10pm"
+                },
+                "comment_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "edited": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_ai_generated": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "issue_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "parent_comment_id": {
+                    "type": "string",
+                    "example": "000000000000000000000001"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                }
+            }
+        },
+        "model.CreateCommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "I would like to propose quiet hours after 10pm"
+                },
+                "parent_comment_id": {
+                    "type": "string",
+                    "example": "000000000000000000000001"
+                }
+            }
+        },
+        "model.CreateIssueRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Loud music after midnight every weekend"
+                },
+                "parties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "000000000000000000000002"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Noise from upstairs"
+                }
+            }
+        },
+        "model.DependencyCheckResponse": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "model.EditCommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "I would like to propose quiet hours after 11pm"
+                }
+            }
+        },
         "model.GetUserResponse": {
             "type": "object",
             "properties": {
@@ -110,12 +1256,72 @@ const docTemplate = `{
                     "type": "string",
                     "example": "https://example.com/photo.jpg"
                 },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
                 "user_id": {
                     "type": "string",
                     "example": "1234567890"
                 }
             }
         },
+        "model.IssueResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "000000000000000000000001"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Loud music after midnight every weekend"
+                },
+                "issue_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "parties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "000000000000000000000001",
+                        "000000000000000000000002"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Noise from upstairs"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                }
+            }
+        },
+        "model.MarkAllNotificationsReadResponse": {
+            "type": "object",
+            "properties": {
+                "updated_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
         "model.MessageResponse": {
             "type": "object",
             "properties": {
@@ -124,6 +1330,203 @@ const docTemplate = `{
                     "example": "ok"
                 }
             }
+        },
+        "model.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "notification_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "payload": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "read_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "comment_created"
+                }
+            }
+        },
+        "model.PageResponse-model_AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AdminUserResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ2IjoxfQ"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.PageResponse-model_AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditLogResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ2IjoxfQ"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.PageResponse-model_CommentResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ2IjoxfQ"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.PageResponse-model_IssueResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IssueResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ2IjoxfQ"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.PageResponse-model_NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NotificationResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ2IjoxfQ"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.DependencyCheckResponse"
+                    }
+                },
+                "shutting_down": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "model.UnreadNotificationCountResponse": {
+            "type": "object",
+            "properties": {
+                "unread_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.UpdateIssueRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Loud music after midnight every weekend"
+                },
+                "parties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "000000000000000000000002"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1,
+                    "example": "Noise from upstairs"
+                }
+            }
+        },
+        "model.UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Scott Li"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+886987654321"
+                },
+                "photo_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/photo.jpg"
+                }
+            }
         }
     },
     "securityDefinitions": {
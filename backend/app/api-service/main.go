@@ -2,78 +2,198 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/config"
 	"github.com/STLeee/mediation-platform/backend/app/api-service/docs"
 	"github.com/STLeee/mediation-platform/backend/app/api-service/middleware"
 	"github.com/STLeee/mediation-platform/backend/app/api-service/router"
+	coreAI "github.com/STLeee/mediation-platform/backend/core/ai"
 	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
 	coreCache "github.com/STLeee/mediation-platform/backend/core/cache"
 	coreDB "github.com/STLeee/mediation-platform/backend/core/db"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreHealth "github.com/STLeee/mediation-platform/backend/core/health"
+	coreLifecycle "github.com/STLeee/mediation-platform/backend/core/lifecycle"
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreMetrics "github.com/STLeee/mediation-platform/backend/core/metrics"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
 	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 	coreService "github.com/STLeee/mediation-platform/backend/core/service"
+	coreTracing "github.com/STLeee/mediation-platform/backend/core/tracing"
 )
 
 // @securityDefinitions.apikey TokenAuth
 // @in header
 // @name Authorization
 func main() {
-	// Parse arguments
+	// Parse arguments, -config is the default of -config flag of commands
 	configPath := flag.String("config", config.DefaultConfigPath, "Config file path")
+	flag.Usage = func() {
+		usage(flag.CommandLine.Output())
+	}
+	flag.Parse()
+
+	// Run command, serve if no command is given
+	if err := runCommand(flag.Args(), *configPath, os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		slog.Error("Failed to run api service", "error", err.Error())
+		os.Exit(1)
+	}
+}
 
+// Run service until it is stopped by signal, resources are closed in reverse order of init on exit or init failure
+func run(configPath string) (err error) {
 	// Load config
-	cfg, err := loadConfig(*configPath)
+	cfg, err := loadConfig(configPath)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to load config: %v", err))
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Set Gin mode
 	gin.SetMode(cfg.Server.GinMode)
 
+	// Init logger
+	logger, err := initLogger(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init logger: %w", err)
+	}
+
+	// Init lifecycle, resources initialized so far are closed if init fails
+	lifecycle := coreLifecycle.NewLifecycle(&cfg.Lifecycle)
+	defer func() {
+		if err != nil {
+			lifecycle.Shutdown(context.Background())
+		}
+	}()
+
+	// Init metrics
+	metrics, err := initMetrics()
+	if err != nil {
+		return fmt.Errorf("failed to init metrics: %w", err)
+	}
+
+	// Init tracer provider, spans buffered in exporter are flushed on exit
+	tracerProvider, err := initTracerProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init tracer provider: %w", err)
+	}
+	lifecycle.Register("tracer_provider", tracerProvider.Shutdown)
+
 	// Init auth service
 	authService, err := initAuthService(cfg)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to init auth service: %v", err))
+		return fmt.Errorf("failed to init auth service: %w", err)
 	}
 
 	// Init DB
 	mongoDB, err := initMongoDB(cfg)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to init MongoDB: %v", err))
+		return fmt.Errorf("failed to init MongoDB: %w", err)
 	}
+	mongoDB.SetMetrics(metrics)
+	lifecycle.Register("mongodb", func(ctx context.Context) error {
+		return mongoDB.Close()
+	})
 
 	// Init cache
 	redisCache, err := initRedisCache(cfg)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to init Redis cache: %v", err))
+		return fmt.Errorf("failed to init Redis cache: %w", err)
 	}
+	redisCache.SetMetrics(metrics)
+	lifecycle.Register("redis", func(ctx context.Context) error {
+		return redisCache.Close()
+	})
 
 	// Init repositories
-	repositories := initRepositories(mongoDB, redisCache, cfg)
+	repositories, err := initRepositories(mongoDB, redisCache, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init repositories: %w", err)
+	}
 
-	// Setup server
-	engine := gin.Default()
-	registerAPIRouters(engine, authService, repositories)
+	// Init error reporter, it is closed after HTTP server and notifier so errors of drained requests and
+	// queued deliveries are flushed
+	errorReporter, err := initErrorReporter(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init error reporter: %w", err)
+	}
+	lifecycle.Register("error_reporter", errorReporter.Close)
 
-	// Swagger
-	if cfg.Service.Environment == coreService.Testing {
-		docs.SwaggerInfo.Title = "Mediation Platform API Service"
-		docs.SwaggerInfo.Description = "API Service for Mediation Platform"
-		docs.SwaggerInfo.Version = "1.0"
-		docs.SwaggerInfo.Host = fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
-		docs.SwaggerInfo.BasePath = "/api"
-		docs.SwaggerInfo.Schemes = []string{"http", "https"}
-		engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Init notifier, it is closed before MongoDB since email channel looks up recipients on delivery
+	notifier, err := initNotifier(cfg, repositories)
+	if err != nil {
+		return fmt.Errorf("failed to init notifier: %w", err)
 	}
+	lifecycle.Register("notifier", notifier.Close)
+
+	// Init comment suggester
+	commentSuggester, err := initCommentSuggester(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init comment suggester: %w", err)
+	}
+
+	// Init authorization policy
+	policy, err := initAuthzPolicy(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init authorization policy: %w", err)
+	}
+
+	// Init health checker
+	healthChecker := initHealthChecker(cfg, authService, mongoDB, redisCache)
 
-	// Run server
-	engine.Run(fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port))
+	// Setup server, gin context falls back to request context, so request ID is carried into repository and auth calls
+	engine := gin.New()
+	engine.ContextWithFallback = true
+	engine.Use(gin.Recovery())
+	registerAPIRouters(engine, cfg.Authentication, apiDependencies{
+		AuthService:      authService,
+		Repositories:     repositories,
+		Notifier:         notifier,
+		CommentSuggester: commentSuggester,
+		Policy:           policy,
+		ErrorReporter:    errorReporter,
+		Logger:           logger,
+		Metrics:          metrics,
+		HealthChecker:    healthChecker,
+	})
+
+	// Swagger
+	registerSwaggerRouter(engine, cfg)
+
+	// Run server, on shutdown readiness turns down first, and the server keeps serving for the shutdown delay until load
+	// balancer observes it, then in-flight requests are drained before resources are closed. Metrics are served by a
+	// separate internal server which is closed after HTTP server, so metrics of drained requests are still scraped
+	servers := []*http.Server{}
+	if metricsServer := initMetricsServer(cfg, metrics); metricsServer != nil {
+		lifecycle.Register("metrics_server", metricsServer.Shutdown)
+		slog.Info("Metrics server is listening", "address", metricsServer.Addr)
+		servers = append(servers, metricsServer)
+	}
+	server := initServer(cfg, engine)
+	lifecycle.Register("http_server", server.Shutdown)
+	lifecycle.RegisterDelay("shutdown_delay")
+	lifecycle.Register("health_checker", healthChecker.Shutdown)
+	slog.Info("Server is listening", "address", server.Addr)
+	servers = append(servers, server)
+	return lifecycle.Run(context.Background(), func() error {
+		return serveServers(servers)
+	})
 }
 
 // Load config
@@ -86,6 +206,40 @@ func loadConfig(path string) (*config.Config, error) {
 	return cfg, nil
 }
 
+// Init logger, it is set as default logger so the standard log is structured as well
+func initLogger(cfg *config.Config) (*slog.Logger, error) {
+	logger, err := coreLogging.NewLogger(os.Stdout, cfg.Server.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(logger)
+
+	return logger, nil
+}
+
+// Init metrics, runtime metrics of Go and process are collected as well
+func initMetrics() (*coreMetrics.Metrics, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	metrics, err := coreMetrics.NewMetrics(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+// Init tracer provider, it is set as global so spans of core packages are exported by it
+func initTracerProvider(cfg *config.Config) (*sdktrace.TracerProvider, error) {
+	tracerProvider, err := coreTracing.NewTracerProvider(context.Background(), cfg.Service.Name, &cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	coreTracing.SetTracerProvider(tracerProvider)
+
+	return tracerProvider, nil
+}
+
 // Init auth service
 func initAuthService(cfg *config.Config) (coreAuth.BaseAuthService, error) {
 	authService, err := coreAuth.NewAuthService(context.Background(), &cfg.AuthService)
@@ -117,7 +271,7 @@ func initRedisCache(cfg *config.Config) (*coreCache.RedisCache, error) {
 }
 
 // Init repositories
-func initRepositories(mongoDB *coreDB.MongoDB, redisCache *coreCache.RedisCache, cfg *config.Config) map[coreRepository.RepositoryName]any {
+func initRepositories(mongoDB *coreDB.MongoDB, redisCache *coreCache.RedisCache, cfg *config.Config) (map[coreRepository.RepositoryName]any, error) {
 	repositories := make(map[coreRepository.RepositoryName]any)
 
 	// Init user db repository
@@ -125,33 +279,204 @@ func initRepositories(mongoDB *coreDB.MongoDB, redisCache *coreCache.RedisCache,
 	repositories[coreRepository.RepositoryNameUserDB] = userDBRepo
 
 	// Init user cache repository
-	userCacheRepo := coreRepository.NewUserRedisCacheRepository(redisCache, cfg.Repositories.UserCache)
+	userCacheRepo, err := coreRepository.NewUserRedisCacheRepository(redisCache, cfg.Repositories.UserCache)
+	if err != nil {
+		return nil, err
+	}
 	repositories[coreRepository.RepositoryNameUserCache] = userCacheRepo
 
-	return repositories
+	// Init issue db repository
+	issueDBRepo := coreRepository.NewIssueMongoDBRepository(mongoDB, cfg.Repositories.IssueDB)
+	repositories[coreRepository.RepositoryNameIssueDB] = issueDBRepo
+
+	// Init comment db repository
+	commentDBRepo := coreRepository.NewCommentMongoDBRepository(mongoDB, cfg.Repositories.CommentDB)
+	repositories[coreRepository.RepositoryNameCommentDB] = commentDBRepo
+
+	// Init notification db repository
+	notificationDBRepo := coreRepository.NewNotificationMongoDBRepository(mongoDB, cfg.Repositories.NotificationDB)
+	repositories[coreRepository.RepositoryNameNotificationDB] = notificationDBRepo
+
+	// Init audit log db repository
+	auditLogDBRepo := coreRepository.NewAuditLogMongoDBRepository(mongoDB, cfg.Repositories.AuditLogDB)
+	repositories[coreRepository.RepositoryNameAuditLogDB] = auditLogDBRepo
+
+	return repositories, nil
 }
 
-// Register API routers
-func registerAPIRouters(engine *gin.Engine, authService coreAuth.BaseAuthService, repositories map[coreRepository.RepositoryName]any) {
+// Init notifier, notifications queued for background delivery are delivered before it is closed
+func initNotifier(cfg *config.Config, repositories map[coreRepository.RepositoryName]any) (coreNotification.Notifier, error) {
+	notificationDBRepo, _ := repositories[coreRepository.RepositoryNameNotificationDB].(coreRepository.NotificationDBRepository)
 	userDBRepo, _ := repositories[coreRepository.RepositoryNameUserDB].(coreRepository.UserDBRepository)
-	userCacheRepo, _ := repositories[coreRepository.RepositoryNameUserCache].(coreRepository.UserCacheRepository)
+
+	notifier, err := coreNotification.NewNotifier(&cfg.Notification, notificationDBRepo, userDBRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifier, nil
+}
+
+// Init comment suggester
+func initCommentSuggester(cfg *config.Config) (coreAI.CommentSuggester, error) {
+	commentSuggester, err := coreAI.NewCommentSuggester(&cfg.AI)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentSuggester, nil
+}
+
+// Init authorization policy
+func initAuthzPolicy(cfg *config.Config) (*coreAuthz.Policy, error) {
+	policy, err := coreAuthz.NewPolicy(&cfg.Authz)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Init error reporter
+func initErrorReporter(cfg *config.Config) (coreErrReport.ErrorReporter, error) {
+	errorReporter, err := coreErrReport.NewErrorReporter(&cfg.ErrorReport)
+	if err != nil {
+		return nil, err
+	}
+
+	return errorReporter, nil
+}
+
+// Init HTTP server
+func initServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+		Handler: handler,
+	}
+}
+
+// Init metrics server, metrics are served on a separate port which is not exposed publicly, nil is returned when the
+// metrics port is 0
+func initMetricsServer(cfg *config.Config, metrics *coreMetrics.Metrics) *http.Server {
+	if cfg.Server.MetricsPort == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	return &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.MetricsPort),
+		Handler: mux,
+	}
+}
+
+// Serve HTTP servers until all of them are shut down, the first error of them is returned without waiting for others
+func serveServers(servers []*http.Server) error {
+	errCh := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+				return
+			}
+			errCh <- nil
+		}()
+	}
+	for range servers {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Init health checker, Redis is not critical since authentication falls back to auth service without cache, and auth
+// service is not critical since cached users and tokens verified by cached public keys are served without it, so an
+// outage of auth service does not take every instance out of traffic
+func initHealthChecker(cfg *config.Config, authService coreAuth.BaseAuthService, mongoDB *coreDB.MongoDB, redisCache *coreCache.RedisCache) *coreHealth.HealthChecker {
+	return coreHealth.NewHealthChecker(&cfg.HealthCheck,
+		coreHealth.Dependency{Name: "mongodb", Checker: mongoDB, Critical: true},
+		coreHealth.Dependency{Name: "redis", Checker: redisCache, Critical: false},
+		coreHealth.Dependency{Name: "auth_service", Checker: authService, Critical: false},
+	)
+}
+
+// apiDependencies are dependencies of API routers, zero value registers routes without dependencies, like for printing
+// the route table
+type apiDependencies struct {
+	AuthService      coreAuth.BaseAuthService
+	Repositories     map[coreRepository.RepositoryName]any
+	Notifier         coreNotification.Notifier
+	CommentSuggester coreAI.CommentSuggester
+	Policy           *coreAuthz.Policy
+	ErrorReporter    coreErrReport.ErrorReporter
+	Logger           *slog.Logger
+	Metrics          *coreMetrics.Metrics
+	HealthChecker    *coreHealth.HealthChecker
+}
+
+// Register API routers
+func registerAPIRouters(engine *gin.Engine, authenticationCfg config.AuthenticationConfig, deps apiDependencies) {
+	userDBRepo, _ := deps.Repositories[coreRepository.RepositoryNameUserDB].(coreRepository.UserDBRepository)
+	userCacheRepo, _ := deps.Repositories[coreRepository.RepositoryNameUserCache].(coreRepository.UserCacheRepository)
+	issueDBRepo, _ := deps.Repositories[coreRepository.RepositoryNameIssueDB].(coreRepository.IssueDBRepository)
+	commentDBRepo, _ := deps.Repositories[coreRepository.RepositoryNameCommentDB].(coreRepository.CommentDBRepository)
+	notificationDBRepo, _ := deps.Repositories[coreRepository.RepositoryNameNotificationDB].(coreRepository.NotificationDBRepository)
+	auditLogDBRepo, _ := deps.Repositories[coreRepository.RepositoryNameAuditLogDB].(coreRepository.AuditLogDBRepository)
 
 	// Register middleware
+	engine.Use(middleware.RequestIDHandler())
+	engine.Use(middleware.TracingHandler())
+	engine.Use(middleware.AccessLogHandler(deps.Logger))
+	engine.Use(middleware.MetricsHandler(deps.Metrics))
 	engine.Use(middleware.CorsHandler())
-	engine.Use(middleware.ErrorHandler())
+	engine.Use(middleware.ErrorHandler(deps.ErrorReporter))
 
 	// Register API routers
 	apiRouterGroup := engine.Group("/api")
 
 	// Register health router
 	healthRouterGroup := apiRouterGroup.Group("/health")
-	router.RegisterHealthRouter(healthRouterGroup)
+	router.RegisterHealthRouter(healthRouterGroup, deps.HealthChecker)
 
 	// Register v1 router
 	v1RouterGroup := apiRouterGroup.Group("/v1")
-	v1RouterGroup.Use(middleware.TokenAuthenticationHandler(authService, userDBRepo, userCacheRepo))
+	v1RouterGroup.Use(middleware.TokenAuthenticationHandler(deps.AuthService, userDBRepo, userCacheRepo, authenticationCfg))
 
 	// Register v1 user router
 	userRouterGroup := v1RouterGroup.Group("/user")
-	router.RegisterV1UserRouter(userRouterGroup)
+	router.RegisterV1UserRouter(userRouterGroup, deps.AuthService, userDBRepo, userCacheRepo, deps.Policy)
+
+	// Register v1 notification router, it inherits the user authorization from user router
+	notificationRouterGroup := userRouterGroup.Group("/:user_id/notification")
+	router.RegisterV1NotificationRouter(notificationRouterGroup, notificationDBRepo, deps.Policy)
+
+	// Register v1 issue router
+	issueRouterGroup := v1RouterGroup.Group("/issue")
+	router.RegisterV1IssueRouter(issueRouterGroup, issueDBRepo, userDBRepo, deps.Notifier, deps.Policy)
+
+	// Register v1 comment router
+	commentRouterGroup := issueRouterGroup.Group("/:issue_id/comment")
+	router.RegisterV1CommentRouter(commentRouterGroup, issueDBRepo, commentDBRepo, deps.Notifier, deps.Policy)
+
+	// Register v1 AI comment router
+	aiCommentRouterGroup := issueRouterGroup.Group("/:issue_id/ai-comment")
+	router.RegisterV1AICommentRouter(aiCommentRouterGroup, issueDBRepo, commentDBRepo, deps.CommentSuggester, deps.Notifier, deps.Policy)
+
+	// Register v1 admin router
+	adminRouterGroup := v1RouterGroup.Group("/admin")
+	router.RegisterV1AdminRouter(adminRouterGroup, deps.AuthService, userDBRepo, userCacheRepo, auditLogDBRepo, deps.Policy)
+}
+
+// Register swagger router, it is only registered in testing environment
+func registerSwaggerRouter(engine *gin.Engine, cfg *config.Config) {
+	if cfg.Service.Environment != coreService.Testing {
+		return
+	}
+	docs.SwaggerInfo.Title = "Mediation Platform API Service"
+	docs.SwaggerInfo.Description = "API Service for Mediation Platform"
+	docs.SwaggerInfo.Version = "1.0"
+	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	docs.SwaggerInfo.BasePath = "/api"
+	docs.SwaggerInfo.Schemes = []string{"http", "https"}
+	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
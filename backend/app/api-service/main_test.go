@@ -1,16 +1,31 @@
 package main
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/config"
+	coreAI "github.com/STLeee/mediation-platform/backend/core/ai"
 	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreMetrics "github.com/STLeee/mediation-platform/backend/core/metrics"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	coreTracing "github.com/STLeee/mediation-platform/backend/core/tracing"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
 )
 
@@ -56,6 +71,100 @@ func TestLoadConfig_FileNotFound(t *testing.T) {
 	assert.Nil(t, loadedCfg)
 }
 
+func TestInitLogger(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	testCases := []struct {
+		name    string
+		config  *config.Config
+		isError bool
+	}{
+		{
+			name:    "default-level",
+			config:  &config.Config{},
+			isError: false,
+		},
+		{
+			name: "debug-level",
+			config: &config.Config{
+				Server: config.ServerConfig{LogLevel: coreLogging.LogLevelDebug},
+			},
+			isError: false,
+		},
+		{
+			name: "invalid-level",
+			config: &config.Config{
+				Server: config.ServerConfig{LogLevel: "verbose"},
+			},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			logger, err := initLogger(testCase.config)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, logger)
+				assert.Equal(t, logger, slog.Default())
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestInitMetrics(t *testing.T) {
+	metrics, err := initMetrics()
+	assert.NoError(t, err)
+	assert.NotNil(t, metrics)
+
+	// Runtime metrics are collected
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	names := []string{}
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, "go_goroutines")
+}
+
+func TestInitTracerProvider(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	testCases := []struct {
+		name    string
+		config  *config.Config
+		isError bool
+	}{
+		{
+			name:    "without-exporter",
+			config:  &config.Config{},
+			isError: false,
+		},
+		{
+			name: "otlp-without-endpoint",
+			config: &config.Config{
+				Tracing: coreTracing.TracingConfig{OTLP: &coreTracing.OTLPExporterConfig{}},
+			},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tracerProvider, err := initTracerProvider(testCase.config)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, tracerProvider)
+				assert.Equal(t, tracerProvider, otel.GetTracerProvider())
+				assert.NoError(t, tracerProvider.Shutdown(context.Background()))
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestInitAuthService(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -132,12 +241,229 @@ func TestInitMongoDB(t *testing.T) {
 	}
 }
 
+func TestInitNotifier(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  *config.Config
+		isError bool
+	}{
+		{
+			name:    "no-config",
+			config:  &config.Config{},
+			isError: false,
+		},
+		{
+			name: "channel-not-enabled",
+			config: &config.Config{
+				Notification: coreNotification.NotificationConfig{
+					Routes: map[coreModel.NotificationType][]coreNotification.ChannelName{
+						coreModel.NotificationTypeIssueInvited: {coreNotification.ChannelNameEmail},
+					},
+				},
+			},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notifier, err := initNotifier(testCase.config, map[coreRepository.RepositoryName]any{})
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, notifier)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestInitCommentSuggester(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  *config.Config
+		isError bool
+	}{
+		{
+			name: "valid-config",
+			config: &config.Config{
+				AI: coreAI.CommentSuggesterConfig{
+					FakeCommentSuggesterConfig: &coreAI.FakeCommentSuggesterConfig{},
+				},
+			},
+			isError: false,
+		},
+		{
+			name:    "no-config",
+			config:  &config.Config{},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			commentSuggester, err := initCommentSuggester(testCase.config)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, commentSuggester)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestInitAuthzPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  *config.Config
+		isError bool
+	}{
+		{
+			name:    "default-rules",
+			config:  &config.Config{},
+			isError: false,
+		},
+		{
+			name: "invalid-rule",
+			config: &config.Config{
+				Authz: coreAuthz.PolicyConfig{
+					Rules: []coreAuthz.Rule{{Role: coreModel.UserRoleUser}},
+				},
+			},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := initAuthzPolicy(testCase.config)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, policy)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestInitErrorReporter(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  *config.Config
+		isError bool
+	}{
+		{
+			name:    "default-reporter",
+			config:  &config.Config{},
+			isError: false,
+		},
+		{
+			name: "invalid-file-reporter",
+			config: &config.Config{
+				ErrorReport: coreErrReport.ErrorReporterConfig{
+					File: &coreErrReport.FileReporterConfig{},
+				},
+			},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			errorReporter, err := initErrorReporter(testCase.config)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, errorReporter)
+				assert.NoError(t, errorReporter.Close(context.Background()))
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestInitServer(t *testing.T) {
+	server := initServer(&config.Config{
+		Server: config.ServerConfig{Host: "localhost", Port: 8080},
+	}, http.NotFoundHandler())
+	assert.Equal(t, "localhost:8080", server.Addr)
+	assert.NotNil(t, server.Handler)
+}
+
+func TestInitMetricsServer(t *testing.T) {
+	metrics, err := coreMetrics.NewMetrics(prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	// Disabled
+	server := initMetricsServer(&config.Config{
+		Server: config.ServerConfig{Host: "localhost", Port: 8080},
+	}, metrics)
+	assert.Nil(t, server)
+
+	// Enabled
+	server = initMetricsServer(&config.Config{
+		Server: config.ServerConfig{Host: "localhost", Port: 8080, MetricsPort: 9091},
+	}, metrics)
+	assert.Equal(t, "localhost:9091", server.Addr)
+	httpRecorder := httptest.NewRecorder()
+	server.Handler.ServeHTTP(httpRecorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusOK, httpRecorder.Code)
+	httpRecorder = httptest.NewRecorder()
+	server.Handler.ServeHTTP(httpRecorder, httptest.NewRequest("GET", "/api/health/liveness", nil))
+	assert.Equal(t, http.StatusNotFound, httpRecorder.Code)
+}
+
+func TestServeServers(t *testing.T) {
+	// Shut down servers
+	servers := []*http.Server{
+		{Addr: "localhost:0", Handler: http.NotFoundHandler()},
+		{Addr: "localhost:0", Handler: http.NotFoundHandler()},
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serveServers(servers)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	for _, server := range servers {
+		assert.NoError(t, server.Shutdown(context.Background()))
+	}
+	assert.NoError(t, <-errCh)
+
+	// Invalid address
+	err := serveServers([]*http.Server{{Addr: "invalid-address", Handler: http.NotFoundHandler()}})
+	assert.Error(t, err)
+}
+
+func TestInitHealthChecker(t *testing.T) {
+	healthChecker := initHealthChecker(&config.Config{}, nil, nil, nil)
+	assert.NotNil(t, healthChecker)
+}
+
 func TestRegisterRouters(t *testing.T) {
 	utils.TestEngineRouterRegister(t, func(engine *gin.Engine) {
-		registerAPIRouters(engine, nil, nil)
+		registerAPIRouters(engine, config.AuthenticationConfig{}, apiDependencies{})
 	}, []string{
 		"/api/health/liveness",
 		"/api/health/readiness",
 		"/api/v1/user/:user_id",
+		"/api/v1/user/:user_id",
+		"/api/v1/user/:user_id",
+		"/api/v1/user/:user_id/notification",
+		"/api/v1/user/:user_id/notification/unread-count",
+		"/api/v1/user/:user_id/notification/read-all",
+		"/api/v1/user/:user_id/notification/:notification_id/read",
+		"/api/v1/issue",
+		"/api/v1/issue",
+		"/api/v1/issue/:issue_id",
+		"/api/v1/issue/:issue_id",
+		"/api/v1/issue/:issue_id/close",
+		"/api/v1/issue/:issue_id/comment",
+		"/api/v1/issue/:issue_id/comment",
+		"/api/v1/issue/:issue_id/comment/:comment_id",
+		"/api/v1/issue/:issue_id/comment/:comment_id",
+		"/api/v1/issue/:issue_id/ai-comment",
+		"/api/v1/admin/user",
+		"/api/v1/admin/user/:user_id/disable",
+		"/api/v1/admin/user/:user_id/enable",
+		"/api/v1/admin/user/:user_id/logout",
+		"/api/v1/admin/audit-log",
 	})
 }
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// AccessLogHandler is a middleware for access logs, every request is logged after it is handled, with user ID set by
+// authentication, server errors are logged in error level and client errors in warn level
+func AccessLogHandler(logger *slog.Logger) gin.HandlerFunc {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		statusCode := c.Writer.Status()
+		level := slog.LevelInfo
+		if statusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if statusCode >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", statusCode),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if user, ok := c.Get("user"); ok {
+			if user, ok := user.(*coreModel.User); ok && user != nil {
+				attrs = append(attrs, slog.String("user_id", user.UserID))
+			}
+		}
+		logger.LogAttrs(c.Request.Context(), level, "access", attrs...)
+	}
+}
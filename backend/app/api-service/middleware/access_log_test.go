@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func TestAccessLogHandler(t *testing.T) {
+	testCases := []struct {
+		name          string
+		user          *coreModel.User
+		statusCode    int
+		expectedLevel string
+		expectedUser  any
+	}{
+		{
+			name:          "success",
+			user:          &coreModel.User{UserID: "test-user-id"},
+			statusCode:    http.StatusOK,
+			expectedLevel: "INFO",
+			expectedUser:  "test-user-id",
+		},
+		{
+			name:          "anonymous/client-error",
+			statusCode:    http.StatusUnauthorized,
+			expectedLevel: "WARN",
+		},
+		{
+			name:          "server-error",
+			user:          &coreModel.User{UserID: "test-user-id"},
+			statusCode:    http.StatusInternalServerError,
+			expectedLevel: "ERROR",
+			expectedUser:  "test-user-id",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			logger, err := coreLogging.NewLogger(&buffer, coreLogging.LogLevelInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			httpRecorder := utils.RegisterAndRecordHttpRequest(func(routeGroup *gin.RouterGroup) {
+				routeGroup.Use(RequestIDHandler(), AccessLogHandler(logger))
+				routeGroup.Handle("GET", "/test/:id", func(c *gin.Context) {
+					if testCase.user != nil {
+						c.Set("user", testCase.user)
+					}
+					c.JSON(testCase.statusCode, gin.H{})
+				})
+			}, "GET", "/test/1", nil)
+
+			record := map[string]any{}
+			assert.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+			assert.Equal(t, testCase.expectedLevel, record["level"])
+			assert.Equal(t, "access", record["msg"])
+			assert.Equal(t, "GET", record["method"])
+			assert.Equal(t, "/test/1", record["path"])
+			assert.Equal(t, "/test/:id", record["route"])
+			assert.Equal(t, float64(testCase.statusCode), record["status"])
+			assert.Equal(t, testCase.expectedUser, record["user_id"])
+			assert.Equal(t, httpRecorder.Header().Get(RequestIDHeader), record[coreLogging.RequestIDAttrKey])
+		})
+	}
+}
+
+func TestAccessLogHandler_DefaultLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buffer, nil)))
+
+	utils.RegisterAndRecordHttpRequest(func(routeGroup *gin.RouterGroup) {
+		routeGroup.Use(AccessLogHandler(nil))
+		routeGroup.Handle("GET", "/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{})
+		})
+	}, "GET", "/test", nil)
+
+	assert.Contains(t, buffer.String(), `"msg":"access"`)
+}
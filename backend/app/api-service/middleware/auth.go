@@ -2,44 +2,84 @@ package middleware
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/STLeee/mediation-platform/backend/app/api-service/config"
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
 	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	"github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreMetrics "github.com/STLeee/mediation-platform/backend/core/metrics"
 	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
 	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 )
 
-func authenticateUserByToken(ctx context.Context, authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, token string) (*coreModel.User, error) {
+// userProfileFields maps names of profile fields to the fields of user, for syncing them from auth service
+var userProfileFields = map[string]func(user *coreModel.User) *string{
+	"display_name": func(user *coreModel.User) *string { return &user.DisplayName },
+	"email":        func(user *coreModel.User) *string { return &user.Email },
+	"phone_number": func(user *coreModel.User) *string { return &user.PhoneNumber },
+	"photo_url":    func(user *coreModel.User) *string { return &user.PhotoURL },
+}
+
+// isDisabledStale returns whether disabled flag of the user should be re-synced from auth service
+func isDisabledStale(user *coreModel.User, cfg config.AuthenticationConfig, now time.Time) bool {
+	return cfg.DisabledSyncInterval > 0 && now.Sub(user.DisabledSyncedAt) >= cfg.DisabledSyncInterval
+}
+
+// syncUserOnLogin updates last login time of the user, and reconciles the provider fields and disabled flag from
+// auth service, user info is fetched only when there are provider fields or the disabled flag is stale,
+// failures keep the user in database
+func syncUserOnLogin(ctx context.Context, authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, authUID string, user *coreModel.User, cfg config.AuthenticationConfig) {
+	now := time.Now()
+	updateData := map[string]any{
+		"last_login_at": now,
+	}
+
+	disabledStale := isDisabledStale(user, cfg, now)
+	if len(cfg.ProviderFields) > 0 || disabledStale {
+		userFromAuth, err := authService.GetUserInfo(ctx, authUID)
+		if err != nil {
+			errreport.Record(ctx, fmt.Errorf("failed to get user info from auth service: %w", err))
+		} else {
+			for _, field := range cfg.ProviderFields {
+				getField, ok := userProfileFields[field]
+				if !ok {
+					continue
+				}
+				if value := *getField(userFromAuth); value != *getField(user) {
+					updateData[field] = value
+					*getField(user) = value
+				}
+			}
+			updateData["disabled"] = userFromAuth.Disabled
+			updateData["disabled_synced_at"] = now
+			user.Disabled = userFromAuth.Disabled
+			user.DisabledSyncedAt = now
+		}
+	}
+
+	if err := userDBRepo.UpdateUserByID(ctx, user.UserID, updateData); err != nil {
+		errreport.Record(ctx, fmt.Errorf("failed to sync user on login: %w", err))
+	}
+	user.LastLoginAt = now
+}
+
+// authenticateUserByToken authenticates the token and gets or creates the user, existing user is synced on login
+func authenticateUserByToken(ctx context.Context, authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, token string, cfg config.AuthenticationConfig) (*coreModel.User, error) {
 	// Empty token
 	if token == "" {
-		return nil, model.HttpStatusCodeError{
-			StatusCode: http.StatusUnauthorized,
-			Message:    "empty token",
-		}
+		return nil, model.NewError(model.ErrorCodeTokenEmpty, "", nil)
 	}
 
 	// Authenticate user by token
 	authUID, err := authService.AuthenticateByToken(ctx, token)
 	if err != nil {
-		if authServiceError, ok := err.(coreAuth.AuthServiceError); ok {
-			errType := authServiceError.ErrType
-			if errType == coreAuth.AuthServiceErrorTypeTokenInvalid || errType == coreAuth.AuthServiceErrorTypeUserNotFound {
-				return nil, model.HttpStatusCodeError{
-					StatusCode: http.StatusUnauthorized,
-					Err:        err,
-				}
-			}
-		}
-		return nil, model.HttpStatusCodeError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to authenticate user by token",
-			Err:        err,
-		}
+		return nil, model.WrapError(err, "failed to authenticate user by token")
 	}
 
 	// Get user from MongoDB
@@ -49,69 +89,66 @@ func authenticateUserByToken(ctx context.Context, authService coreAuth.BaseAuthS
 		if repositoryError, ok := err.(coreRepository.RepositoryError); ok && repositoryError.ErrType == coreRepository.RepositoryErrorTypeRecordNotFound {
 			userFromAuth, err := authService.GetUserInfo(ctx, authUID)
 			if err != nil {
-				return nil, model.HttpStatusCodeError{
-					StatusCode: http.StatusInternalServerError,
-					Message:    "failed to get user info from auth service",
-					Err:        err,
-				}
-			}
-			userID, err := userDBRepo.CreateUser(ctx, userFromAuth)
-			if err != nil {
-				return nil, model.HttpStatusCodeError{
-					StatusCode: http.StatusInternalServerError,
-					Message:    "failed to create user",
-					Err:        err,
-				}
+				return nil, model.WrapError(err, "failed to get user info from auth service")
 			}
-			user, err = userDBRepo.GetUserByID(ctx, userID)
+			// Concurrent first logins of the user get the same user
+			userFromAuth.DisabledSyncedAt = time.Now()
+			user, err = userDBRepo.GetOrCreateUserByAuthUID(ctx, authService.GetName(), authUID, userFromAuth)
 			if err != nil {
-				return nil, model.HttpStatusCodeError{
-					StatusCode: http.StatusInternalServerError,
-					Message:    "failed to get user",
-					Err:        err,
-				}
+				return nil, model.WrapError(err, "failed to create user")
 			}
 		} else {
-			return nil, model.HttpStatusCodeError{
-				StatusCode: http.StatusInternalServerError,
-				Message:    "failed to get user by auth UID",
-				Err:        err,
-			}
+			return nil, model.WrapError(err, "failed to get user by auth UID")
 		}
+	} else {
+		syncUserOnLogin(ctx, authService, userDBRepo, authUID, user, cfg)
 	}
 
 	return user, nil
 }
 
-func newErrorUser(err model.HttpStatusCodeError) *coreModel.User {
-	return &coreModel.User{
-		UserID:      "error",
-		DisplayName: err.Message,
-		PhoneNumber: strconv.Itoa(err.StatusCode),
-		Disabled:    true,
+// authFailureOutcome classifies the failure of token authentication, client errors are rejected tokens, and server
+// errors are failures of auth service or the others like MongoDB
+func authFailureOutcome(err error) coreMetrics.AuthOutcome {
+	if httpStatusCodeError, ok := err.(model.HttpStatusCodeError); ok && httpStatusCodeError.StatusCode < http.StatusInternalServerError {
+		if httpStatusCodeError.Code == model.ErrorCodeTokenEmpty {
+			return coreMetrics.AuthOutcomeMissingToken
+		}
+		return coreMetrics.AuthOutcomeInvalidToken
 	}
+	var authServiceError coreAuth.AuthServiceError
+	if errors.As(err, &authServiceError) {
+		return coreMetrics.AuthOutcomeProviderFailure
+	}
+	return coreMetrics.AuthOutcomeInternalError
 }
 
-func parseErrorUser(user *coreModel.User) (*coreModel.User, error) {
-	if user == nil {
-		return nil, nil
+// setAuthTokenFailureToCache sets failure of the token to cache, so the token is not authenticated again
+func setAuthTokenFailureToCache(c *gin.Context, authService coreAuth.BaseAuthService, userCacheRepo coreRepository.UserCacheRepository, token string, err model.HttpStatusCodeError, userID string) {
+	if userCacheRepo == nil {
+		return
 	}
-	if user.UserID == "error" {
-		statusCode, _ := strconv.Atoi(user.PhoneNumber)
-		return nil, model.HttpStatusCodeError{
-			StatusCode: statusCode,
-			Message:    user.DisplayName,
-		}
+	failure := &coreRepository.AuthTokenFailure{
+		StatusCode: err.StatusCode,
+		Code:       err.Code,
+		Reason:     err.Message,
+		UserID:     userID,
+	}
+	if cacheErr := userCacheRepo.SetAuthTokenFailure(c, authService.GetName(), token, failure); cacheErr != nil {
+		errreport.Record(c, fmt.Errorf("failed to set failure to cache: %w", cacheErr))
 	}
-	return user, nil
 }
 
-// TokenAuthenticationHandler is a middleware for token authentication
-func TokenAuthenticationHandler(authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, userCacheRepo coreRepository.UserCacheRepository) gin.HandlerFunc {
+// TokenAuthenticationHandler is a middleware for token authentication, disabled users are rejected with 403,
+// and their failures are cached so they do not authenticate against auth service on every request, cached users are
+// served until their disabled flag is stale. Outcomes of cache and auth service, and failures by cause, are counted to
+// metrics set by MetricsHandler
+func TokenAuthenticationHandler(authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, userCacheRepo coreRepository.UserCacheRepository, cfg config.AuthenticationConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from header
 		token := c.GetHeader("Authorization")
 		if len(token) < 8 || token[:7] != "Bearer " {
+			getMetrics(c).IncAuthOutcome(coreMetrics.AuthOutcomeMissingToken)
 			c.Next()
 			return
 		}
@@ -119,60 +156,66 @@ func TokenAuthenticationHandler(authService coreAuth.BaseAuthService, userDBRepo
 
 		var user *coreModel.User
 
-		// Get user from cache
+		// Get user or failure from cache, cached user with stale disabled flag is authenticated again, so user disabled
+		// in auth service is rejected within the sync interval rather than the cache TTL
 		if userCacheRepo != nil {
-			var cacheErr error
-			user, cacheErr = userCacheRepo.GetAuthTokenUser(c, authService.GetName(), token)
+			entry, cacheErr := userCacheRepo.GetAuthTokenEntry(c, authService.GetName(), token)
 			if cacheErr != nil {
-				if repositoryError, ok := cacheErr.(coreRepository.RepositoryError); ok && repositoryError.ErrType == coreRepository.RepositoryErrorTypeRecordNotFound {
-					user = nil
-				} else {
-					// TODO: record error
-					log.Printf("failed to get user from cache: %v", cacheErr)
-				}
-			} else {
-				var err error
-				user, err = parseErrorUser(user)
-				if err != nil {
-					c.Error(err)
-					c.Abort()
-					return
+				if repositoryError, ok := cacheErr.(coreRepository.RepositoryError); !ok || repositoryError.ErrType != coreRepository.RepositoryErrorTypeRecordNotFound {
+					errreport.Record(c, fmt.Errorf("failed to get user from cache: %w", cacheErr))
 				}
+			} else if entry.Failure != nil {
+				getMetrics(c).IncAuthOutcome(coreMetrics.AuthOutcomeCacheNegativeHit)
+				c.Error(model.ResolveError(model.HttpStatusCodeError{
+					StatusCode: entry.Failure.StatusCode,
+					Code:       entry.Failure.Code,
+					Message:    entry.Failure.Reason,
+				}))
+				c.Abort()
+				return
+			} else if !isDisabledStale(entry.User, cfg, time.Now()) {
+				getMetrics(c).IncAuthOutcome(coreMetrics.AuthOutcomeCacheHit)
+				user = entry.User
 			}
 		}
+		cached := user != nil
 
 		if user == nil {
 			// Authenticate user by token
 			var err error
-			user, err = authenticateUserByToken(c, authService, userDBRepo, token)
+			user, err = authenticateUserByToken(c, authService, userDBRepo, token, cfg)
 			if err != nil {
-				if httpStatusCodeError, ok := err.(model.HttpStatusCodeError); ok && httpStatusCodeError.StatusCode != http.StatusInternalServerError {
-					// Set error to cache
-					if userCacheRepo != nil {
-						errUser := newErrorUser(httpStatusCodeError)
-						if cacheErr := userCacheRepo.SetAuthTokenUser(c, authService.GetName(), token, errUser); cacheErr != nil {
-							// TODO: record error
-							log.Printf("failed to set error user to cache: %v", cacheErr)
-						}
-					}
+				getMetrics(c).IncAuthOutcome(authFailureOutcome(err))
+				// Server errors are not cached, they may be recovered on next request
+				if httpStatusCodeError, ok := err.(model.HttpStatusCodeError); ok && httpStatusCodeError.StatusCode < http.StatusInternalServerError {
+					setAuthTokenFailureToCache(c, authService, userCacheRepo, token, httpStatusCodeError, "")
 				}
 				c.Error(err)
 				c.Abort()
 				return
 			}
+			getMetrics(c).IncAuthOutcome(coreMetrics.AuthOutcomeProviderVerified)
+		}
 
-			// Set user to cache
-			if userCacheRepo != nil {
-				err = userCacheRepo.SetAuthTokenUser(c, authService.GetName(), token, user)
-				if err != nil {
-					// TODO: record error
-					log.Printf("failed to set user to cache: %v", err)
-				}
+		// Reject disabled user, cached user may be disabled before the user cache is invalidated
+		if user.Disabled {
+			err := model.NewError(model.ErrorCodeUserDisabled, "", nil)
+			setAuthTokenFailureToCache(c, authService, userCacheRepo, token, err, user.UserID)
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		// Set user to cache
+		if !cached && userCacheRepo != nil {
+			if err := userCacheRepo.SetAuthTokenUser(c, authService.GetName(), token, user); err != nil {
+				errreport.Record(c, fmt.Errorf("failed to set user to cache: %w", err))
 			}
 		}
 
-		// Set user info to context
+		// Set user info to context, and attach the user to request info of error reports
 		c.Set("user", user)
+		c.Request = c.Request.WithContext(errreport.WithUserID(c.Request.Context(), user.UserID))
 		c.Next()
 	}
 }
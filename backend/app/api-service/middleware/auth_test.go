@@ -2,15 +2,21 @@ package middleware
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/STLeee/mediation-platform/backend/app/api-service/config"
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
 	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreMetrics "github.com/STLeee/mediation-platform/backend/core/metrics"
 	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
 	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
@@ -19,6 +25,8 @@ import (
 type MockFirebaseAuthService struct {
 	AuthenticateByTokenFunc func(ctx context.Context, token string) (uid string, err error)
 	GetUserInfoFunc         func(ctx context.Context, uid string) (user *coreModel.User, err error)
+	SetUserDisabledFunc     func(ctx context.Context, uid string, disabled bool) error
+	CheckHealthFunc         func(ctx context.Context) error
 }
 
 func (auth *MockFirebaseAuthService) GetName() coreAuth.AuthServiceName {
@@ -33,10 +41,22 @@ func (auth *MockFirebaseAuthService) GetUserInfo(ctx context.Context, uid string
 	return auth.GetUserInfoFunc(ctx, uid)
 }
 
+func (auth *MockFirebaseAuthService) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	return auth.SetUserDisabledFunc(ctx, uid, disabled)
+}
+
+func (auth *MockFirebaseAuthService) CheckHealth(ctx context.Context) error {
+	return auth.CheckHealthFunc(ctx)
+}
+
 type MockUserDBRepository struct {
 	CreateUserFunc       func(ctx context.Context, user *coreModel.User) (string, error)
 	GetUserByAuthUIDFunc func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string) (*coreModel.User, error)
+	GetOrCreateUserFunc  func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string, user *coreModel.User) (*coreModel.User, error)
 	GetUserByIDFunc      func(ctx context.Context, userID string) (*coreModel.User, error)
+	UpdateUserByIDFunc   func(ctx context.Context, userID string, updateData map[string]any) error
+	DeleteUserByIDFunc   func(ctx context.Context, userID string) error
+	ListUsersFunc        func(ctx context.Context, filter *coreRepository.UserFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.User], error)
 }
 
 func (repo *MockUserDBRepository) CreateUser(ctx context.Context, user *coreModel.User) (string, error) {
@@ -47,21 +67,52 @@ func (repo *MockUserDBRepository) GetUserByAuthUID(ctx context.Context, authName
 	return repo.GetUserByAuthUIDFunc(ctx, authName, authUID)
 }
 
+func (repo *MockUserDBRepository) GetOrCreateUserByAuthUID(ctx context.Context, authName coreAuth.AuthServiceName, authUID string, user *coreModel.User) (*coreModel.User, error) {
+	return repo.GetOrCreateUserFunc(ctx, authName, authUID, user)
+}
+
 func (repo *MockUserDBRepository) GetUserByID(ctx context.Context, userID string) (*coreModel.User, error) {
 	return repo.GetUserByIDFunc(ctx, userID)
 }
 
+func (repo *MockUserDBRepository) UpdateUserByID(ctx context.Context, userID string, updateData map[string]any) error {
+	return repo.UpdateUserByIDFunc(ctx, userID, updateData)
+}
+
+func (repo *MockUserDBRepository) DeleteUserByID(ctx context.Context, userID string) error {
+	return repo.DeleteUserByIDFunc(ctx, userID)
+}
+
+func (repo *MockUserDBRepository) ListUsers(ctx context.Context, filter *coreRepository.UserFilter, pageOptions *coreRepository.PageOptions) (*coreRepository.Page[*coreModel.User], error) {
+	return repo.ListUsersFunc(ctx, filter, pageOptions)
+}
+
 type MockUserCacheRepository struct {
-	SetAuthTokenUserFunc func(ctx context.Context, authName coreAuth.AuthServiceName, token string, user *coreModel.User) error
-	GetAuthTokenUserFunc func(ctx context.Context, authName coreAuth.AuthServiceName, token string) (*coreModel.User, error)
+	SetAuthTokenUserFunc    func(ctx context.Context, authName coreAuth.AuthServiceName, token string, user *coreModel.User) error
+	SetAuthTokenFailureFunc func(ctx context.Context, authName coreAuth.AuthServiceName, token string, failure *coreRepository.AuthTokenFailure) error
+	GetAuthTokenEntryFunc   func(ctx context.Context, authName coreAuth.AuthServiceName, token string) (*coreRepository.AuthTokenCacheEntry, error)
+	DeleteAuthTokenUserFunc func(ctx context.Context, authName coreAuth.AuthServiceName, token string) error
+	InvalidateUserFunc      func(ctx context.Context, userID string) error
 }
 
 func (repo *MockUserCacheRepository) SetAuthTokenUser(ctx context.Context, authName coreAuth.AuthServiceName, token string, user *coreModel.User) error {
 	return repo.SetAuthTokenUserFunc(ctx, authName, token, user)
 }
 
-func (repo *MockUserCacheRepository) GetAuthTokenUser(ctx context.Context, authName coreAuth.AuthServiceName, token string) (*coreModel.User, error) {
-	return repo.GetAuthTokenUserFunc(ctx, authName, token)
+func (repo *MockUserCacheRepository) SetAuthTokenFailure(ctx context.Context, authName coreAuth.AuthServiceName, token string, failure *coreRepository.AuthTokenFailure) error {
+	return repo.SetAuthTokenFailureFunc(ctx, authName, token, failure)
+}
+
+func (repo *MockUserCacheRepository) GetAuthTokenEntry(ctx context.Context, authName coreAuth.AuthServiceName, token string) (*coreRepository.AuthTokenCacheEntry, error) {
+	return repo.GetAuthTokenEntryFunc(ctx, authName, token)
+}
+
+func (repo *MockUserCacheRepository) DeleteAuthTokenUser(ctx context.Context, authName coreAuth.AuthServiceName, token string) error {
+	return repo.DeleteAuthTokenUserFunc(ctx, authName, token)
+}
+
+func (repo *MockUserCacheRepository) InvalidateUser(ctx context.Context, userID string) error {
+	return repo.InvalidateUserFunc(ctx, userID)
 }
 
 var mockFirebaseUser = &coreModel.User{
@@ -87,6 +138,114 @@ var mockUserInDB = &coreModel.User{
 	LastLoginAt: time.Now(),
 }
 
+var mockDisabledUserInDB = &coreModel.User{
+	UserID:      "test-disabled-user-id",
+	FirebaseUID: "test-firebase-uid",
+	DisplayName: "Test User",
+	Email:       "test-user@mediation-platform.com",
+	Disabled:    true,
+}
+
+func TestSyncUserOnLogin(t *testing.T) {
+	testCases := []struct {
+		name               string
+		cfg                config.AuthenticationConfig
+		disabledSyncedAt   time.Time
+		authUser           *coreModel.User
+		getUserInfoFuncErr error
+		updateUserErr      error
+		expectedUpdateData map[string]any
+		expectedUser       *coreModel.User
+	}{
+		{
+			name:               "last-login-only",
+			cfg:                config.AuthenticationConfig{},
+			expectedUpdateData: map[string]any{},
+			expectedUser:       &coreModel.User{UserID: "test-user-id", DisplayName: "old-name", Email: "old@mediation-platform.com"},
+		},
+		{
+			name:               "last-login-only/disabled-synced-recently",
+			cfg:                config.AuthenticationConfig{DisabledSyncInterval: 10 * time.Minute},
+			disabledSyncedAt:   time.Now(),
+			expectedUpdateData: map[string]any{},
+			expectedUser:       &coreModel.User{UserID: "test-user-id", DisplayName: "old-name", Email: "old@mediation-platform.com"},
+		},
+		{
+			name:               "provider-fields",
+			cfg:                config.AuthenticationConfig{ProviderFields: []string{"email", "photo_url", "unknown"}},
+			authUser:           &coreModel.User{DisplayName: "new-name", Email: "new@mediation-platform.com", PhotoURL: ""},
+			expectedUpdateData: map[string]any{"email": "new@mediation-platform.com", "disabled": false},
+			expectedUser:       &coreModel.User{UserID: "test-user-id", DisplayName: "old-name", Email: "new@mediation-platform.com"},
+		},
+		{
+			name:               "disabled-sync-expired",
+			cfg:                config.AuthenticationConfig{DisabledSyncInterval: 10 * time.Minute},
+			disabledSyncedAt:   time.Now().Add(-time.Hour),
+			authUser:           &coreModel.User{DisplayName: "new-name", Disabled: true},
+			expectedUpdateData: map[string]any{"disabled": true},
+			expectedUser:       &coreModel.User{UserID: "test-user-id", DisplayName: "old-name", Email: "old@mediation-platform.com", Disabled: true},
+		},
+		{
+			name:               "auth-error",
+			cfg:                config.AuthenticationConfig{ProviderFields: []string{"email"}},
+			getUserInfoFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
+			expectedUpdateData: map[string]any{},
+			expectedUser:       &coreModel.User{UserID: "test-user-id", DisplayName: "old-name", Email: "old@mediation-platform.com"},
+		},
+		{
+			name:               "db-error",
+			cfg:                config.AuthenticationConfig{ProviderFields: []string{"display_name"}},
+			authUser:           &coreModel.User{DisplayName: "new-name"},
+			updateUserErr:      coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			expectedUpdateData: map[string]any{"display_name": "new-name", "disabled": false},
+			expectedUser:       &coreModel.User{UserID: "test-user-id", DisplayName: "new-name", Email: "old@mediation-platform.com"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			user := &coreModel.User{
+				UserID:           "test-user-id",
+				FirebaseUID:      "test-firebase-uid",
+				DisplayName:      "old-name",
+				Email:            "old@mediation-platform.com",
+				DisabledSyncedAt: testCase.disabledSyncedAt,
+			}
+			mockFirebaseAuthService := &MockFirebaseAuthService{
+				GetUserInfoFunc: func(ctx context.Context, uid string) (*coreModel.User, error) {
+					assert.Equal(t, user.FirebaseUID, uid)
+					return testCase.authUser, testCase.getUserInfoFuncErr
+				},
+			}
+			var updateData map[string]any
+			mockUserDBRepo := &MockUserDBRepository{
+				UpdateUserByIDFunc: func(ctx context.Context, userID string, data map[string]any) error {
+					assert.Equal(t, user.UserID, userID)
+					updateData = data
+					return testCase.updateUserErr
+				},
+			}
+
+			syncUserOnLogin(context.Background(), mockFirebaseAuthService, mockUserDBRepo, user.FirebaseUID, user, testCase.cfg)
+
+			// Check timestamps and drop them for comparing
+			assert.True(t, utils.SimplyValidTimestamp(updateData["last_login_at"].(time.Time)))
+			assert.True(t, utils.SimplyValidTimestamp(user.LastLoginAt))
+			delete(updateData, "last_login_at")
+			if syncedAt, ok := updateData["disabled_synced_at"]; ok {
+				assert.True(t, utils.SimplyValidTimestamp(syncedAt.(time.Time)))
+				assert.Equal(t, syncedAt, user.DisabledSyncedAt)
+				delete(updateData, "disabled_synced_at")
+			} else {
+				assert.Equal(t, testCase.disabledSyncedAt, user.DisabledSyncedAt)
+			}
+			assert.Equal(t, testCase.expectedUpdateData, updateData)
+			assert.Equal(t, testCase.expectedUser.DisplayName, user.DisplayName)
+			assert.Equal(t, testCase.expectedUser.Email, user.Email)
+			assert.Equal(t, testCase.expectedUser.Disabled, user.Disabled)
+		})
+	}
+}
+
 func TestAuthenticateUserByToken(t *testing.T) {
 	testCases := []struct {
 		name                       string
@@ -97,40 +256,76 @@ func TestAuthenticateUserByToken(t *testing.T) {
 		authenticateByTokenFuncErr error
 		getUserByAuthUIDFuncErr    error
 		getUserInfoFuncErr         error
-		createUserFuncErr          error
-		getUserByIDErr             error
+		getOrCreateUserFuncErr     error
+		cfg                        config.AuthenticationConfig
+		expectedLoginSynced        bool
 		expectedStatusCode         int
+		expectedCode               string
 	}{
 		{
-			name:               "success",
-			token:              "test-token",
-			authUID:            mockFirebaseUser.FirebaseUID,
-			authUser:           mockFirebaseUser,
-			dbUser:             mockUserInDB,
-			expectedStatusCode: http.StatusOK,
+			name:                "success",
+			token:               "test-token",
+			authUID:             mockFirebaseUser.FirebaseUID,
+			authUser:            mockFirebaseUser,
+			dbUser:              mockUserInDB,
+			expectedLoginSynced: true,
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "success/disabled-synced-recently",
+			token:               "test-token",
+			authUID:             mockFirebaseUser.FirebaseUID,
+			authUser:            mockFirebaseUser,
+			dbUser:              &coreModel.User{UserID: "test-user-id", FirebaseUID: "test-firebase-uid", DisabledSyncedAt: time.Now()},
+			cfg:                 config.AuthenticationConfig{DisabledSyncInterval: 10 * time.Minute},
+			expectedLoginSynced: true,
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "success/disabled-sync-expired",
+			token:               "test-token",
+			authUID:             mockFirebaseUser.FirebaseUID,
+			authUser:            &coreModel.User{FirebaseUID: "test-firebase-uid", Disabled: true},
+			dbUser:              &coreModel.User{UserID: "test-user-id", FirebaseUID: "test-firebase-uid", DisabledSyncedAt: time.Now().Add(-time.Hour)},
+			cfg:                 config.AuthenticationConfig{DisabledSyncInterval: 10 * time.Minute},
+			expectedLoginSynced: true,
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                    "success/first-login",
+			token:                   "test-token",
+			authUID:                 mockFirebaseUser.FirebaseUID,
+			authUser:                &coreModel.User{FirebaseUID: "test-firebase-uid", Email: "test-user@mediation-platform.com"},
+			dbUser:                  mockUserInDB,
+			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:      http.StatusOK,
 		},
 		{
 			name:               "empty-token",
 			token:              "",
 			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       model.ErrorCodeTokenEmpty,
 		},
 		{
 			name:                       "auth/invalid-token",
 			token:                      "invalid-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeTokenInvalid},
 			expectedStatusCode:         http.StatusUnauthorized,
+			expectedCode:               model.ErrorCodeTokenInvalid,
 		},
 		{
 			name:                       "auth/user-not-found",
 			token:                      "test-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeUserNotFound},
 			expectedStatusCode:         http.StatusUnauthorized,
+			expectedCode:               model.ErrorCodeUserNotFound,
 		},
 		{
 			name:                       "auth/unknown-error",
 			token:                      "test-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
 			expectedStatusCode:         http.StatusInternalServerError,
+			expectedCode:               model.ErrorCodeAuthServiceError,
 		},
 		{
 			name:                    "db/get-user-by-auth-uid-error",
@@ -139,6 +334,7 @@ func TestAuthenticateUserByToken(t *testing.T) {
 			authUser:                mockFirebaseUser,
 			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
 			expectedStatusCode:      http.StatusInternalServerError,
+			expectedCode:            model.ErrorCodeInternalError,
 		},
 		{
 			name:                    "auth/get-user-info-error",
@@ -147,25 +343,17 @@ func TestAuthenticateUserByToken(t *testing.T) {
 			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
 			getUserInfoFuncErr:      coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
 			expectedStatusCode:      http.StatusInternalServerError,
+			expectedCode:            model.ErrorCodeAuthServiceError,
 		},
 		{
-			name:                    "db/create-user-error",
+			name:                    "db/get-or-create-user-error",
 			token:                   "test-token",
 			authUID:                 mockFirebaseUser.FirebaseUID,
 			authUser:                mockFirebaseUser,
 			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			createUserFuncErr:       coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
-			expectedStatusCode:      http.StatusInternalServerError,
-		},
-		{
-			name:                    "db/get-user-by-id-error",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			dbUser:                  mockUserInDB,
-			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			getUserByIDErr:          coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			getOrCreateUserFuncErr:  coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
 			expectedStatusCode:      http.StatusInternalServerError,
+			expectedCode:            model.ErrorCodeAuthServiceError,
 		},
 	}
 
@@ -181,215 +369,266 @@ func TestAuthenticateUserByToken(t *testing.T) {
 					return testCase.authUser, testCase.getUserInfoFuncErr
 				},
 			}
+			loginSynced := false
 			mockUserDBRepo := &MockUserDBRepository{
-				CreateUserFunc: func(ctx context.Context, user *coreModel.User) (string, error) {
-					assert.Equal(t, testCase.authUser, user)
-					userID := ""
-					if testCase.dbUser != nil {
-						userID = testCase.dbUser.UserID
+				GetUserByAuthUIDFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string) (*coreModel.User, error) {
+					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
+					assert.Equal(t, testCase.authUID, authUID)
+					if testCase.getUserByAuthUIDFuncErr != nil {
+						return nil, testCase.getUserByAuthUIDFuncErr
 					}
-					return userID, testCase.createUserFuncErr
+					return testCase.dbUser, nil
 				},
-				GetUserByAuthUIDFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string) (*coreModel.User, error) {
+				GetOrCreateUserFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string, user *coreModel.User) (*coreModel.User, error) {
 					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
 					assert.Equal(t, testCase.authUID, authUID)
-					return testCase.dbUser, testCase.getUserByAuthUIDFuncErr
+					assert.Equal(t, testCase.authUser, user)
+					assert.False(t, user.DisabledSyncedAt.IsZero())
+					return testCase.dbUser, testCase.getOrCreateUserFuncErr
 				},
-				GetUserByIDFunc: func(ctx context.Context, userID string) (*coreModel.User, error) {
-					assert.Equal(t, testCase.dbUser.UserID, userID)
-					return testCase.dbUser, testCase.getUserByIDErr
+				UpdateUserByIDFunc: func(ctx context.Context, userID string, updateData map[string]any) error {
+					loginSynced = true
+					return nil
 				},
 			}
 
-			user, err := authenticateUserByToken(context.Background(), mockFirebaseAuthService, mockUserDBRepo, testCase.token)
+			user, err := authenticateUserByToken(context.Background(), mockFirebaseAuthService, mockUserDBRepo, testCase.token, testCase.cfg)
 			if testCase.expectedStatusCode == http.StatusOK {
 				assert.Nil(t, err)
 				assert.Equal(t, utils.ConvertToJSONString(testCase.dbUser), utils.ConvertToJSONString(user))
+				assert.Equal(t, testCase.expectedLoginSynced, loginSynced)
 			} else {
 				assert.NotNil(t, err)
 				assert.Equal(t, testCase.expectedStatusCode, err.(model.HttpStatusCodeError).StatusCode)
-			}
-		})
-	}
-}
-
-func TestParseErrorUser(t *testing.T) {
-	testCases := []struct {
-		name string
-		user *coreModel.User
-		err  error
-	}{
-		{
-			name: "nil-user",
-			user: nil,
-			err:  nil,
-		},
-		{
-			name: "error-user",
-			user: newErrorUser(model.HttpStatusCodeError{
-				StatusCode: http.StatusUnauthorized,
-				Message:    "test-error",
-			}),
-			err: model.HttpStatusCodeError{
-				StatusCode: http.StatusUnauthorized,
-				Message:    "test-error",
-			},
-		},
-		{
-			name: "normal-user",
-			user: mockUserInDB,
-			err:  nil,
-		},
-	}
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			user, err := parseErrorUser(testCase.user)
-			if testCase.err != nil {
-				assert.Nil(t, user)
-				assert.Equal(t, testCase.err, err)
-			} else {
-				assert.Equal(t, testCase.user, user)
-				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedCode, err.(model.HttpStatusCodeError).Code)
 			}
 		})
 	}
 }
 
 func TestTokenAuthenticationHandler(t *testing.T) {
+	mockSyncedUser := &coreModel.User{UserID: "test-user-id", DisabledSyncedAt: time.Now()}
 	testCases := []struct {
 		name                       string
 		token                      string
 		authUID                    string
 		authUser                   *coreModel.User
 		dbUser                     *coreModel.User
-		cacheUser                  *coreModel.User
+		cacheEntry                 *coreRepository.AuthTokenCacheEntry
 		authenticateByTokenFuncErr error
 		getUserByAuthUIDFuncErr    error
 		getUserInfoFuncErr         error
-		createUserFuncErr          error
-		getUserByIDErr             error
-		setAuthTokenUserFuncErr    error
-		getAuthTokenUserFuncErr    error
+		getOrCreateUserFuncErr     error
+		setAuthTokenFuncErr        error
+		getAuthTokenEntryFuncErr   error
+		cfg                        config.AuthenticationConfig
 		expectedStatusCode         int
+		expectedCode               string
+		expectedAuthOutcome        coreMetrics.AuthOutcome
 	}{
 		{
-			name:                    "success/auth-and-db",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			dbUser:                  mockUserInDB,
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			expectedStatusCode:      http.StatusOK,
+			name:                     "success/auth-and-db",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			dbUser:                   mockUserInDB,
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusOK,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeProviderVerified,
 		},
 		{
-			name:               "success/cache",
-			token:              "test-token",
-			authUID:            mockFirebaseUser.FirebaseUID,
-			authUser:           mockFirebaseUser,
-			dbUser:             mockUserInDB,
-			cacheUser:          mockUserInDB,
-			expectedStatusCode: http.StatusOK,
+			name:                "success/cache",
+			token:               "test-token",
+			authUID:             mockFirebaseUser.FirebaseUID,
+			authUser:            mockFirebaseUser,
+			dbUser:              mockUserInDB,
+			cacheEntry:          &coreRepository.AuthTokenCacheEntry{User: mockUserInDB},
+			expectedStatusCode:  http.StatusOK,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeCacheHit,
 		},
 		{
-			name:               "empty-token",
-			token:              "",
-			expectedStatusCode: http.StatusUnauthorized,
+			name:                "success/cache-disabled-synced",
+			token:               "test-token",
+			authUID:             mockFirebaseUser.FirebaseUID,
+			dbUser:              mockSyncedUser,
+			cacheEntry:          &coreRepository.AuthTokenCacheEntry{User: mockSyncedUser},
+			cfg:                 config.AuthenticationConfig{DisabledSyncInterval: 10 * time.Minute},
+			expectedStatusCode:  http.StatusOK,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeCacheHit,
+		},
+		{
+			name:                "disabled/cache-disabled-stale",
+			token:               "test-token",
+			authUID:             mockFirebaseUser.FirebaseUID,
+			authUser:            &coreModel.User{FirebaseUID: "test-firebase-uid", Disabled: true},
+			dbUser:              &coreModel.User{UserID: "test-user-id", DisabledSyncedAt: time.Now().Add(-time.Hour)},
+			cacheEntry:          &coreRepository.AuthTokenCacheEntry{User: &coreModel.User{UserID: "test-user-id", DisabledSyncedAt: time.Now().Add(-time.Hour)}},
+			cfg:                 config.AuthenticationConfig{DisabledSyncInterval: 10 * time.Minute},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedCode:        model.ErrorCodeUserDisabled,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeProviderVerified,
+		},
+		{
+			name:                     "success/first-login",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 &coreModel.User{FirebaseUID: "test-firebase-uid", Email: "test-user@mediation-platform.com"},
+			dbUser:                   mockUserInDB,
+			getUserByAuthUIDFuncErr:  coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusOK,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeProviderVerified,
+		},
+		{
+			name:                "empty-token",
+			token:               "",
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeMissingToken,
 		},
 		{
 			name:                       "auth/invalid-token",
 			token:                      "invalid-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeTokenInvalid},
-			getAuthTokenUserFuncErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getAuthTokenEntryFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
 			expectedStatusCode:         http.StatusUnauthorized,
+			expectedCode:               model.ErrorCodeTokenInvalid,
+			expectedAuthOutcome:        coreMetrics.AuthOutcomeInvalidToken,
 		},
 		{
 			name:                       "auth/user-not-found",
 			token:                      "test-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeUserNotFound},
-			getAuthTokenUserFuncErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getAuthTokenEntryFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
 			expectedStatusCode:         http.StatusUnauthorized,
+			expectedCode:               model.ErrorCodeUserNotFound,
+			expectedAuthOutcome:        coreMetrics.AuthOutcomeInvalidToken,
 		},
 		{
 			name:                       "auth/unknown-error",
 			token:                      "test-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
-			getAuthTokenUserFuncErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getAuthTokenEntryFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
 			expectedStatusCode:         http.StatusInternalServerError,
+			expectedAuthOutcome:        coreMetrics.AuthOutcomeProviderFailure,
 		},
 		{
-			name:                    "db/get-user-by-auth-uid-error",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			expectedStatusCode:      http.StatusInternalServerError,
+			name:                     "db/get-user-by-auth-uid-error",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			getUserByAuthUIDFuncErr:  coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusInternalServerError,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeInternalError,
 		},
 		{
-			name:                    "auth/get-user-info-error",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			getUserInfoFuncErr:      coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			expectedStatusCode:      http.StatusInternalServerError,
+			name:                     "auth/get-user-info-error",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			getUserByAuthUIDFuncErr:  coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getUserInfoFuncErr:       coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusInternalServerError,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeProviderFailure,
 		},
 		{
-			name:                    "db/create-user-error",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			createUserFuncErr:       coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			expectedStatusCode:      http.StatusInternalServerError,
+			name:                     "db/get-or-create-user-error",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			getUserByAuthUIDFuncErr:  coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getOrCreateUserFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusInternalServerError,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeInternalError,
 		},
 		{
-			name:                    "db/get-user-by-id-error",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			dbUser:                  mockUserInDB,
-			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			getUserByIDErr:          coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			expectedStatusCode:      http.StatusInternalServerError,
+			name:                     "db/get-or-create-user-conflict",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			getUserByAuthUIDFuncErr:  coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getOrCreateUserFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError, Message: "document conflicts with another document by email"},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusInternalServerError,
+			expectedCode:             model.ErrorCodeInternalError,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeInternalError,
 		},
 		{
-			name:                    "cache/server-error/success",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			dbUser:                  mockUserInDB,
-			setAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
-			expectedStatusCode:      http.StatusOK,
+			name:                     "cache/server-error/success",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			dbUser:                   mockUserInDB,
+			setAuthTokenFuncErr:      coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			expectedStatusCode:       http.StatusOK,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeProviderVerified,
 		},
 		{
 			name:                       "cache/server-error/invalid-token",
 			token:                      "invalid-token",
 			authenticateByTokenFuncErr: coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeTokenInvalid},
-			setAuthTokenUserFuncErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
-			getAuthTokenUserFuncErr:    coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			setAuthTokenFuncErr:        coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			getAuthTokenEntryFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
 			expectedStatusCode:         http.StatusUnauthorized,
+			expectedCode:               model.ErrorCodeTokenInvalid,
+			expectedAuthOutcome:        coreMetrics.AuthOutcomeInvalidToken,
 		},
 		{
-			name:                    "cache/server-error/create-user-error",
-			token:                   "test-token",
-			authUID:                 mockFirebaseUser.FirebaseUID,
-			authUser:                mockFirebaseUser,
-			getUserByAuthUIDFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			createUserFuncErr:       coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
-			getAuthTokenUserFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
-			expectedStatusCode:      http.StatusInternalServerError,
+			name:                     "cache/server-error/get-or-create-user-error",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			getUserByAuthUIDFuncErr:  coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			getOrCreateUserFuncErr:   coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusInternalServerError,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeInternalError,
 		},
 		{
 			name:  "cache/error",
 			token: "test-token",
-			cacheUser: newErrorUser(model.HttpStatusCodeError{
-				StatusCode: http.StatusUnauthorized,
-			}),
-			expectedStatusCode: http.StatusUnauthorized,
+			cacheEntry: &coreRepository.AuthTokenCacheEntry{
+				Failure: &coreRepository.AuthTokenFailure{StatusCode: http.StatusUnauthorized},
+			},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeCacheNegativeHit,
+		},
+		{
+			name:                     "disabled/auth-and-db",
+			token:                    "test-token",
+			authUID:                  mockFirebaseUser.FirebaseUID,
+			authUser:                 mockFirebaseUser,
+			dbUser:                   mockDisabledUserInDB,
+			getAuthTokenEntryFuncErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode:       http.StatusForbidden,
+			expectedCode:             model.ErrorCodeUserDisabled,
+			expectedAuthOutcome:      coreMetrics.AuthOutcomeProviderVerified,
+		},
+		{
+			name:                "disabled/cache-user",
+			token:               "test-token",
+			dbUser:              mockDisabledUserInDB,
+			cacheEntry:          &coreRepository.AuthTokenCacheEntry{User: mockDisabledUserInDB},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedCode:        model.ErrorCodeUserDisabled,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeCacheHit,
+		},
+		{
+			name:  "disabled/cache-failure",
+			token: "test-token",
+			cacheEntry: &coreRepository.AuthTokenCacheEntry{
+				Failure: &coreRepository.AuthTokenFailure{
+					StatusCode: http.StatusForbidden,
+					Code:       model.ErrorCodeUserDisabled,
+					Reason:     "user is disabled",
+					UserID:     mockDisabledUserInDB.UserID,
+				},
+			},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedCode:        model.ErrorCodeUserDisabled,
+			expectedAuthOutcome: coreMetrics.AuthOutcomeCacheNegativeHit,
 		},
 	}
 
@@ -406,51 +645,65 @@ func TestTokenAuthenticationHandler(t *testing.T) {
 				},
 			}
 			mockUserDBRepo := &MockUserDBRepository{
-				CreateUserFunc: func(ctx context.Context, user *coreModel.User) (string, error) {
-					assert.Equal(t, testCase.authUser, user)
-					userID := ""
-					if testCase.dbUser != nil {
-						userID = testCase.dbUser.UserID
+				GetUserByAuthUIDFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string) (*coreModel.User, error) {
+					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
+					assert.Equal(t, testCase.authUID, authUID)
+					if testCase.getUserByAuthUIDFuncErr != nil {
+						return nil, testCase.getUserByAuthUIDFuncErr
 					}
-					return userID, testCase.createUserFuncErr
+					return testCase.dbUser, nil
 				},
-				GetUserByAuthUIDFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string) (*coreModel.User, error) {
+				GetOrCreateUserFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, authUID string, user *coreModel.User) (*coreModel.User, error) {
 					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
 					assert.Equal(t, testCase.authUID, authUID)
-					return testCase.dbUser, testCase.getUserByAuthUIDFuncErr
+					assert.Equal(t, testCase.authUser, user)
+					assert.False(t, user.DisabledSyncedAt.IsZero())
+					return testCase.dbUser, testCase.getOrCreateUserFuncErr
 				},
-				GetUserByIDFunc: func(ctx context.Context, userID string) (*coreModel.User, error) {
+				UpdateUserByIDFunc: func(ctx context.Context, userID string, updateData map[string]any) error {
 					assert.Equal(t, testCase.dbUser.UserID, userID)
-					return testCase.dbUser, testCase.getUserByIDErr
+					assert.Contains(t, updateData, "last_login_at")
+					return nil
 				},
 			}
 			mockUserCacheRepository := &MockUserCacheRepository{
 				SetAuthTokenUserFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, token string, user *coreModel.User) error {
 					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
 					assert.Equal(t, testCase.token, token)
-					user, err := parseErrorUser(user)
-					if testCase.expectedStatusCode == http.StatusOK {
-						assert.Nil(t, err)
-						assert.Equal(t, testCase.dbUser, user)
-					} else {
-						assert.Nil(t, user)
-						assert.Equal(t, testCase.expectedStatusCode, err.(model.HttpStatusCodeError).StatusCode)
+					assert.Equal(t, http.StatusOK, testCase.expectedStatusCode)
+					assert.Equal(t, testCase.dbUser, user)
+					return testCase.setAuthTokenFuncErr
+				},
+				SetAuthTokenFailureFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, token string, failure *coreRepository.AuthTokenFailure) error {
+					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
+					assert.Equal(t, testCase.token, token)
+					assert.Equal(t, testCase.expectedStatusCode, failure.StatusCode)
+					assert.Less(t, failure.StatusCode, http.StatusInternalServerError, "server errors are not cached")
+					assert.Equal(t, testCase.expectedCode, failure.Code)
+					if testCase.expectedCode == model.ErrorCodeUserDisabled {
+						assert.Equal(t, testCase.dbUser.UserID, failure.UserID)
 					}
-					return testCase.setAuthTokenUserFuncErr
+					return testCase.setAuthTokenFuncErr
 				},
-				GetAuthTokenUserFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, token string) (*coreModel.User, error) {
+				GetAuthTokenEntryFunc: func(ctx context.Context, authName coreAuth.AuthServiceName, token string) (*coreRepository.AuthTokenCacheEntry, error) {
 					assert.Equal(t, coreAuth.AuthServiceNameFirebase, authName)
 					assert.Equal(t, testCase.token, token)
-					return testCase.cacheUser, testCase.getAuthTokenUserFuncErr
+					return testCase.cacheEntry, testCase.getAuthTokenEntryFuncErr
 				},
 			}
 
+			registry := prometheus.NewRegistry()
+			metrics, err := coreMetrics.NewMetrics(registry)
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			httpRecorder := utils.RegisterAndRecordHttpRequest(func(routeGroup *gin.RouterGroup) {
 				routeGroup.Use(func(ctx *gin.Context) {
 					ctx.Request.Header.Set("Authorization", "Bearer "+testCase.token)
 					ctx.Next()
 				})
-				routeGroup.Use(ErrorHandler(), TokenAuthenticationHandler(mockFirebaseAuthService, mockUserDBRepo, mockUserCacheRepository))
+				routeGroup.Use(MetricsHandler(metrics), ErrorHandler(nil), TokenAuthenticationHandler(mockFirebaseAuthService, mockUserDBRepo, mockUserCacheRepository, testCase.cfg))
 				routeGroup.Handle("GET", "/test", func(c *gin.Context) {
 					if testCase.token == "" {
 						c.JSON(http.StatusUnauthorized, nil)
@@ -464,6 +717,20 @@ func TestTokenAuthenticationHandler(t *testing.T) {
 			if httpRecorder.Code == http.StatusOK {
 				assert.Equal(t, utils.ConvertToJSONString(testCase.dbUser), httpRecorder.Body.String())
 			}
+			if testCase.expectedCode != "" {
+				assert.Contains(t, httpRecorder.Body.String(), `"code":"`+testCase.expectedCode+`"`)
+			}
+
+			// Only the outcome is counted
+			expectedSeries := 0
+			if testCase.expectedAuthOutcome != "" {
+				expectedSeries = 1
+				expected := fmt.Sprintf("# HELP mediation_platform_auth_outcomes_total Number of token authentications by outcome.\n# TYPE mediation_platform_auth_outcomes_total counter\nmediation_platform_auth_outcomes_total{outcome=%q} 1\n", testCase.expectedAuthOutcome)
+				assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "mediation_platform_auth_outcomes_total"))
+			}
+			count, err := testutil.GatherAndCount(registry, "mediation_platform_auth_outcomes_total")
+			assert.NoError(t, err)
+			assert.Equal(t, expectedSeries, count)
 		})
 	}
 }
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// RequirePermission is a middleware for checking if the token user is allowed to do the action on the resource
+func RequirePermission(policy *coreAuthz.Policy, resource coreAuthz.Resource, action coreAuthz.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Get user from context
+		var user *coreModel.User
+		if userInterface, ok := c.Get("user"); ok {
+			user, _ = userInterface.(*coreModel.User)
+		}
+		if user == nil {
+			c.Error(model.NewError(model.ErrorCodeUnauthorized, "", nil))
+			c.Abort()
+			return
+		}
+
+		// Check permission by role of user
+		if !policy.IsUserAllowed(user, resource, action) {
+			c.Error(model.NewError(model.ErrorCodeForbidden, "permission denied", nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
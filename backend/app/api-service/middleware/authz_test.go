@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func TestRequirePermission(t *testing.T) {
+	policy, err := coreAuthz.NewPolicy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name       string
+		tokenUser  *coreModel.User
+		resource   coreAuthz.Resource
+		action     coreAuthz.Action
+		statusCode int
+	}{
+		{
+			name:       "allowed",
+			tokenUser:  &coreModel.User{UserID: "test-user-id"},
+			resource:   coreAuthz.ResourceIssue,
+			action:     coreAuthz.ActionCreate,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "denied",
+			tokenUser:  &coreModel.User{UserID: "test-user-id"},
+			resource:   coreAuthz.ResourceAdmin,
+			action:     coreAuthz.ActionRead,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "admin",
+			tokenUser:  &coreModel.User{UserID: "test-user-id", Role: coreModel.UserRoleAdmin},
+			resource:   coreAuthz.ResourceAdmin,
+			action:     coreAuthz.ActionRead,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "no-user",
+			tokenUser:  nil,
+			resource:   coreAuthz.ResourceIssue,
+			action:     coreAuthz.ActionRead,
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var statusCode int
+			httpRecorder := utils.RegisterAndRecordHttpRequest(func(router *gin.RouterGroup) {
+				router.Use(func(ctx *gin.Context) {
+					// Set user to context
+					if testCase.tokenUser != nil {
+						ctx.Set("user", testCase.tokenUser)
+					}
+					ctx.Next()
+
+					// Get status code from error
+					if err := ctx.Errors.Last(); err != nil {
+						statusCode = err.Err.(model.HttpStatusCodeError).StatusCode
+					}
+				})
+				router.GET("/test", RequirePermission(policy, testCase.resource, testCase.action), func(c *gin.Context) {
+					c.JSON(http.StatusOK, nil)
+				})
+			}, "GET", "/test", nil)
+			if statusCode == 0 {
+				statusCode = httpRecorder.Code
+			}
+			assert.Equal(t, testCase.statusCode, statusCode)
+		})
+	}
+}
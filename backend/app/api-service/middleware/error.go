@@ -6,28 +6,58 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	"github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
 )
 
-// ErrorHandler is a middleware for handling errors
-func ErrorHandler() gin.HandlerFunc {
+// getRequestID gets the request ID of response, or the one carried by request context
+func getRequestID(c *gin.Context) string {
+	if requestID := c.Writer.Header().Get(RequestIDHeader); requestID != "" {
+		return requestID
+	}
+	return coreLogging.GetRequestID(c.Request.Context())
+}
+
+// newRequestInfo creates request info of the error report from gin context, user is attached by authentication
+func newRequestInfo(c *gin.Context) *errreport.RequestInfo {
+	request := &errreport.RequestInfo{
+		Method:    c.Request.Method,
+		Path:      c.FullPath(),
+		RequestID: getRequestID(c),
+	}
+	if request.Path == "" {
+		request.Path = c.Request.URL.Path
+	}
+	return request
+}
+
+// ErrorHandler is a middleware for handling errors, errors are responded in structured envelope with stable code,
+// and server errors are reported with the error reporter. The reporter and request info are carried by request
+// context, so errors recorded by errreport.Record in following handlers and their background work are reported with them
+func ErrorHandler(reporter errreport.ErrorReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if reporter != nil {
+			ctx = errreport.WithReporter(ctx, reporter)
+		}
+		c.Request = c.Request.WithContext(errreport.WithRequest(ctx, newRequestInfo(c)))
 		c.Next()
 		err := c.Errors.Last()
 		if err != nil {
 			// Handle error
-			httpStatusCodeError, ok := err.Err.(model.HttpStatusCodeError)
-			if !ok {
-				httpStatusCodeError = model.HttpStatusCodeError{
-					StatusCode: http.StatusInternalServerError,
-					Err:        err.Err,
-				}
-			}
+			httpStatusCodeError := model.ResolveError(err.Err)
 
-			// TODO: record error
+			// Record error
+			if httpStatusCodeError.StatusCode >= http.StatusInternalServerError {
+				errreport.Record(c.Request.Context(), err.Err)
+			}
 
 			// Send response
-			response := model.MessageResponse{
-				Message: httpStatusCodeError.Error(),
+			response := model.ErrorResponse{
+				Code:      httpStatusCodeError.Code,
+				Message:   httpStatusCodeError.Error(),
+				Details:   httpStatusCodeError.Details,
+				RequestID: getRequestID(c),
 			}
 			c.JSON(httpStatusCodeError.StatusCode, response)
 			c.Abort()
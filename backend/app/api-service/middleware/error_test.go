@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -9,14 +11,38 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	"github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
 )
 
+type MockErrorReporter struct {
+	Reports []*errreport.Report
+}
+
+func (reporter *MockErrorReporter) GetName() errreport.ReporterName {
+	return "mock"
+}
+
+func (reporter *MockErrorReporter) Report(ctx context.Context, report *errreport.Report) error {
+	reporter.Reports = append(reporter.Reports, report)
+	return nil
+}
+
+func (reporter *MockErrorReporter) Close(ctx context.Context) error {
+	return nil
+}
+
 func TestErrorHandler(t *testing.T) {
 	testCases := []struct {
-		name          string
-		err           error
-		expected_code int
+		name             string
+		err              error
+		expected_code    int
+		requestID        string
+		expected_body    string
+		expectedReported bool
 	}{
 		{
 			name:          "no-error",
@@ -29,22 +55,76 @@ func TestErrorHandler(t *testing.T) {
 			expected_code: http.StatusBadRequest,
 		},
 		{
-			name:          "internal-server-error",
-			err:           model.HttpStatusCodeError{StatusCode: http.StatusInternalServerError},
-			expected_code: http.StatusInternalServerError,
+			name:             "internal-server-error",
+			err:              model.HttpStatusCodeError{StatusCode: http.StatusInternalServerError},
+			expected_code:    http.StatusInternalServerError,
+			expectedReported: true,
+		},
+		{
+			name:          "forbidden-with-code",
+			err:           model.HttpStatusCodeError{StatusCode: http.StatusForbidden, Code: model.ErrorCodeUserDisabled, Message: "user is disabled"},
+			expected_code: http.StatusForbidden,
+			expected_body: `{"code":"user_disabled","message":"user is disabled"}`,
+		},
+		{
+			name:          "bad-request/generic-code",
+			err:           model.HttpStatusCodeError{StatusCode: http.StatusBadRequest, Message: "invalid request"},
+			expected_code: http.StatusBadRequest,
+			expected_body: `{"code":"bad_request","message":"invalid request"}`,
+		},
+		{
+			name:          "auth-service-error/token-invalid",
+			err:           coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeTokenInvalid},
+			expected_code: http.StatusUnauthorized,
+			expected_body: `{"code":"token_invalid","message":"token is invalid"}`,
+		},
+		{
+			name:          "request-id",
+			err:           model.HttpStatusCodeError{StatusCode: http.StatusNotFound},
+			requestID:     "test-request-id",
+			expected_code: http.StatusNotFound,
+			expected_body: `{"code":"not_found","message":"not found","request_id":"test-request-id"}`,
+		},
+		{
+			name: "duplicate-key",
+			err: model.HttpStatusCodeError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "failed to update user",
+				Err:        coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeDuplicateKey, Field: "email"},
+			},
+			expected_code: http.StatusConflict,
+			expected_body: `{"code":"duplicate_key","message":"duplicate value of email","details":{"field":"email"}}`,
+		},
+		{
+			name:          "duplicate-key/unknown-field",
+			err:           coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeDuplicateKey},
+			expected_code: http.StatusConflict,
+			expected_body: `{"code":"duplicate_key","message":"duplicate value"}`,
+		},
+		{
+			name:             "internal-server-error/with-stack",
+			err:              model.NewError(model.ErrorCodeInternalError, "", nil),
+			expected_code:    http.StatusInternalServerError,
+			expectedReported: true,
 		},
 		{
-			name:          "unknown-error",
-			err:           fmt.Errorf("test-error"),
-			expected_code: http.StatusInternalServerError,
+			name:             "unknown-error",
+			err:              fmt.Errorf("test-error"),
+			expected_code:    http.StatusInternalServerError,
+			expected_body:    `{"code":"internal_error","message":"internal server error"}`,
+			expectedReported: true,
 		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
+			mockErrorReporter := &MockErrorReporter{}
 			httpRecorder := utils.RegisterAndRecordHttpRequest(func(routeGroup *gin.RouterGroup) {
-				routeGroup.Use(ErrorHandler())
+				routeGroup.Use(ErrorHandler(mockErrorReporter))
 				routeGroup.Handle("GET", "/test", func(c *gin.Context) {
+					if testCase.requestID != "" {
+						c.Header(RequestIDHeader, testCase.requestID)
+					}
 					if testCase.err != nil {
 						c.Error(testCase.err)
 						return
@@ -54,6 +134,71 @@ func TestErrorHandler(t *testing.T) {
 			}, "GET", "/test", nil)
 
 			assert.Equal(t, testCase.expected_code, httpRecorder.Code)
+			if testCase.expected_body != "" {
+				assert.Equal(t, testCase.expected_body, httpRecorder.Body.String())
+			}
+			if testCase.expectedReported {
+				assert.Len(t, mockErrorReporter.Reports, 1)
+				assert.Equal(t, testCase.err.Error(), mockErrorReporter.Reports[0].Message)
+				assert.Equal(t, &errreport.RequestInfo{Method: "GET", Path: "/test", RequestID: testCase.requestID}, mockErrorReporter.Reports[0].Request)
+				// Stack of where the error is created is reported rather than stack of the middleware
+				if stackTracer, ok := testCase.err.(errreport.StackTracer); ok && len(stackTracer.StackTrace()) > 0 {
+					assert.Equal(t, stackTracer.StackTrace(), mockErrorReporter.Reports[0].Stack)
+				}
+			} else {
+				assert.Empty(t, mockErrorReporter.Reports)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_Record(t *testing.T) {
+	testCases := []struct {
+		name            string
+		userID          string
+		requestID       string
+		expectedRequest *errreport.RequestInfo
+	}{
+		{
+			name:            "anonymous",
+			expectedRequest: &errreport.RequestInfo{Method: "GET", Path: "/test/:id"},
+		},
+		{
+			name:            "with-user-and-request-id",
+			userID:          "test-user-id",
+			requestID:       "test-request-id",
+			expectedRequest: &errreport.RequestInfo{Method: "GET", Path: "/test/:id", UserID: "test-user-id", RequestID: "test-request-id"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockErrorReporter := &MockErrorReporter{}
+			err := fmt.Errorf("failed to test: %w", errors.New("test-error"))
+			httpRecorder := utils.RegisterAndRecordHttpRequest(func(routeGroup *gin.RouterGroup) {
+				routeGroup.Use(func(c *gin.Context) {
+					if testCase.requestID != "" {
+						c.Request = c.Request.WithContext(coreLogging.WithRequestID(c.Request.Context(), testCase.requestID))
+					}
+					c.Next()
+				})
+				routeGroup.Use(ErrorHandler(mockErrorReporter))
+				routeGroup.Handle("GET", "/test/:id", func(c *gin.Context) {
+					if testCase.userID != "" {
+						c.Request = c.Request.WithContext(errreport.WithUserID(c.Request.Context(), testCase.userID))
+					}
+					errreport.Record(c.Request.Context(), err)
+					c.JSON(http.StatusOK, gin.H{})
+				})
+			}, "GET", "/test/1", nil)
+
+			assert.Equal(t, http.StatusOK, httpRecorder.Code)
+			assert.Len(t, mockErrorReporter.Reports, 1)
+			report := mockErrorReporter.Reports[0]
+			assert.Equal(t, "failed to test: test-error", report.Message)
+			assert.Len(t, report.Chain, 2)
+			assert.Equal(t, testCase.expectedRequest, report.Request)
+			assert.Equal(t, "github.com/STLeee/mediation-platform/backend/app/api-service/middleware.TestErrorHandler_Record.func1.1.2", report.Stack[0].Function)
 		})
 	}
 }
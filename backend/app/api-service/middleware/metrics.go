@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	coreMetrics "github.com/STLeee/mediation-platform/backend/core/metrics"
+)
+
+const (
+	// MetricsKey is the context key of metrics
+	MetricsKey = "metrics"
+	// UnmatchedRoute is the route label of requests matching no route, so unknown paths do not create new series
+	UnmatchedRoute = "unmatched"
+)
+
+// getMetrics gets metrics set by MetricsHandler, nil if there is none
+func getMetrics(c *gin.Context) *coreMetrics.Metrics {
+	metrics, _ := c.Value(MetricsKey).(*coreMetrics.Metrics)
+	return metrics
+}
+
+// MetricsHandler is a middleware for HTTP metrics, every request is counted and observed by method, route and status,
+// metrics are set to context for following middlewares
+func MetricsHandler(metrics *coreMetrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(MetricsKey, metrics)
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	coreMetrics "github.com/STLeee/mediation-platform/backend/core/metrics"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		expectedRoute  string
+		expectedStatus string
+	}{
+		{
+			name:           "matched-route",
+			path:           "/test/1",
+			expectedRoute:  "/test/:id",
+			expectedStatus: "200",
+		},
+		{
+			name:           "unmatched-route",
+			path:           "/not-found/1",
+			expectedRoute:  UnmatchedRoute,
+			expectedStatus: "404",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			metrics, err := coreMetrics.NewMetrics(registry)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Middleware of engine handles unmatched route as well
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.Use(MetricsHandler(metrics))
+			engine.GET("/test/:id", func(c *gin.Context) {
+				// Metrics are set to context
+				assert.Equal(t, metrics, getMetrics(c))
+				c.JSON(http.StatusOK, gin.H{})
+			})
+			engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", testCase.path, nil))
+
+			expected := `
+# HELP mediation_platform_http_requests_total Number of HTTP requests by method, route and status.
+# TYPE mediation_platform_http_requests_total counter
+mediation_platform_http_requests_total{method="GET",route="` + testCase.expectedRoute + `",status="` + testCase.expectedStatus + `"} 1
+`
+			assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "mediation_platform_http_requests_total"))
+		})
+	}
+}
+
+func TestGetMetrics(t *testing.T) {
+	utils.RegisterAndRecordHttpRequest(func(routeGroup *gin.RouterGroup) {
+		routeGroup.Handle("GET", "/test", func(c *gin.Context) {
+			// Nil metrics without MetricsHandler, methods of it do nothing
+			assert.Nil(t, getMetrics(c))
+			assert.NotPanics(t, func() {
+				getMetrics(c).IncAuthOutcome(coreMetrics.AuthOutcomeCacheHit)
+			})
+		})
+	}, "GET", "/test", nil)
+}
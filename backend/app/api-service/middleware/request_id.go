@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+)
+
+const (
+	// RequestIDHeader is the header of request ID
+	RequestIDHeader = "X-Request-ID"
+	// MaxRequestIDLength is the max length of request ID accepted from client
+	MaxRequestIDLength = 128
+)
+
+// isValidRequestID checks the request ID from client is not too long and only has letters, digits, '-', '_' and '.'
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > MaxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestIDHandler is a middleware for request correlation, it accepts valid X-Request-ID from client or generates one,
+// responds it in header, and carries it in request context so logs of following calls are correlated
+func RequestIDHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = coreLogging.NewRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(coreLogging.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
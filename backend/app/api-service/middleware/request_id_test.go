@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+)
+
+func TestIsValidRequestID(t *testing.T) {
+	testCases := []struct {
+		name      string
+		requestID string
+		expected  bool
+	}{
+		{
+			name:      "uuid",
+			requestID: "123e4567-e89b-12d3-a456-426614174000",
+			expected:  true,
+		},
+		{
+			name:      "with-dot-and-underscore",
+			requestID: "trace.span_1",
+			expected:  true,
+		},
+		{
+			name:      "empty",
+			requestID: "",
+			expected:  false,
+		},
+		{
+			name:      "too-long",
+			requestID: strings.Repeat("a", MaxRequestIDLength+1),
+			expected:  false,
+		},
+		{
+			name:      "invalid-character",
+			requestID: "test request\nid",
+			expected:  false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, isValidRequestID(testCase.requestID))
+		})
+	}
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	testCases := []struct {
+		name              string
+		requestID         string
+		expectedRequestID string
+	}{
+		{
+			name:              "accepted",
+			requestID:         "test-request-id",
+			expectedRequestID: "test-request-id",
+		},
+		{
+			name:      "generated",
+			requestID: "",
+		},
+		{
+			name:      "invalid/generated",
+			requestID: "test request id",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.ContextWithFallback = true
+			var contextRequestID string
+			engine.Use(RequestIDHandler())
+			engine.GET("/test", func(c *gin.Context) {
+				// Gin context falls back to request context, as it is passed into repository and auth calls
+				contextRequestID = coreLogging.GetRequestID(c)
+				c.JSON(http.StatusOK, gin.H{})
+			})
+
+			httpRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/test", nil)
+			if testCase.requestID != "" {
+				request.Header.Set(RequestIDHeader, testCase.requestID)
+			}
+			engine.ServeHTTP(httpRecorder, request)
+
+			requestID := httpRecorder.Header().Get(RequestIDHeader)
+			if testCase.expectedRequestID != "" {
+				assert.Equal(t, testCase.expectedRequestID, requestID)
+			} else {
+				assert.Len(t, requestID, 32)
+			}
+			assert.Equal(t, requestID, contextRequestID)
+		})
+	}
+}
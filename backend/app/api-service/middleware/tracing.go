@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreTracing "github.com/STLeee/mediation-platform/backend/core/tracing"
+)
+
+// TracingHandler is a middleware creating a server span of every request, it continues the trace propagated by
+// headers of the request, and the span is set to request context so spans of following calls are its children
+func TracingHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := coreTracing.StartSpan(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(c.Request.URL.Path),
+				attribute.String(coreLogging.RequestIDAttrKey, getRequestID(c)),
+			),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if user, ok := c.Get("user"); ok {
+			if user, ok := user.(*coreModel.User); ok && user != nil {
+				span.SetAttributes(semconv.EnduserID(user.UserID))
+			}
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	coreLogging "github.com/STLeee/mediation-platform/backend/core/logging"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreTracing "github.com/STLeee/mediation-platform/backend/core/tracing"
+	coreTracingTest "github.com/STLeee/mediation-platform/backend/core/tracing/tracingtest"
+)
+
+func TestTracingHandler(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	provider, exporter := coreTracingTest.NewInMemoryTracerProvider()
+	coreTracing.SetTracerProvider(provider)
+
+	testCases := []struct {
+		name               string
+		path               string
+		traceparent        string
+		expectedName       string
+		expectedStatus     int
+		expectedStatusCode codes.Code
+		expectedUserID     string
+		expectedTraceID    string
+		expectedChildSpans int
+	}{
+		{
+			name:               "matched-route",
+			path:               "/test/1",
+			expectedName:       "GET /test/:id",
+			expectedStatus:     http.StatusOK,
+			expectedStatusCode: codes.Unset,
+			expectedUserID:     "test-user-id",
+			expectedChildSpans: 1,
+		},
+		{
+			name:               "server-error",
+			path:               "/error",
+			expectedName:       "GET /error",
+			expectedStatus:     http.StatusInternalServerError,
+			expectedStatusCode: codes.Error,
+		},
+		{
+			name:               "unmatched-route",
+			path:               "/not-found/1",
+			expectedName:       "GET " + UnmatchedRoute,
+			expectedStatus:     http.StatusNotFound,
+			expectedStatusCode: codes.Unset,
+		},
+		{
+			name:               "propagated-trace",
+			path:               "/test/1",
+			traceparent:        "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedName:       "GET /test/:id",
+			expectedStatus:     http.StatusOK,
+			expectedStatusCode: codes.Unset,
+			expectedUserID:     "test-user-id",
+			expectedTraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedChildSpans: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer exporter.Reset()
+
+			// Middleware of engine handles unmatched route as well
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.ContextWithFallback = true
+			engine.Use(RequestIDHandler())
+			engine.Use(TracingHandler())
+			engine.GET("/test/:id", func(c *gin.Context) {
+				// Span of following call is a child of server span
+				_, span := coreTracing.StartSpan(c, "child")
+				span.End()
+				c.Set("user", &coreModel.User{UserID: "test-user-id"})
+				c.JSON(http.StatusOK, gin.H{})
+			})
+			engine.GET("/error", func(c *gin.Context) {
+				c.JSON(http.StatusInternalServerError, gin.H{})
+			})
+			request := httptest.NewRequest("GET", testCase.path, nil)
+			if testCase.traceparent != "" {
+				request.Header.Set("traceparent", testCase.traceparent)
+			}
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, testCase.expectedChildSpans+1)
+			serverSpan := spans[len(spans)-1]
+			assert.Equal(t, testCase.expectedName, serverSpan.Name)
+			assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind)
+			assert.Equal(t, testCase.expectedStatusCode, serverSpan.Status.Code)
+			assert.Contains(t, serverSpan.Attributes, semconv.HTTPResponseStatusCode(testCase.expectedStatus))
+			assert.Contains(t, serverSpan.Attributes, attribute.String(coreLogging.RequestIDAttrKey, recorder.Header().Get(RequestIDHeader)))
+			if testCase.expectedUserID != "" {
+				assert.Contains(t, serverSpan.Attributes, semconv.EnduserID(testCase.expectedUserID))
+			}
+			if testCase.expectedTraceID != "" {
+				assert.Equal(t, testCase.expectedTraceID, serverSpan.SpanContext.TraceID().String())
+				assert.True(t, serverSpan.Parent.IsRemote())
+			}
+			for _, span := range spans[:len(spans)-1] {
+				assert.Equal(t, serverSpan.SpanContext.SpanID(), span.Parent.SpanID())
+			}
+		})
+	}
+
+	// With noop tracer provider, spans are not recorded and the request is handled as well
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	engine := gin.New()
+	engine.Use(TracingHandler())
+	engine.GET("/test", func(c *gin.Context) {
+		assert.False(t, trace.SpanFromContext(c.Request.Context()).IsRecording())
+		c.Status(http.StatusOK)
+	})
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest("GET", "/test", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, exporter.GetSpans())
+}
@@ -1,8 +1,6 @@
 package v1
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
@@ -18,9 +16,7 @@ func UserAPIAuthorizationHandler() gin.HandlerFunc {
 			user, _ = userInterface.(*coreModel.User)
 		}
 		if user == nil {
-			c.Error(model.HttpStatusCodeError{
-				StatusCode: http.StatusUnauthorized,
-			})
+			c.Error(model.NewError(model.ErrorCodeUnauthorized, "", nil))
 			c.Abort()
 			return
 		}
@@ -32,9 +28,7 @@ func UserAPIAuthorizationHandler() gin.HandlerFunc {
 			return
 		}
 
-		c.Error(model.HttpStatusCodeError{
-			StatusCode: http.StatusForbidden,
-		})
+		c.Error(model.NewError(model.ErrorCodeForbidden, "", nil))
 		c.Abort()
 	}
 }
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// IssueAPIAuthorizationHandler is a middleware for Issue API authorization, only authenticated users can access issues,
+// participants of the issue are checked by the controller
+func IssueAPIAuthorizationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Get user from context
+		var user *coreModel.User
+		if userInterface, ok := c.Get("user"); ok {
+			user, _ = userInterface.(*coreModel.User)
+		}
+		if user == nil {
+			c.Error(model.NewError(model.ErrorCodeUnauthorized, "", nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// IssueParticipantAuthorizationHandler is a middleware for authorizing participants of the issue in path,
+// users who can moderate issues are authorized as well
+func IssueParticipantAuthorizationHandler(issueDBRepo coreRepository.IssueDBRepository, policy *coreAuthz.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Get user from context
+		var user *coreModel.User
+		if userInterface, ok := c.Get("user"); ok {
+			user, _ = userInterface.(*coreModel.User)
+		}
+		if user == nil {
+			c.Error(model.NewError(model.ErrorCodeUnauthorized, "", nil))
+			c.Abort()
+			return
+		}
+
+		// Get issue
+		issue, err := issueDBRepo.GetIssueByID(c, c.Param("issue_id"))
+		if err != nil {
+			c.Error(model.WrapError(err, "failed to get issue"))
+			c.Abort()
+			return
+		}
+
+		// Check if user is participant of the issue or can moderate issues
+		if issue.IsParticipant(user.UserID) || policy.IsUserAllowed(user, coreAuthz.ResourceIssue, coreAuthz.ActionModerate) {
+			c.Set("issue", issue)
+			c.Next()
+			return
+		}
+
+		c.Error(model.NewError(model.ErrorCodeForbidden, "", nil))
+		c.Abort()
+	}
+}
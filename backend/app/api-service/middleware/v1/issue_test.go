@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/model"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreModel "github.com/STLeee/mediation-platform/backend/core/model"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+type MockIssueDBRepository struct {
+	coreRepository.IssueDBRepository
+	GetIssueByIDFunc func(ctx context.Context, issueID string) (*coreModel.Issue, error)
+}
+
+func (repo *MockIssueDBRepository) GetIssueByID(ctx context.Context, issueID string) (*coreModel.Issue, error) {
+	return repo.GetIssueByIDFunc(ctx, issueID)
+}
+
+func TestIssueAPIAuthorizationHandler(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setUser    bool
+		tokenUser  *coreModel.User
+		statusCode int
+		isErr      bool
+	}{
+		{
+			name:       "user",
+			setUser:    true,
+			tokenUser:  &coreModel.User{UserID: "test-user-id"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "nil-user",
+			setUser:    true,
+			tokenUser:  nil,
+			statusCode: http.StatusUnauthorized,
+			isErr:      true,
+		},
+		{
+			name:       "anonymous",
+			setUser:    false,
+			statusCode: http.StatusUnauthorized,
+			isErr:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			called := false
+			utils.RegisterAndRecordHttpRequest(
+				func(router *gin.RouterGroup) {
+					router.Use(func(ctx *gin.Context) {
+						// Set user to context
+						if testCase.setUser {
+							ctx.Set("user", testCase.tokenUser)
+						}
+						ctx.Next()
+
+						// Check error
+						if testCase.isErr {
+							err := ctx.Errors.Last()
+							assert.NotNil(t, err)
+							assert.Equal(t, testCase.statusCode, err.Err.(model.HttpStatusCodeError).StatusCode)
+						}
+					})
+					router.Use(IssueAPIAuthorizationHandler())
+					router.GET("/", func(ctx *gin.Context) {
+						called = true
+						ctx.Status(http.StatusOK)
+					})
+				},
+				"GET",
+				"/",
+				nil,
+			)
+
+			assert.Equal(t, !testCase.isErr, called)
+		})
+	}
+}
+
+func TestIssueParticipantAuthorizationHandler(t *testing.T) {
+	issue := &coreModel.Issue{
+		IssueID:   "test-issue-id",
+		Parties:   []string{"test-creator-id", "test-party-id"},
+		CreatedBy: "test-creator-id",
+	}
+
+	testCases := []struct {
+		name        string
+		tokenUser   *coreModel.User
+		getIssueErr error
+		statusCode  int
+		isErr       bool
+	}{
+		{
+			name:       "creator",
+			tokenUser:  &coreModel.User{UserID: "test-creator-id"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "party",
+			tokenUser:  &coreModel.User{UserID: "test-party-id"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "not-participant",
+			tokenUser:  &coreModel.User{UserID: "test-stranger-id"},
+			statusCode: http.StatusForbidden,
+			isErr:      true,
+		},
+		{
+			name:       "mediator",
+			tokenUser:  &coreModel.User{UserID: "test-mediator-id", Role: coreModel.UserRoleMediator},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "no-user",
+			tokenUser:  nil,
+			statusCode: http.StatusUnauthorized,
+			isErr:      true,
+		},
+		{
+			name:        "issue-not-found",
+			tokenUser:   &coreModel.User{UserID: "test-creator-id"},
+			getIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			statusCode:  http.StatusNotFound,
+			isErr:       true,
+		},
+		{
+			name:        "db-error",
+			tokenUser:   &coreModel.User{UserID: "test-creator-id"},
+			getIssueErr: coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeServerError},
+			statusCode:  http.StatusInternalServerError,
+			isErr:       true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := coreAuthz.NewPolicy(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mockIssueDBRepo := &MockIssueDBRepository{
+				GetIssueByIDFunc: func(ctx context.Context, issueID string) (*coreModel.Issue, error) {
+					assert.Equal(t, issue.IssueID, issueID)
+					if testCase.getIssueErr != nil {
+						return nil, testCase.getIssueErr
+					}
+					return issue, nil
+				},
+			}
+			httpRecorder := utils.RegisterAndRecordHttpRequest(
+				func(router *gin.RouterGroup) {
+					router.Use(func(ctx *gin.Context) {
+						// Set user to context
+						ctx.Set("user", testCase.tokenUser)
+						ctx.Next()
+
+						// Check error
+						if testCase.isErr {
+							err := ctx.Errors.Last()
+							assert.NotNil(t, err)
+							assert.Equal(t, testCase.statusCode, err.Err.(model.HttpStatusCodeError).StatusCode)
+						}
+					})
+					router.Use(IssueParticipantAuthorizationHandler(mockIssueDBRepo, policy))
+					router.GET("/:issue_id", func(ctx *gin.Context) {
+						ctx.JSON(http.StatusOK, ctx.MustGet("issue"))
+					})
+				},
+				"GET",
+				"/"+issue.IssueID,
+				nil,
+			)
+
+			// Check response
+			if !testCase.isErr {
+				assert.Equal(t, testCase.statusCode, httpRecorder.Code)
+				assert.Equal(t, utils.ConvertToJSONString(issue), httpRecorder.Body.String())
+			}
+		})
+	}
+}
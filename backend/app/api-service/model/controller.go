@@ -1,14 +1,163 @@
 package model
 
+import "time"
+
 // MessageResponse is a response for message
 type MessageResponse struct {
 	Message string `json:"message" example:"ok"`
 }
 
+// ErrorResponse is a response for error, code is stable for clients to distinguish errors
+type ErrorResponse struct {
+	Code      string         `json:"code" example:"token_invalid"`
+	Message   string         `json:"message" example:"token is invalid"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty" example:"5f0c3a9e-7d8b-4c21-9a51-0e6f2b8d4c17"`
+}
+
+// PageRequest is a request for pagination, use either offset or cursor
+type PageRequest struct {
+	Limit     int64  `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Offset    int64  `form:"offset" binding:"omitempty,min=0" example:"0"`
+	Cursor    string `form:"cursor" binding:"omitempty,excluded_with=Offset" example:"eyJ2IjoxfQ"`
+	WithTotal bool   `form:"with_total" example:"false"`
+}
+
+// PageResponse is a response for a page of items
+type PageResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJ2IjoxfQ"`
+	Total      *int64 `json:"total,omitempty" example:"42"`
+}
+
+// DependencyCheckResponse is a result of checking a dependency, latency is in milliseconds, errors of dependencies
+// are logged rather than responded since readiness is public
+type DependencyCheckResponse struct {
+	Status    string  `json:"status" example:"up"`
+	Critical  bool    `json:"critical" example:"true"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+}
+
+// ReadinessResponse is a response for readiness check, it is down when any critical dependency is down or the
+// service is shutting down
+type ReadinessResponse struct {
+	Status       string                             `json:"status" example:"up"`
+	ShuttingDown bool                               `json:"shutting_down,omitempty" example:"false"`
+	CheckedAt    time.Time                          `json:"checked_at" example:"2025-03-01T00:00:00Z"`
+	Checks       map[string]DependencyCheckResponse `json:"checks"`
+}
+
 type GetUserResponse struct {
 	UserID      string `json:"user_id" example:"1234567890"`
 	DisplayName string `json:"display_name" example:"Scott Li"`
 	Email       string `json:"email" example:"example@mediation-platform.com"`
 	PhoneNumber string `json:"phone_number" example:"+886987654321"`
 	PhotoURL    string `json:"photo_url" example:"https://example.com/photo.jpg"`
+	Role        string `json:"role" example:"user"`
+}
+
+type UpdateUserRequest struct {
+	DisplayName *string `json:"display_name" binding:"omitempty,min=1,max=100" example:"Scott Li"`
+	PhotoURL    *string `json:"photo_url" binding:"omitempty,url,max=2048" example:"https://example.com/photo.jpg"`
+	PhoneNumber *string `json:"phone_number" binding:"omitempty,e164" example:"+886987654321"`
+}
+
+type CreateIssueRequest struct {
+	Title       string   `json:"title" binding:"required,max=200" example:"Noise from upstairs"`
+	Description string   `json:"description" binding:"max=5000" example:"Loud music after midnight every weekend"`
+	Parties     []string `json:"parties" binding:"dive,mongodb" example:"000000000000000000000002"`
+}
+
+type UpdateIssueRequest struct {
+	Title       *string   `json:"title" binding:"omitempty,min=1,max=200" example:"Noise from upstairs"`
+	Description *string   `json:"description" binding:"omitempty,max=5000" example:"Loud music after midnight every weekend"`
+	Parties     *[]string `json:"parties" binding:"omitempty,dive,mongodb" example:"000000000000000000000002"`
+}
+
+type IssueResponse struct {
+	IssueID     string     `json:"issue_id" example:"1234567890"`
+	Title       string     `json:"title" example:"Noise from upstairs"`
+	Description string     `json:"description" example:"Loud music after midnight every weekend"`
+	Parties     []string   `json:"parties" example:"000000000000000000000001,000000000000000000000002"`
+	Status      string     `json:"status" example:"open"`
+	CreatedBy   string     `json:"created_by" example:"000000000000000000000001"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-03-01T00:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"2025-03-01T00:00:00Z"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty" example:"2025-03-01T00:00:00Z"`
+}
+
+type CreateCommentRequest struct {
+	Body            string `json:"body" binding:"required,max=5000" example:"I would like to propose quiet hours after 10pm"`
+	ParentCommentID string `json:"parent_comment_id" binding:"omitempty,mongodb" example:"000000000000000000000001"`
+}
+
+type EditCommentRequest struct {
+	Body string `json:"body" binding:"required,max=5000" example:"I would like to propose quiet hours after 11pm"`
+}
+
+type CommentResponse struct {
+	CommentID       string    `json:"comment_id" example:"1234567890"`
+	IssueID         string    `json:"issue_id" example:"1234567890"`
+	AuthorID        string    `json:"author_id" example:"000000000000000000000001"`
+	Body            string    `json:"body" example:"I would like to propose quiet hours after 10pm"`
+	ParentCommentID string    `json:"parent_comment_id,omitempty" example:"000000000000000000000001"`
+	Edited          bool      `json:"edited" example:"false"`
+	Deleted         bool      `json:"deleted" example:"false"`
+	IsAIGenerated   bool      `json:"is_ai_generated" example:"false"`
+	CreatedAt       time.Time `json:"created_at" example:"2025-03-01T00:00:00Z"`
+	UpdatedAt       time.Time `json:"updated_at" example:"2025-03-01T00:00:00Z"`
+}
+
+type ListNotificationsRequest struct {
+	Unread bool `form:"unread" example:"false"`
+}
+
+type NotificationResponse struct {
+	NotificationID string         `json:"notification_id" example:"1234567890"`
+	Type           string         `json:"type" example:"comment_created"`
+	Payload        map[string]any `json:"payload"`
+	ReadAt         *time.Time     `json:"read_at,omitempty" example:"2025-03-01T00:00:00Z"`
+	CreatedAt      time.Time      `json:"created_at" example:"2025-03-01T00:00:00Z"`
+}
+
+type UnreadNotificationCountResponse struct {
+	UnreadCount int64 `json:"unread_count" example:"3"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	UpdatedCount int64 `json:"updated_count" example:"3"`
+}
+
+type ListUsersRequest struct {
+	Email       string `form:"email" binding:"omitempty,email" example:"example@mediation-platform.com"`
+	PhoneNumber string `form:"phone_number" binding:"omitempty,e164" example:"+886987654321"`
+	FirebaseUID string `form:"firebase_uid" example:"LRgwDJoRP7BCYJBNmNrNL4rxhvgR"`
+}
+
+type AdminUserResponse struct {
+	UserID      string    `json:"user_id" example:"1234567890"`
+	FirebaseUID string    `json:"firebase_uid" example:"LRgwDJoRP7BCYJBNmNrNL4rxhvgR"`
+	DisplayName string    `json:"display_name" example:"Scott Li"`
+	Email       string    `json:"email" example:"example@mediation-platform.com"`
+	PhoneNumber string    `json:"phone_number" example:"+886987654321"`
+	PhotoURL    string    `json:"photo_url" example:"https://example.com/photo.jpg"`
+	Role        string    `json:"role" example:"user"`
+	Disabled    bool      `json:"disabled" example:"false"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-03-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-03-01T00:00:00Z"`
+	LastLoginAt time.Time `json:"last_login_at" example:"2025-03-01T00:00:00Z"`
+}
+
+type ListAuditLogsRequest struct {
+	ActorID  string `form:"actor_id" example:"1234567890"`
+	TargetID string `form:"target_id" example:"1234567890"`
+	Action   string `form:"action" binding:"omitempty,oneof=user_disabled user_enabled user_force_logout" example:"user_disabled"`
+}
+
+type AuditLogResponse struct {
+	AuditLogID string    `json:"audit_log_id" example:"1234567890"`
+	ActorID    string    `json:"actor_id" example:"1234567890"`
+	Action     string    `json:"action" example:"user_disabled"`
+	TargetID   string    `json:"target_id" example:"1234567890"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-03-01T00:00:00Z"`
 }
@@ -2,13 +2,20 @@ package model
 
 import (
 	"net/http"
+
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
 )
 
-// HttpStatusCodeError struct for HTTP status code error
+// HttpStatusCodeError struct for HTTP status code error, Code distinguishes errors with the same status code,
+// and Details are extra information of the error like the offending field. Errors created by NewError and WrapError
+// carry stack of where they are created, so reports of them point to the handler rather than the error middleware
 type HttpStatusCodeError struct {
 	StatusCode int
+	Code       string
 	Message    string
+	Details    map[string]any
 	Err        error
+	stack      []coreErrReport.StackFrame
 }
 
 // Error returns the error message
@@ -24,3 +31,8 @@ func (e HttpStatusCodeError) Error() string {
 func (e HttpStatusCodeError) Unwrap() error {
 	return e.Err
 }
+
+// StackTrace returns stack of where the error is created, empty if it is not created by NewError or WrapError
+func (e HttpStatusCodeError) StackTrace() []coreErrReport.StackFrame {
+	return e.stack
+}
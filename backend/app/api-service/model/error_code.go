@@ -0,0 +1,197 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreCache "github.com/STLeee/mediation-platform/backend/core/cache"
+	coreDB "github.com/STLeee/mediation-platform/backend/core/db"
+	coreErrReport "github.com/STLeee/mediation-platform/backend/core/errreport"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// Error codes of API, they are stable so clients can distinguish errors by them
+const (
+	ErrorCodeBadRequest         = "bad_request"
+	ErrorCodeUnauthorized       = "unauthorized"
+	ErrorCodeForbidden          = "forbidden"
+	ErrorCodeNotFound           = "not_found"
+	ErrorCodeConflict           = "conflict"
+	ErrorCodeInternalError      = "internal_error"
+	ErrorCodeBadGateway         = "bad_gateway"
+	ErrorCodeServiceUnavailable = "service_unavailable"
+	ErrorCodeTokenEmpty         = "token_empty"
+	ErrorCodeTokenInvalid       = "token_invalid"
+	ErrorCodeUserNotFound       = "user_not_found"
+	ErrorCodeUserDisabled       = "user_disabled"
+	ErrorCodeInvalidCursor      = "invalid_cursor"
+	ErrorCodeDuplicateKey       = "duplicate_key"
+	ErrorCodeAuthServiceError   = "auth_service_error"
+)
+
+// ErrorDefinition is a definition of error code, with its HTTP status code and default message
+type ErrorDefinition struct {
+	StatusCode int
+	Message    string
+}
+
+// ErrorDefinitions are definitions of error codes, default messages are lower-cased like other messages of errors
+var ErrorDefinitions = map[string]ErrorDefinition{
+	ErrorCodeBadRequest:         {StatusCode: http.StatusBadRequest, Message: "bad request"},
+	ErrorCodeUnauthorized:       {StatusCode: http.StatusUnauthorized, Message: "unauthorized"},
+	ErrorCodeForbidden:          {StatusCode: http.StatusForbidden, Message: "forbidden"},
+	ErrorCodeNotFound:           {StatusCode: http.StatusNotFound, Message: "not found"},
+	ErrorCodeConflict:           {StatusCode: http.StatusConflict, Message: "conflict"},
+	ErrorCodeInternalError:      {StatusCode: http.StatusInternalServerError, Message: "internal server error"},
+	ErrorCodeBadGateway:         {StatusCode: http.StatusBadGateway, Message: "bad gateway"},
+	ErrorCodeServiceUnavailable: {StatusCode: http.StatusServiceUnavailable, Message: "service unavailable"},
+	ErrorCodeTokenEmpty:         {StatusCode: http.StatusUnauthorized, Message: "empty token"},
+	ErrorCodeTokenInvalid:       {StatusCode: http.StatusUnauthorized, Message: "token is invalid"},
+	ErrorCodeUserNotFound:       {StatusCode: http.StatusUnauthorized, Message: "user not found"},
+	ErrorCodeUserDisabled:       {StatusCode: http.StatusForbidden, Message: "user is disabled"},
+	ErrorCodeInvalidCursor:      {StatusCode: http.StatusBadRequest, Message: "invalid cursor"},
+	ErrorCodeDuplicateKey:       {StatusCode: http.StatusConflict, Message: "duplicate value"},
+	ErrorCodeAuthServiceError:   {StatusCode: http.StatusInternalServerError, Message: "auth service error"},
+}
+
+// statusErrorCodes are generic error codes of HTTP status codes, for errors without code
+var statusErrorCodes = map[int]string{
+	http.StatusBadRequest:          ErrorCodeBadRequest,
+	http.StatusUnauthorized:        ErrorCodeUnauthorized,
+	http.StatusForbidden:           ErrorCodeForbidden,
+	http.StatusNotFound:            ErrorCodeNotFound,
+	http.StatusConflict:            ErrorCodeConflict,
+	http.StatusInternalServerError: ErrorCodeInternalError,
+	http.StatusBadGateway:          ErrorCodeBadGateway,
+	http.StatusServiceUnavailable:  ErrorCodeServiceUnavailable,
+}
+
+// coreErrorCodes maps error types of core to error codes, types of different packages are different keys
+var coreErrorCodes = map[any]string{
+	coreAuth.AuthServiceErrorTypeServerError:         ErrorCodeAuthServiceError,
+	coreAuth.AuthServiceErrorTypeTokenInvalid:        ErrorCodeTokenInvalid,
+	coreAuth.AuthServiceErrorTypeUserNotFound:        ErrorCodeUserNotFound,
+	coreRepository.RepositoryErrorTypeServerError:    ErrorCodeInternalError,
+	coreRepository.RepositoryErrorTypeConfigError:    ErrorCodeInternalError,
+	coreRepository.RepositoryErrorTypeRecordNotFound: ErrorCodeNotFound,
+	coreRepository.RepositoryErrorTypeInvalidID:      ErrorCodeNotFound,
+	coreRepository.RepositoryErrorTypeInvalidData:    ErrorCodeInternalError,
+	coreRepository.RepositoryErrorTypeInvalidCursor:  ErrorCodeInvalidCursor,
+	coreRepository.RepositoryErrorTypeDuplicateKey:   ErrorCodeDuplicateKey,
+	coreCache.CacheErrorTypeServerError:              ErrorCodeServiceUnavailable,
+	coreCache.CacheErrorTypeOperationError:           ErrorCodeInternalError,
+	coreDB.DBErrorTypeServerError:                    ErrorCodeServiceUnavailable,
+	coreDB.DBErrorConfigError:                        ErrorCodeInternalError,
+}
+
+// lookupCoreError finds the outermost core error in chain of err, and returns its error code and details
+func lookupCoreError(err error) (string, map[string]any, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		var errType any
+		var details map[string]any
+		switch coreErr := err.(type) {
+		case coreAuth.AuthServiceError:
+			errType = coreErr.ErrType
+		case coreRepository.RepositoryError:
+			errType = coreErr.ErrType
+			if coreErr.Field != "" {
+				details = map[string]any{"field": coreErr.Field}
+			}
+		case coreCache.CacheError:
+			errType = coreErr.ErrType
+		case coreDB.DBError:
+			errType = coreErr.ErrType
+		default:
+			continue
+		}
+		code, ok := coreErrorCodes[errType]
+		return code, details, ok
+	}
+	return "", nil, false
+}
+
+// NewError creates an error of the code, message replaces the default message of the code if it is not empty
+func NewError(code string, message string, err error) HttpStatusCodeError {
+	return newError(code, message, err, 1)
+}
+
+// newError creates an error of the code with stack of caller, skip is the number of frames to skip above the caller
+func newError(code string, message string, err error, skip int) HttpStatusCodeError {
+	definition, ok := ErrorDefinitions[code]
+	if !ok {
+		code = ErrorCodeInternalError
+		definition = ErrorDefinitions[code]
+	}
+	if message == "" {
+		message = definition.Message
+	}
+	return HttpStatusCodeError{
+		StatusCode: definition.StatusCode,
+		Code:       code,
+		Message:    message,
+		Err:        err,
+		stack:      coreErrReport.CaptureStack(skip + 1),
+	}
+}
+
+// WrapError creates an error from the core error in chain of err, message describes the failed operation and is
+// only used for server errors, since client errors are described by their codes
+func WrapError(err error, message string) HttpStatusCodeError {
+	code, details, ok := lookupCoreError(err)
+	if !ok {
+		code = ErrorCodeInternalError
+	}
+	if ErrorDefinitions[code].StatusCode < http.StatusInternalServerError {
+		message = ""
+		if field, ok := details["field"]; ok && code == ErrorCodeDuplicateKey {
+			message = fmt.Sprintf("%s of %s", ErrorDefinitions[code].Message, field)
+		}
+	}
+	httpStatusCodeError := newError(code, message, err, 1)
+	httpStatusCodeError.Details = details
+	return httpStatusCodeError
+}
+
+// ResolveError resolves the code of err for response. Code set by handler is kept, then the core error in chain
+// decides the code, and it reclassifies server errors of handler. Generic code of the status code and its default
+// message are the last resort
+func ResolveError(err error) HttpStatusCodeError {
+	httpStatusCodeError, ok := err.(HttpStatusCodeError)
+	if !ok {
+		httpStatusCodeError = HttpStatusCodeError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        err,
+		}
+	}
+	if httpStatusCodeError.Code != "" {
+		return httpStatusCodeError
+	}
+
+	// Code of core error in chain
+	if code, details, ok := lookupCoreError(httpStatusCodeError.Err); ok {
+		if httpStatusCodeError.StatusCode == ErrorDefinitions[code].StatusCode {
+			httpStatusCodeError.Code = code
+			httpStatusCodeError.Details = details
+			return httpStatusCodeError
+		}
+		if httpStatusCodeError.StatusCode == http.StatusInternalServerError {
+			return WrapError(httpStatusCodeError.Err, httpStatusCodeError.Message)
+		}
+	}
+
+	// Generic code of status code
+	code, ok := statusErrorCodes[httpStatusCodeError.StatusCode]
+	if !ok {
+		code = ErrorCodeInternalError
+		if httpStatusCodeError.StatusCode < http.StatusInternalServerError {
+			code = ErrorCodeBadRequest
+		}
+	}
+	httpStatusCodeError.Code = code
+	if httpStatusCodeError.Message == "" {
+		httpStatusCodeError.Message = ErrorDefinitions[code].Message
+	}
+	return httpStatusCodeError
+}
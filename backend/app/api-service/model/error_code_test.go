@@ -0,0 +1,233 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreCache "github.com/STLeee/mediation-platform/backend/core/cache"
+	coreDB "github.com/STLeee/mediation-platform/backend/core/db"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+func TestNewError(t *testing.T) {
+	testCases := []struct {
+		name               string
+		code               string
+		message            string
+		expectedStatusCode int
+		expectedCode       string
+		expectedMessage    string
+	}{
+		{
+			name:               "default-message",
+			code:               ErrorCodeUserDisabled,
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       ErrorCodeUserDisabled,
+			expectedMessage:    "user is disabled",
+		},
+		{
+			name:               "with-message",
+			code:               ErrorCodeTokenEmpty,
+			message:            "test message",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       ErrorCodeTokenEmpty,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "unknown-code",
+			code:               "unknown",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       ErrorCodeInternalError,
+			expectedMessage:    "internal server error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := NewError(testCase.code, testCase.message, nil)
+			assert.Equal(t, testCase.expectedStatusCode, err.StatusCode)
+			assert.Equal(t, testCase.expectedCode, err.Code)
+			assert.Equal(t, testCase.expectedMessage, err.Error())
+			assert.Equal(t, "github.com/STLeee/mediation-platform/backend/app/api-service/model.TestNewError.func1", err.StackTrace()[0].Function)
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedCode       string
+		expectedMessage    string
+		expectedDetails    map[string]any
+	}{
+		{
+			name:               "auth/token-invalid",
+			err:                coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeTokenInvalid},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       ErrorCodeTokenInvalid,
+			expectedMessage:    "token is invalid",
+		},
+		{
+			name:               "auth/user-not-found",
+			err:                coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeUserNotFound},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       ErrorCodeUserNotFound,
+			expectedMessage:    "user not found",
+		},
+		{
+			name:               "auth/server-error",
+			err:                coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeServerError},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       ErrorCodeAuthServiceError,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "repository/record-not-found",
+			err:                coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode: http.StatusNotFound,
+			expectedCode:       ErrorCodeNotFound,
+			expectedMessage:    "not found",
+		},
+		{
+			name:               "repository/duplicate-key",
+			err:                coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeDuplicateKey, Field: "email"},
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       ErrorCodeDuplicateKey,
+			expectedMessage:    "duplicate value of email",
+			expectedDetails:    map[string]any{"field": "email"},
+		},
+		{
+			name: "repository/wrapping-db-error",
+			err: coreRepository.RepositoryError{
+				ErrType: coreRepository.RepositoryErrorTypeServerError,
+				Err:     coreDB.DBError{ErrType: coreDB.DBErrorTypeServerError},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       ErrorCodeInternalError,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "cache/server-error",
+			err:                fmt.Errorf("wrapped: %w", coreCache.CacheError{ErrType: coreCache.CacheErrorTypeServerError}),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedCode:       ErrorCodeServiceUnavailable,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "db/server-error",
+			err:                coreDB.DBError{ErrType: coreDB.DBErrorTypeServerError},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedCode:       ErrorCodeServiceUnavailable,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "unknown-error",
+			err:                fmt.Errorf("test error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       ErrorCodeInternalError,
+			expectedMessage:    "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := WrapError(testCase.err, "test message")
+			assert.Equal(t, testCase.expectedStatusCode, err.StatusCode)
+			assert.Equal(t, testCase.expectedCode, err.Code)
+			assert.Equal(t, testCase.expectedMessage, err.Error())
+			assert.Equal(t, testCase.expectedDetails, err.Details)
+			assert.Equal(t, testCase.err, err.Unwrap())
+			assert.Equal(t, "github.com/STLeee/mediation-platform/backend/app/api-service/model.TestWrapError.func1", err.StackTrace()[0].Function)
+		})
+	}
+}
+
+func TestResolveError(t *testing.T) {
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedCode       string
+		expectedMessage    string
+	}{
+		{
+			name:               "with-code",
+			err:                HttpStatusCodeError{StatusCode: http.StatusForbidden, Code: ErrorCodeUserDisabled, Message: "test message"},
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       ErrorCodeUserDisabled,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "generic-code",
+			err:                HttpStatusCodeError{StatusCode: http.StatusConflict, Message: "test message"},
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       ErrorCodeConflict,
+			expectedMessage:    "test message",
+		},
+		{
+			name:               "generic-code/unknown-client-status",
+			err:                HttpStatusCodeError{StatusCode: http.StatusTeapot},
+			expectedStatusCode: http.StatusTeapot,
+			expectedCode:       ErrorCodeBadRequest,
+			expectedMessage:    "bad request",
+		},
+		{
+			name: "core-error/same-status",
+			err: HttpStatusCodeError{
+				StatusCode: http.StatusNotFound,
+				Message:    "issue not found",
+				Err:        coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedCode:       ErrorCodeNotFound,
+			expectedMessage:    "issue not found",
+		},
+		{
+			name: "core-error/reclassify-server-error",
+			err: HttpStatusCodeError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "failed to update user",
+				Err:        coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeDuplicateKey},
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       ErrorCodeDuplicateKey,
+			expectedMessage:    "duplicate value",
+		},
+		{
+			name: "core-error/different-status",
+			err: HttpStatusCodeError{
+				StatusCode: http.StatusForbidden,
+				Err:        coreRepository.RepositoryError{ErrType: coreRepository.RepositoryErrorTypeRecordNotFound},
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       ErrorCodeForbidden,
+			expectedMessage:    "forbidden",
+		},
+		{
+			name:               "core-error/not-wrapped",
+			err:                coreAuth.AuthServiceError{ErrType: coreAuth.AuthServiceErrorTypeTokenInvalid},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       ErrorCodeTokenInvalid,
+			expectedMessage:    "token is invalid",
+		},
+		{
+			name:               "unknown-error",
+			err:                fmt.Errorf("test error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       ErrorCodeInternalError,
+			expectedMessage:    "internal server error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ResolveError(testCase.err)
+			assert.Equal(t, testCase.expectedStatusCode, err.StatusCode)
+			assert.Equal(t, testCase.expectedCode, err.Code)
+			assert.Equal(t, testCase.expectedMessage, err.Error())
+		})
+	}
+}
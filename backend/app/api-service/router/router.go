@@ -5,20 +5,79 @@ import (
 
 	"github.com/STLeee/mediation-platform/backend/app/api-service/controller"
 	controllerV1 "github.com/STLeee/mediation-platform/backend/app/api-service/controller/v1"
+	"github.com/STLeee/mediation-platform/backend/app/api-service/middleware"
 	middlewareV1 "github.com/STLeee/mediation-platform/backend/app/api-service/middleware/v1"
+	coreAI "github.com/STLeee/mediation-platform/backend/core/ai"
+	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
+	coreHealth "github.com/STLeee/mediation-platform/backend/core/health"
+	coreNotification "github.com/STLeee/mediation-platform/backend/core/notification"
+	coreRepository "github.com/STLeee/mediation-platform/backend/core/repository"
 )
 
-func RegisterHealthRouter(r *gin.RouterGroup) {
-	healthController := controller.NewHealthController()
+func RegisterHealthRouter(r *gin.RouterGroup, healthChecker *coreHealth.HealthChecker) {
+	healthController := controller.NewHealthController(healthChecker)
 
 	r.GET("/liveness", healthController.Liveness)
 	r.GET("/readiness", healthController.Readiness)
 }
 
-func RegisterV1UserRouter(r *gin.RouterGroup) {
+func RegisterV1UserRouter(r *gin.RouterGroup, authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, userCacheRepo coreRepository.UserCacheRepository, policy *coreAuthz.Policy) {
 	r.Use(middlewareV1.UserAPIAuthorizationHandler())
 
-	userController := controllerV1.NewUserController()
+	userController := controllerV1.NewUserController(authService, userDBRepo, userCacheRepo)
 
-	r.GET("/:user_id", userController.GetUser)
+	r.GET("/:user_id", middleware.RequirePermission(policy, coreAuthz.ResourceUser, coreAuthz.ActionRead), userController.GetUser)
+	r.PATCH("/:user_id", middleware.RequirePermission(policy, coreAuthz.ResourceUser, coreAuthz.ActionUpdate), userController.UpdateUser)
+	r.DELETE("/:user_id", middleware.RequirePermission(policy, coreAuthz.ResourceUser, coreAuthz.ActionDelete), userController.DeleteUser)
+}
+
+func RegisterV1IssueRouter(r *gin.RouterGroup, issueDBRepo coreRepository.IssueDBRepository, userDBRepo coreRepository.UserDBRepository, notifier coreNotification.Notifier, policy *coreAuthz.Policy) {
+	r.Use(middlewareV1.IssueAPIAuthorizationHandler())
+
+	issueController := controllerV1.NewIssueController(issueDBRepo, userDBRepo, notifier, policy)
+
+	r.POST("", middleware.RequirePermission(policy, coreAuthz.ResourceIssue, coreAuthz.ActionCreate), issueController.CreateIssue)
+	r.GET("", middleware.RequirePermission(policy, coreAuthz.ResourceIssue, coreAuthz.ActionRead), issueController.ListIssues)
+	r.GET("/:issue_id", middleware.RequirePermission(policy, coreAuthz.ResourceIssue, coreAuthz.ActionRead), issueController.GetIssue)
+	r.PATCH("/:issue_id", middleware.RequirePermission(policy, coreAuthz.ResourceIssue, coreAuthz.ActionUpdate), issueController.UpdateIssue)
+	r.POST("/:issue_id/close", middleware.RequirePermission(policy, coreAuthz.ResourceIssue, coreAuthz.ActionUpdate), issueController.CloseIssue)
+}
+
+func RegisterV1CommentRouter(r *gin.RouterGroup, issueDBRepo coreRepository.IssueDBRepository, commentDBRepo coreRepository.CommentDBRepository, notifier coreNotification.Notifier, policy *coreAuthz.Policy) {
+	r.Use(middlewareV1.IssueParticipantAuthorizationHandler(issueDBRepo, policy))
+
+	commentController := controllerV1.NewCommentController(commentDBRepo, notifier)
+
+	r.POST("", middleware.RequirePermission(policy, coreAuthz.ResourceComment, coreAuthz.ActionCreate), commentController.CreateComment)
+	r.GET("", middleware.RequirePermission(policy, coreAuthz.ResourceComment, coreAuthz.ActionRead), commentController.ListComments)
+	r.PATCH("/:comment_id", middleware.RequirePermission(policy, coreAuthz.ResourceComment, coreAuthz.ActionUpdate), commentController.EditComment)
+	r.DELETE("/:comment_id", middleware.RequirePermission(policy, coreAuthz.ResourceComment, coreAuthz.ActionDelete), commentController.DeleteComment)
+}
+
+func RegisterV1AICommentRouter(r *gin.RouterGroup, issueDBRepo coreRepository.IssueDBRepository, commentDBRepo coreRepository.CommentDBRepository, commentSuggester coreAI.CommentSuggester, notifier coreNotification.Notifier, policy *coreAuthz.Policy) {
+	r.Use(middlewareV1.IssueParticipantAuthorizationHandler(issueDBRepo, policy))
+
+	aiCommentController := controllerV1.NewAICommentController(commentDBRepo, commentSuggester, notifier)
+
+	r.POST("", middleware.RequirePermission(policy, coreAuthz.ResourceAIComment, coreAuthz.ActionCreate), aiCommentController.GenerateAIComment)
+}
+
+func RegisterV1NotificationRouter(r *gin.RouterGroup, notificationDBRepo coreRepository.NotificationDBRepository, policy *coreAuthz.Policy) {
+	notificationController := controllerV1.NewNotificationController(notificationDBRepo)
+
+	r.GET("", middleware.RequirePermission(policy, coreAuthz.ResourceNotification, coreAuthz.ActionRead), notificationController.ListNotifications)
+	r.GET("/unread-count", middleware.RequirePermission(policy, coreAuthz.ResourceNotification, coreAuthz.ActionRead), notificationController.GetUnreadCount)
+	r.POST("/read-all", middleware.RequirePermission(policy, coreAuthz.ResourceNotification, coreAuthz.ActionUpdate), notificationController.MarkAllAsRead)
+	r.POST("/:notification_id/read", middleware.RequirePermission(policy, coreAuthz.ResourceNotification, coreAuthz.ActionUpdate), notificationController.MarkAsRead)
+}
+
+func RegisterV1AdminRouter(r *gin.RouterGroup, authService coreAuth.BaseAuthService, userDBRepo coreRepository.UserDBRepository, userCacheRepo coreRepository.UserCacheRepository, auditLogDBRepo coreRepository.AuditLogDBRepository, policy *coreAuthz.Policy) {
+	adminController := controllerV1.NewAdminController(authService, userDBRepo, userCacheRepo, auditLogDBRepo)
+
+	r.GET("/user", middleware.RequirePermission(policy, coreAuthz.ResourceAdmin, coreAuthz.ActionRead), adminController.ListUsers)
+	r.POST("/user/:user_id/disable", middleware.RequirePermission(policy, coreAuthz.ResourceAdmin, coreAuthz.ActionUpdate), adminController.DisableUser)
+	r.POST("/user/:user_id/enable", middleware.RequirePermission(policy, coreAuthz.ResourceAdmin, coreAuthz.ActionUpdate), adminController.EnableUser)
+	r.POST("/user/:user_id/logout", middleware.RequirePermission(policy, coreAuthz.ResourceAdmin, coreAuthz.ActionUpdate), adminController.ForceLogoutUser)
+	r.GET("/audit-log", middleware.RequirePermission(policy, coreAuthz.ResourceAdmin, coreAuthz.ActionRead), adminController.ListAuditLogs)
 }
@@ -1,20 +1,118 @@
 package router
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/app/api-service/middleware"
+	coreAuthz "github.com/STLeee/mediation-platform/backend/core/authz"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
 )
 
 func TestRegisterHealthRouter(t *testing.T) {
-	utils.TestRouterRegister(t, RegisterHealthRouter, []string{
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterHealthRouter(r, nil)
+	}, []string{
 		"/liveness",
 		"/readiness",
 	})
 }
 
 func TestRegisterV1UserRouter(t *testing.T) {
-	utils.TestRouterRegister(t, RegisterV1UserRouter, []string{
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterV1UserRouter(r, nil, nil, nil, nil)
+	}, []string{
+		"/:user_id",
+		"/:user_id",
 		"/:user_id",
 	})
 }
+
+func TestRegisterV1IssueRouter(t *testing.T) {
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterV1IssueRouter(r, nil, nil, nil, nil)
+	}, []string{
+		"/",
+		"/",
+		"/:issue_id",
+		"/:issue_id",
+		"/:issue_id/close",
+	})
+}
+
+func TestRegisterV1IssueRouter_Anonymous(t *testing.T) {
+	policy, err := coreAuthz.NewPolicy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.ErrorHandler(nil))
+	RegisterV1IssueRouter(engine.Group("/issue"), nil, nil, nil, policy)
+
+	// Requests without token user are rejected before reaching repository
+	testCases := []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodPost, path: "/issue"},
+		{method: http.MethodGet, path: "/issue"},
+		{method: http.MethodGet, path: "/issue/test-issue-id"},
+		{method: http.MethodPatch, path: "/issue/test-issue-id"},
+		{method: http.MethodPost, path: "/issue/test-issue-id/close"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.method+testCase.path, func(t *testing.T) {
+			httpRecorder := httptest.NewRecorder()
+			engine.ServeHTTP(httpRecorder, httptest.NewRequest(testCase.method, testCase.path, nil))
+			assert.Equal(t, http.StatusUnauthorized, httpRecorder.Code)
+		})
+	}
+}
+
+func TestRegisterV1CommentRouter(t *testing.T) {
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterV1CommentRouter(r, nil, nil, nil, nil)
+	}, []string{
+		"/",
+		"/",
+		"/:comment_id",
+		"/:comment_id",
+	})
+}
+
+func TestRegisterV1AICommentRouter(t *testing.T) {
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterV1AICommentRouter(r, nil, nil, nil, nil, nil)
+	}, []string{
+		"/",
+	})
+}
+
+func TestRegisterV1NotificationRouter(t *testing.T) {
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterV1NotificationRouter(r, nil, nil)
+	}, []string{
+		"/",
+		"/unread-count",
+		"/read-all",
+		"/:notification_id/read",
+	})
+}
+
+func TestRegisterV1AdminRouter(t *testing.T) {
+	utils.TestRouterRegister(t, func(r *gin.RouterGroup) {
+		RegisterV1AdminRouter(r, nil, nil, nil, nil, nil)
+	}, []string{
+		"/user",
+		"/user/:user_id/disable",
+		"/user/:user_id/enable",
+		"/user/:user_id/logout",
+		"/audit-log",
+	})
+}
@@ -1,11 +1,8 @@
 package config
 
 import (
-	"os"
-
-	"gopkg.in/yaml.v3"
-
 	coreAuth "github.com/STLeee/mediation-platform/backend/core/auth"
+	coreConfig "github.com/STLeee/mediation-platform/backend/core/config"
 )
 
 type Config struct {
@@ -14,14 +11,10 @@ type Config struct {
 
 var cfg *Config
 
+// LoadConfig loads config from the YAML file, with defaults, validation and overrides by MP_ environment variables
 func LoadConfig(path string) (*Config, error) {
 	loadedCfg := &Config{}
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	err = yaml.Unmarshal(data, loadedCfg)
-	if err != nil {
+	if err := coreConfig.Load(path, loadedCfg); err != nil {
 		return nil, err
 	}
 	cfg = loadedCfg
@@ -34,6 +34,21 @@ auth_service:
 	assert.Equal(t, loadedCfg, gotCfg)
 }
 
+func TestLoadConfig_Env(t *testing.T) {
+	t.Setenv("MP_AUTH_SERVICE_FIREBASE_PROJECT_ID", "mediation-platform-env")
+
+	// Create a temporary config file
+	tempFile, err := os.CreateTemp("", "test_config_*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+	tempFile.Close()
+
+	// Firebase config is created by environment variable
+	loadedCfg, err := LoadConfig(tempFile.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "mediation-platform-env", loadedCfg.AuthService.FirebaseAuthConfig.ProjectID)
+}
+
 func TestLoadConfig_FileNotFound(t *testing.T) {
 	// Load a non-existent config file
 	_, err := LoadConfig("non_existent_file.yaml")
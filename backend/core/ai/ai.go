@@ -0,0 +1,83 @@
+package ai
+
+import (
+	"context"
+	"strings"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+type CommentSuggesterName string
+
+const (
+	CommentSuggesterNameFake CommentSuggesterName = "fake"
+	CommentSuggesterNameHTTP CommentSuggesterName = "http"
+)
+
+// CommentSuggesterConfig struct for comment suggester configuration
+type CommentSuggesterConfig struct {
+	FakeCommentSuggesterConfig *FakeCommentSuggesterConfig `yaml:"fake"`
+	HTTPCommentSuggesterConfig *HTTPCommentSuggesterConfig `yaml:"http"`
+}
+
+type AIErrorType string
+
+const (
+	AIErrorTypeServerError   AIErrorType = "server_error"
+	AIErrorTypeConfigError   AIErrorType = "config_error"
+	AIErrorTypeProviderError AIErrorType = "provider_error"
+)
+
+var AIErrorDefaultMessages = map[AIErrorType]string{
+	AIErrorTypeServerError:   "server error",
+	AIErrorTypeConfigError:   "config error",
+	AIErrorTypeProviderError: "provider error",
+}
+
+// AIError struct for AI error
+type AIError struct {
+	ErrType AIErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e AIError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := AIErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e AIError) Unwrap() error {
+	return e.Err
+}
+
+// CommentSuggester interface for suggesting a neutral mediator comment on the issue
+type CommentSuggester interface {
+	GetName() CommentSuggesterName
+	SuggestComment(ctx context.Context, issue *model.Issue, comments []*model.Comment) (string, error)
+}
+
+// NewCommentSuggester creates a new comment suggester
+func NewCommentSuggester(cfg *CommentSuggesterConfig) (CommentSuggester, error) {
+	if cfg != nil {
+		if cfg.HTTPCommentSuggesterConfig != nil {
+			return NewHTTPCommentSuggester(cfg.HTTPCommentSuggesterConfig)
+		}
+		if cfg.FakeCommentSuggesterConfig != nil {
+			return NewFakeCommentSuggester(cfg.FakeCommentSuggesterConfig), nil
+		}
+	}
+	return nil, AIError{
+		ErrType: AIErrorTypeConfigError,
+		Message: "no comment suggester is configured",
+	}
+}
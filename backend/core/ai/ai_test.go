@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  AIErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "provider-error/no-message",
+			errType:  AIErrorTypeProviderError,
+			err:      fmt.Errorf("test error"),
+			expected: "provider error: test error",
+		},
+		{
+			name:     "config-error/with-message",
+			errType:  AIErrorTypeConfigError,
+			message:  "test message",
+			expected: "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ae := AIError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, ae.Error())
+			assert.Equal(t, testCase.err, ae.Unwrap())
+		})
+	}
+}
+
+func TestNewCommentSuggester(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cfg          *CommentSuggesterConfig
+		expectedName CommentSuggesterName
+		isErr        bool
+	}{
+		{
+			name: "fake",
+			cfg: &CommentSuggesterConfig{
+				FakeCommentSuggesterConfig: &FakeCommentSuggesterConfig{},
+			},
+			expectedName: CommentSuggesterNameFake,
+		},
+		{
+			name: "http",
+			cfg: &CommentSuggesterConfig{
+				HTTPCommentSuggesterConfig: &HTTPCommentSuggesterConfig{URL: "http://localhost:8000/suggest"},
+			},
+			expectedName: CommentSuggesterNameHTTP,
+		},
+		{
+			name: "http-without-url",
+			cfg: &CommentSuggesterConfig{
+				HTTPCommentSuggesterConfig: &HTTPCommentSuggesterConfig{},
+			},
+			isErr: true,
+		},
+		{
+			name:  "empty-config",
+			cfg:   &CommentSuggesterConfig{},
+			isErr: true,
+		},
+		{
+			name:  "nil-config",
+			cfg:   nil,
+			isErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			suggester, err := NewCommentSuggester(testCase.cfg)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &AIError{})
+				assert.Equal(t, AIErrorTypeConfigError, err.(AIError).ErrType)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedName, suggester.GetName())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// FakeCommentSuggesterConfig struct for fake comment suggester configuration
+type FakeCommentSuggesterConfig struct {
+	Prefix string `yaml:"prefix"`
+}
+
+// FakeCommentSuggester is a deterministic local comment suggester for testing
+type FakeCommentSuggester struct {
+	cfg *FakeCommentSuggesterConfig
+}
+
+// NewFakeCommentSuggester creates a new FakeCommentSuggester
+func NewFakeCommentSuggester(cfg *FakeCommentSuggesterConfig) *FakeCommentSuggester {
+	return &FakeCommentSuggester{
+		cfg: cfg,
+	}
+}
+
+// GetName returns the name of comment suggester
+func (suggester *FakeCommentSuggester) GetName() CommentSuggesterName {
+	return CommentSuggesterNameFake
+}
+
+// SuggestComment returns a comment built only from the issue and the thread, so the same input always gives the same comment
+func (suggester *FakeCommentSuggester) SuggestComment(ctx context.Context, issue *model.Issue, comments []*model.Comment) (string, error) {
+	// Count commenters of the thread
+	commenters := map[string]struct{}{}
+	for _, comment := range comments {
+		commenters[comment.AuthorID] = struct{}{}
+	}
+
+	return fmt.Sprintf(
+		"%sThank you all for sharing your views on %q. So far %d comment(s) from %d participant(s) have been shared. "+
+			"Let's each restate our main concern in one sentence, then look for the points we already agree on.",
+		suggester.cfg.Prefix, issue.Title, len(comments), len(commenters),
+	), nil
+}
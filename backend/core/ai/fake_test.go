@@ -0,0 +1,30 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+func TestFakeCommentSuggester(t *testing.T) {
+	issue := &model.Issue{Title: "test-title"}
+	comments := []*model.Comment{
+		{AuthorID: "test-user-1", Body: "test-comment-1"},
+		{AuthorID: "test-user-2", Body: "test-comment-2"},
+		{AuthorID: "test-user-1", Body: "test-comment-3"},
+	}
+
+	suggester := NewFakeCommentSuggester(&FakeCommentSuggesterConfig{Prefix: "[AI] "})
+	comment, err := suggester.SuggestComment(context.Background(), issue, comments)
+	assert.NoError(t, err)
+	assert.Equal(t, "[AI] Thank you all for sharing your views on \"test-title\". So far 3 comment(s) from 2 participant(s) have been shared. "+
+		"Let's each restate our main concern in one sentence, then look for the points we already agree on.", comment)
+
+	// Same input gives same comment
+	sameComment, err := suggester.SuggestComment(context.Background(), issue, comments)
+	assert.NoError(t, err)
+	assert.Equal(t, comment, sameComment)
+}
@@ -0,0 +1,186 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+const DefaultHTTPCommentSuggesterTimeout = 30 * time.Second
+
+// MaxHTTPCommentSuggesterResponseSize is the max size of provider response body in bytes
+const MaxHTTPCommentSuggesterResponseSize = 1 << 20
+
+// MaxSuggestedCommentLength is the max length of suggested comment in characters, same as comment body of API
+const MaxSuggestedCommentLength = 5000
+
+// HTTPCommentSuggesterConfig struct for HTTP comment suggester configuration
+type HTTPCommentSuggesterConfig struct {
+	URL     string        `yaml:"url"`
+	APIKey  string        `yaml:"api_key"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// HTTPCommentSuggester is a comment suggester which asks a remote provider over HTTP
+type HTTPCommentSuggester struct {
+	cfg    *HTTPCommentSuggesterConfig
+	client *http.Client
+}
+
+// suggestCommentRequest is the request body sent to the provider
+type suggestCommentRequest struct {
+	Issue    suggestCommentRequestIssue     `json:"issue"`
+	Comments []suggestCommentRequestComment `json:"comments"`
+}
+
+type suggestCommentRequestIssue struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Parties     []string `json:"parties"`
+}
+
+type suggestCommentRequestComment struct {
+	CommentID       string    `json:"comment_id"`
+	AuthorID        string    `json:"author_id"`
+	Body            string    `json:"body"`
+	ParentCommentID string    `json:"parent_comment_id,omitempty"`
+	IsAIGenerated   bool      `json:"is_ai_generated"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// suggestCommentResponse is the response body returned by the provider
+type suggestCommentResponse struct {
+	Comment string `json:"comment"`
+}
+
+// NewHTTPCommentSuggester creates a new HTTPCommentSuggester
+func NewHTTPCommentSuggester(cfg *HTTPCommentSuggesterConfig) (*HTTPCommentSuggester, error) {
+	if cfg.URL == "" {
+		return nil, AIError{
+			ErrType: AIErrorTypeConfigError,
+			Message: "comment suggester URL is required",
+		}
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHTTPCommentSuggesterTimeout
+	}
+	return &HTTPCommentSuggester{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// GetName returns the name of comment suggester
+func (suggester *HTTPCommentSuggester) GetName() CommentSuggesterName {
+	return CommentSuggesterNameHTTP
+}
+
+// SuggestComment posts the issue and the thread to the provider and returns the suggested comment
+func (suggester *HTTPCommentSuggester) SuggestComment(ctx context.Context, issue *model.Issue, comments []*model.Comment) (string, error) {
+	// Build request body
+	requestBody := suggestCommentRequest{
+		Issue: suggestCommentRequestIssue{
+			Title:       issue.Title,
+			Description: issue.Description,
+			Parties:     issue.Parties,
+		},
+		Comments: make([]suggestCommentRequestComment, 0, len(comments)),
+	}
+	for _, comment := range comments {
+		requestBody.Comments = append(requestBody.Comments, suggestCommentRequestComment{
+			CommentID:       comment.CommentID,
+			AuthorID:        comment.AuthorID,
+			Body:            comment.Body,
+			ParentCommentID: comment.ParentCommentID,
+			IsAIGenerated:   comment.IsAIGenerated,
+			CreatedAt:       comment.CreatedAt,
+		})
+	}
+	body, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", AIError{
+			ErrType: AIErrorTypeServerError,
+			Message: "failed to marshal request",
+			Err:     err,
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, suggester.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", AIError{
+			ErrType: AIErrorTypeServerError,
+			Message: "failed to create request",
+			Err:     err,
+		}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if suggester.cfg.APIKey != "" {
+		request.Header.Set("Authorization", "Bearer "+suggester.cfg.APIKey)
+	}
+
+	// Send request
+	response, err := suggester.client.Do(request)
+	if err != nil {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: "failed to request provider",
+			Err:     err,
+		}
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: fmt.Sprintf("provider responded with status code %d", response.StatusCode),
+		}
+	}
+
+	// Read response, body over the max size is not read
+	data, err := io.ReadAll(io.LimitReader(response.Body, MaxHTTPCommentSuggesterResponseSize+1))
+	if err != nil {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: "failed to read provider response",
+			Err:     err,
+		}
+	}
+	if len(data) > MaxHTTPCommentSuggesterResponseSize {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: "provider response is too large",
+		}
+	}
+
+	// Decode response
+	var responseBody suggestCommentResponse
+	if err := json.Unmarshal(data, &responseBody); err != nil {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: "failed to decode provider response",
+			Err:     err,
+		}
+	}
+	comment := strings.TrimSpace(responseBody.Comment)
+	if comment == "" {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: "provider returned an empty comment",
+		}
+	}
+	if utf8.RuneCountInString(comment) > MaxSuggestedCommentLength {
+		return "", AIError{
+			ErrType: AIErrorTypeProviderError,
+			Message: fmt.Sprintf("provider returned a comment longer than %d characters", MaxSuggestedCommentLength),
+		}
+	}
+	return comment, nil
+}
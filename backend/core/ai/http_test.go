@@ -0,0 +1,114 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+func TestHTTPCommentSuggester(t *testing.T) {
+	testCases := []struct {
+		name         string
+		apiKey       string
+		statusCode   int
+		responseBody string
+		expected     string
+		isErr        bool
+	}{
+		{
+			name:         "success",
+			statusCode:   http.StatusOK,
+			responseBody: `{"comment":"  test-suggested-comment  "}`,
+			expected:     "test-suggested-comment",
+		},
+		{
+			name:         "success/with-api-key",
+			apiKey:       "test-api-key",
+			statusCode:   http.StatusOK,
+			responseBody: `{"comment":"test-suggested-comment"}`,
+			expected:     "test-suggested-comment",
+		},
+		{
+			name:       "error-status-code",
+			statusCode: http.StatusServiceUnavailable,
+			isErr:      true,
+		},
+		{
+			name:         "invalid-response",
+			statusCode:   http.StatusOK,
+			responseBody: `not-json`,
+			isErr:        true,
+		},
+		{
+			name:         "empty-comment",
+			statusCode:   http.StatusOK,
+			responseBody: `{"comment":" "}`,
+			isErr:        true,
+		},
+		{
+			name:         "max-length-comment",
+			statusCode:   http.StatusOK,
+			responseBody: `{"comment":"` + strings.Repeat("字", MaxSuggestedCommentLength) + `"}`,
+			expected:     strings.Repeat("字", MaxSuggestedCommentLength),
+		},
+		{
+			name:         "too-long-comment",
+			statusCode:   http.StatusOK,
+			responseBody: `{"comment":"` + strings.Repeat("a", MaxSuggestedCommentLength+1) + `"}`,
+			isErr:        true,
+		},
+		{
+			name:         "too-large-response",
+			statusCode:   http.StatusOK,
+			responseBody: `{"comment":"test-suggested-comment","padding":"` + strings.Repeat("a", MaxHTTPCommentSuggesterResponseSize) + `"}`,
+			isErr:        true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				if testCase.apiKey != "" {
+					assert.Equal(t, "Bearer "+testCase.apiKey, r.Header.Get("Authorization"))
+				} else {
+					assert.Empty(t, r.Header.Get("Authorization"))
+				}
+
+				// Check request body
+				var requestBody suggestCommentRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&requestBody))
+				assert.Equal(t, "test-title", requestBody.Issue.Title)
+				assert.Len(t, requestBody.Comments, 1)
+				assert.Equal(t, "test-comment", requestBody.Comments[0].Body)
+
+				w.WriteHeader(testCase.statusCode)
+				w.Write([]byte(testCase.responseBody))
+			}))
+			defer server.Close()
+
+			suggester, err := NewHTTPCommentSuggester(&HTTPCommentSuggesterConfig{URL: server.URL, APIKey: testCase.apiKey})
+			if err != nil {
+				t.Fatal(err)
+			}
+			comment, err := suggester.SuggestComment(
+				context.Background(),
+				&model.Issue{Title: "test-title"},
+				[]*model.Comment{{AuthorID: "test-user-1", Body: "test-comment"}},
+			)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &AIError{})
+				assert.Equal(t, AIErrorTypeProviderError, err.(AIError).ErrType)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expected, comment)
+			}
+		})
+	}
+}
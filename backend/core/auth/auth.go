@@ -63,6 +63,8 @@ type BaseAuthService interface {
 	GetName() AuthServiceName
 	AuthenticateByToken(ctx context.Context, token string) (uid string, err error)
 	GetUserInfo(ctx context.Context, uid string) (user *model.User, err error)
+	SetUserDisabled(ctx context.Context, uid string, disabled bool) error
+	CheckHealth(ctx context.Context) error
 }
 
 // NewAuthService creates a new authentication service
@@ -8,6 +8,8 @@ import (
 	"firebase.google.com/go/v4/auth"
 	firebaseErrorutils "firebase.google.com/go/v4/errorutils"
 	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 )
 
@@ -78,6 +80,9 @@ func (firebaseAuth *FirebaseAuth) GetName() AuthServiceName {
 
 // AuthenticateByToken authenticates a user by token
 func (firebaseAuth *FirebaseAuth) AuthenticateByToken(ctx context.Context, token string) (uid string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "FirebaseAuth.AuthenticateByToken", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	verifiedToken, err := firebaseAuth.authClient.VerifyIDToken(ctx, token)
 	if err != nil {
 		if firebaseErrorutils.IsInvalidArgument(err) {
@@ -96,6 +101,9 @@ func (firebaseAuth *FirebaseAuth) AuthenticateByToken(ctx context.Context, token
 
 // GetUserInfo gets user info
 func (firebaseAuth *FirebaseAuth) GetUserInfo(ctx context.Context, uid string) (user *model.User, err error) {
+	ctx, span := tracing.StartSpan(ctx, "FirebaseAuth.GetUserInfo", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	userRecord, err := firebaseAuth.authClient.GetUser(ctx, uid)
 	if err != nil {
 		if firebaseErrorutils.IsNotFound(err) {
@@ -117,3 +125,35 @@ func (firebaseAuth *FirebaseAuth) GetUserInfo(ctx context.Context, uid string) (
 	}
 	return user, nil
 }
+
+// firebaseHealthCheckUID is a UID looked up by health check, it is not expected to exist
+const firebaseHealthCheckUID = "health-check"
+
+// CheckHealth checks firebase auth is reachable by looking up a user, user not found is healthy
+func (firebaseAuth *FirebaseAuth) CheckHealth(ctx context.Context) error {
+	_, err := firebaseAuth.authClient.GetUser(ctx, firebaseHealthCheckUID)
+	if err != nil && !firebaseErrorutils.IsNotFound(err) {
+		return AuthServiceError{
+			ErrType: AuthServiceErrorTypeServerError,
+			Message: "failed to check firebase auth",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// SetUserDisabled enables or disables a user, disabled user cannot sign in or refresh token
+func (firebaseAuth *FirebaseAuth) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	_, err := firebaseAuth.authClient.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).Disabled(disabled))
+	if err != nil {
+		if firebaseErrorutils.IsNotFound(err) {
+			return AuthServiceError{ErrType: AuthServiceErrorTypeUserNotFound}
+		}
+		return AuthServiceError{
+			ErrType: AuthServiceErrorTypeServerError,
+			Message: "failed to set user disabled",
+			Err:     err,
+		}
+	}
+	return nil
+}
@@ -143,3 +143,40 @@ func TestFirebase_GetUserInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestFirebase_CheckHealth(t *testing.T) {
+	assert.NoError(t, firebaseAuth.CheckHealth(context.Background()))
+
+	// Cancelled context fails the check
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := firebaseAuth.CheckHealth(ctx)
+	assert.ErrorAs(t, err, &AuthServiceError{})
+	assert.Equal(t, AuthServiceErrorTypeServerError, err.(AuthServiceError).ErrType)
+}
+
+func TestFirebase_SetUserDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	// Disable and enable user
+	uid := localUsers[1].FirebaseUID
+	err := firebaseAuth.SetUserDisabled(ctx, uid, true)
+	assert.NoError(t, err)
+	userInfo, err := firebaseAuth.GetUserInfo(ctx, uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, userInfo.Disabled)
+
+	err = firebaseAuth.SetUserDisabled(ctx, uid, false)
+	assert.NoError(t, err)
+	userInfo, err = firebaseAuth.GetUserInfo(ctx, uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, userInfo.Disabled)
+
+	// User not found
+	err = firebaseAuth.SetUserDisabled(ctx, "invalid-uid", true)
+	assert.Equal(t, AuthServiceError{ErrType: AuthServiceErrorTypeUserNotFound}, err)
+}
@@ -0,0 +1,177 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// Resource is a kind of resource to be authorized
+type Resource string
+
+const (
+	ResourceAll          Resource = "*"
+	ResourceUser         Resource = "user"
+	ResourceIssue        Resource = "issue"
+	ResourceComment      Resource = "comment"
+	ResourceAIComment    Resource = "ai_comment"
+	ResourceNotification Resource = "notification"
+	ResourceAdmin        Resource = "admin"
+)
+
+// Action is an action on resource to be authorized
+type Action string
+
+const (
+	ActionAll    Action = "*"
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	// ActionModerate allows acting on resources which the user does not participate in
+	ActionModerate Action = "moderate"
+)
+
+// Effect is the effect of a rule
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// RoleAll matches all roles in rule
+const RoleAll model.UserRole = "*"
+
+// Rule struct for a rule of policy, "*" matches all roles, resources or actions
+type Rule struct {
+	Role     model.UserRole `yaml:"role"`
+	Resource Resource       `yaml:"resource"`
+	Action   Action         `yaml:"action"`
+	Effect   Effect         `yaml:"effect"`
+}
+
+// matches checks if the rule matches role, resource and action
+func (rule *Rule) matches(role model.UserRole, resource Resource, action Action) bool {
+	return (rule.Role == RoleAll || rule.Role == role) &&
+		(rule.Resource == ResourceAll || rule.Resource == resource) &&
+		(rule.Action == ActionAll || rule.Action == action)
+}
+
+// PolicyConfig struct for policy configuration, default rules are used when no rule is configured
+type PolicyConfig struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// DefaultRules are the default rules of policy
+var DefaultRules = []Rule{
+	// Admin can do everything
+	{Role: model.UserRoleAdmin, Resource: ResourceAll, Action: ActionAll, Effect: EffectAllow},
+
+	// Mediator can act on all issues without being a participant
+	{Role: model.UserRoleMediator, Resource: ResourceUser, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleMediator, Resource: ResourceIssue, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleMediator, Resource: ResourceComment, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleMediator, Resource: ResourceAIComment, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleMediator, Resource: ResourceNotification, Action: ActionAll, Effect: EffectAllow},
+
+	// User can act on their own resources
+	{Role: model.UserRoleUser, Resource: ResourceUser, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleUser, Resource: ResourceIssue, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleUser, Resource: ResourceComment, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleUser, Resource: ResourceAIComment, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleUser, Resource: ResourceNotification, Action: ActionAll, Effect: EffectAllow},
+	{Role: model.UserRoleUser, Resource: ResourceAll, Action: ActionModerate, Effect: EffectDeny},
+}
+
+type AuthzErrorType string
+
+const (
+	AuthzErrorTypeConfigError AuthzErrorType = "config_error"
+)
+
+var AuthzErrorDefaultMessages = map[AuthzErrorType]string{
+	AuthzErrorTypeConfigError: "config error",
+}
+
+// AuthzError struct for authorization error
+type AuthzError struct {
+	ErrType AuthzErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e AuthzError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := AuthzErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e AuthzError) Unwrap() error {
+	return e.Err
+}
+
+// Policy is a policy engine mapping role, resource and action to allow or deny, deny takes precedence over allow
+// and nothing is allowed without a matched rule
+type Policy struct {
+	rules []Rule
+}
+
+// NewPolicy creates a new policy
+func NewPolicy(cfg *PolicyConfig) (*Policy, error) {
+	rules := DefaultRules
+	if cfg != nil && len(cfg.Rules) > 0 {
+		rules = cfg.Rules
+	}
+	for i, rule := range rules {
+		if rule.Role == "" || rule.Resource == "" || rule.Action == "" {
+			return nil, AuthzError{
+				ErrType: AuthzErrorTypeConfigError,
+				Message: fmt.Sprintf("rule %d: role, resource and action are required", i),
+			}
+		}
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, AuthzError{
+				ErrType: AuthzErrorTypeConfigError,
+				Message: fmt.Sprintf("rule %d: unknown effect %q", i, rule.Effect),
+			}
+		}
+	}
+	return &Policy{rules: rules}, nil
+}
+
+// IsAllowed checks if the role is allowed to do the action on the resource, nil policy allows nothing
+func (policy *Policy) IsAllowed(role model.UserRole, resource Resource, action Action) bool {
+	if policy == nil {
+		return false
+	}
+	allowed := false
+	for _, rule := range policy.rules {
+		if !rule.matches(role, resource, action) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return false
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// IsUserAllowed checks if the user is allowed to do the action on the resource by role of the user
+func (policy *Policy) IsUserAllowed(user *model.User, resource Resource, action Action) bool {
+	if user == nil {
+		return false
+	}
+	return policy.IsAllowed(user.GetRole(), resource, action)
+}
@@ -0,0 +1,177 @@
+package authz
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+func TestAuthzError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      AuthzError
+		expected string
+	}{
+		{
+			name:     "default-message",
+			err:      AuthzError{ErrType: AuthzErrorTypeConfigError},
+			expected: "config error",
+		},
+		{
+			name:     "custom-message-with-error",
+			err:      AuthzError{ErrType: AuthzErrorTypeConfigError, Message: "test message", Err: fmt.Errorf("test error")},
+			expected: "test message: test error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.err.Error())
+			assert.Equal(t, testCase.err.Err, testCase.err.Unwrap())
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     *PolicyConfig
+		isError bool
+	}{
+		{
+			name: "default-rules",
+			cfg:  nil,
+		},
+		{
+			name: "custom-rules",
+			cfg: &PolicyConfig{Rules: []Rule{
+				{Role: RoleAll, Resource: ResourceIssue, Action: ActionRead, Effect: EffectAllow},
+			}},
+		},
+		{
+			name: "missing-field",
+			cfg: &PolicyConfig{Rules: []Rule{
+				{Role: model.UserRoleUser, Action: ActionRead, Effect: EffectAllow},
+			}},
+			isError: true,
+		},
+		{
+			name: "unknown-effect",
+			cfg: &PolicyConfig{Rules: []Rule{
+				{Role: model.UserRoleUser, Resource: ResourceIssue, Action: ActionRead, Effect: "maybe"},
+			}},
+			isError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := NewPolicy(testCase.cfg)
+			if !testCase.isError {
+				assert.NoError(t, err)
+				assert.NotNil(t, policy)
+			} else {
+				assert.Nil(t, policy)
+				assert.Equal(t, AuthzErrorTypeConfigError, err.(AuthzError).ErrType)
+			}
+		})
+	}
+}
+
+func TestPolicy_IsAllowed(t *testing.T) {
+	policy, err := NewPolicy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name     string
+		role     model.UserRole
+		resource Resource
+		action   Action
+		expected bool
+	}{
+		{
+			name:     "user/create-issue",
+			role:     model.UserRoleUser,
+			resource: ResourceIssue,
+			action:   ActionCreate,
+			expected: true,
+		},
+		{
+			name:     "user/moderate-issue",
+			role:     model.UserRoleUser,
+			resource: ResourceIssue,
+			action:   ActionModerate,
+			expected: false,
+		},
+		{
+			name:     "user/admin",
+			role:     model.UserRoleUser,
+			resource: ResourceAdmin,
+			action:   ActionRead,
+			expected: false,
+		},
+		{
+			name:     "mediator/moderate-issue",
+			role:     model.UserRoleMediator,
+			resource: ResourceIssue,
+			action:   ActionModerate,
+			expected: true,
+		},
+		{
+			name:     "mediator/admin",
+			role:     model.UserRoleMediator,
+			resource: ResourceAdmin,
+			action:   ActionRead,
+			expected: false,
+		},
+		{
+			name:     "admin/admin",
+			role:     model.UserRoleAdmin,
+			resource: ResourceAdmin,
+			action:   ActionUpdate,
+			expected: true,
+		},
+		{
+			name:     "unknown-role",
+			role:     "guest",
+			resource: ResourceIssue,
+			action:   ActionRead,
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, policy.IsAllowed(testCase.role, testCase.resource, testCase.action))
+		})
+	}
+}
+
+func TestPolicy_IsAllowed_DenyOverridesAllow(t *testing.T) {
+	policy, err := NewPolicy(&PolicyConfig{Rules: []Rule{
+		{Role: RoleAll, Resource: ResourceAll, Action: ActionAll, Effect: EffectAllow},
+		{Role: model.UserRoleUser, Resource: ResourceComment, Action: ActionDelete, Effect: EffectDeny},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, policy.IsAllowed(model.UserRoleUser, ResourceComment, ActionCreate))
+	assert.False(t, policy.IsAllowed(model.UserRoleUser, ResourceComment, ActionDelete))
+	assert.True(t, policy.IsAllowed(model.UserRoleMediator, ResourceComment, ActionDelete))
+
+	// Nil policy allows nothing
+	var nilPolicy *Policy
+	assert.False(t, nilPolicy.IsAllowed(model.UserRoleAdmin, ResourceAdmin, ActionRead))
+}
+
+func TestPolicy_IsUserAllowed(t *testing.T) {
+	policy, err := NewPolicy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, policy.IsUserAllowed(&model.User{}, ResourceIssue, ActionRead))
+	assert.False(t, policy.IsUserAllowed(&model.User{}, ResourceAdmin, ActionRead))
+	assert.True(t, policy.IsUserAllowed(&model.User{Role: model.UserRoleAdmin}, ResourceAdmin, ActionRead))
+	assert.False(t, policy.IsUserAllowed(nil, ResourceIssue, ActionRead))
+}
@@ -7,11 +7,13 @@ type CacheErrorType string
 const (
 	CacheErrorTypeServerError    CacheErrorType = "server_error"
 	CacheErrorTypeOperationError CacheErrorType = "operation_error"
+	CacheErrorTypeConfigError    CacheErrorType = "config_error"
 )
 
 var CacheErrorDefaultMessages = map[CacheErrorType]string{
 	CacheErrorTypeServerError:    "server error",
 	CacheErrorTypeOperationError: "operation error",
+	CacheErrorTypeConfigError:    "config error",
 }
 
 // CacheError struct for database error
@@ -50,6 +50,13 @@ func TestCacheError(t *testing.T) {
 			err:      fmt.Errorf("test error"),
 			expected: "operation error: test error",
 		},
+		{
+			name:     "config-error",
+			errType:  CacheErrorTypeConfigError,
+			message:  "",
+			err:      nil,
+			expected: "config error",
+		},
 	}
 
 	for _, testCase := range testCases {
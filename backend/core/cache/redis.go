@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/STLeee/mediation-platform/backend/core/metrics"
 )
 
 const (
@@ -39,7 +41,8 @@ var LocalRedisCacheConfig = &RedisCacheConfig{
 // RedisCache is a cache implementation using Redis
 type RedisCache struct {
 	redis.Client
-	cfg *RedisCacheConfig
+	cfg         *RedisCacheConfig
+	metricsHook *metricsHook
 }
 
 // NewRedisCache creates a new RedisCache instance
@@ -72,10 +75,14 @@ func NewRedisCache(ctx context.Context, cfg *RedisCacheConfig) (*RedisCache, err
 		WriteTimeout:    cfg.WriteTimeout,
 	}
 
-	// Create connection
+	// Create connection, hooks are added before the client is copied, since commands are processed by the original
+	client := redis.NewClient(opt)
+	hook := &metricsHook{}
+	client.AddHook(hook)
 	redisCache := &RedisCache{
-		Client: *redis.NewClient(opt),
-		cfg:    cfg,
+		Client:      *client,
+		cfg:         cfg,
+		metricsHook: hook,
 	}
 
 	// Ping
@@ -91,6 +98,61 @@ func NewRedisCache(ctx context.Context, cfg *RedisCacheConfig) (*RedisCache, err
 	return redisCache, nil
 }
 
+// CheckHealth pings Redis
+func (redisCache *RedisCache) CheckHealth(ctx context.Context) error {
+	if err := redisCache.Ping(ctx).Err(); err != nil {
+		return CacheError{
+			ErrType: CacheErrorTypeServerError,
+			Message: "failed to ping Redis",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// SetMetrics sets metrics which latency and errors of commands are observed to, it should be called before use
+func (redisCache *RedisCache) SetMetrics(metrics *metrics.Metrics) {
+	redisCache.metricsHook.metrics = metrics
+}
+
+// metricsHook is a Redis hook observing commands to metrics, missing key is not an error
+type metricsHook struct {
+	metrics *metrics.Metrics
+}
+
+// DialHook returns the next hook
+func (hook *metricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook observes the command
+func (hook *metricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		hook.metrics.ObserveRedisCommand(cmd.Name(), time.Since(start), ignoreNil(err))
+		return err
+	}
+}
+
+// ProcessPipelineHook observes the pipeline as one command
+func (hook *metricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		hook.metrics.ObserveRedisCommand("pipeline", time.Since(start), ignoreNil(err))
+		return err
+	}
+}
+
+// ignoreNil returns nil for the missing key error
+func ignoreNil(err error) error {
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
 // Close closes the Redis connection
 func (redisCache *RedisCache) Close() error {
 	err := redisCache.Client.Close()
@@ -2,10 +2,16 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/metrics"
 )
 
 var redisCache *RedisCache
@@ -41,6 +47,20 @@ func TestNewRedisCacheFailed(t *testing.T) {
 	assert.NotNil(t, err.(CacheError).Err)
 }
 
+func TestRedisCache_CheckHealth(t *testing.T) {
+	cache, err := NewRedisCache(context.Background(), LocalRedisCacheConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, cache.CheckHealth(context.Background()))
+
+	// Closed client is unhealthy
+	cache.Close()
+	err = cache.CheckHealth(context.Background())
+	assert.ErrorAs(t, err, &CacheError{})
+	assert.Equal(t, CacheErrorTypeServerError, err.(CacheError).ErrType)
+}
+
 func TestRedisCache_Close(t *testing.T) {
 	err := redisCache.Close()
 	assert.Nil(t, err)
@@ -50,3 +70,76 @@ func TestRedisCache_Close(t *testing.T) {
 	assert.Equal(t, "failed to close Redis connection", err.(CacheError).Message)
 	assert.NotNil(t, err.(CacheError).Err)
 }
+
+func TestMetricsHook(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		err                  error
+		expectedErrorsSeries int
+	}{
+		{
+			name:                 "success",
+			err:                  nil,
+			expectedErrorsSeries: 0,
+		},
+		{
+			name:                 "missing-key",
+			err:                  redis.Nil,
+			expectedErrorsSeries: 0,
+		},
+		{
+			name:                 "failed",
+			err:                  errors.New("test error"),
+			expectedErrorsSeries: 2,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			m, err := metrics.NewMetrics(registry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hook := &metricsHook{metrics: m}
+			ctx := context.Background()
+
+			process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+				return testCase.err
+			})
+			assert.Equal(t, testCase.err, process(ctx, redis.NewStringCmd(ctx, "get", "test-key")))
+			pipeline := hook.ProcessPipelineHook(func(ctx context.Context, cmds []redis.Cmder) error {
+				return testCase.err
+			})
+			assert.Equal(t, testCase.err, pipeline(ctx, []redis.Cmder{redis.NewStringCmd(ctx, "get", "test-key")}))
+
+			// Command and pipeline are observed
+			count, err := testutil.GatherAndCount(registry, "mediation_platform_redis_command_duration_seconds")
+			assert.NoError(t, err)
+			assert.Equal(t, 2, count)
+			count, err = testutil.GatherAndCount(registry, "mediation_platform_redis_command_errors_total")
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedErrorsSeries, count)
+		})
+	}
+}
+
+func TestRedisCache_SetMetrics(t *testing.T) {
+	ctx := context.Background()
+	redisCache, err := NewRedisCache(ctx, LocalRedisCacheConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer redisCache.Close()
+
+	registry := prometheus.NewRegistry()
+	m, err := metrics.NewMetrics(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redisCache.SetMetrics(m)
+
+	redisCache.Get(ctx, "test-metrics-key")
+	count, err := testutil.GatherAndCount(registry, "mediation_platform_redis_command_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of environment variables overriding config fields
+const EnvPrefix = "MP"
+
+type ConfigErrorType string
+
+const (
+	ConfigErrorTypeFileError          ConfigErrorType = "file_error"
+	ConfigErrorTypeParseError         ConfigErrorType = "parse_error"
+	ConfigErrorTypeInterpolationError ConfigErrorType = "interpolation_error"
+	ConfigErrorTypeDefaultError       ConfigErrorType = "default_error"
+	ConfigErrorTypeEnvError           ConfigErrorType = "env_error"
+	ConfigErrorTypeValidationError    ConfigErrorType = "validation_error"
+)
+
+var ConfigErrorDefaultMessages = map[ConfigErrorType]string{
+	ConfigErrorTypeFileError:          "failed to read config file",
+	ConfigErrorTypeParseError:         "failed to parse config file",
+	ConfigErrorTypeInterpolationError: "failed to interpolate environment variables",
+	ConfigErrorTypeDefaultError:       "invalid default value",
+	ConfigErrorTypeEnvError:           "invalid environment variable",
+	ConfigErrorTypeValidationError:    "invalid config",
+}
+
+// ConfigError struct for config error
+type ConfigError struct {
+	ErrType ConfigErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e ConfigError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := ConfigErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Load loads the YAML file at path into cfg, which is a pointer to struct. ${ENV} in string values is interpolated,
+// then `default` tags are applied to fields whose keys are missing or null, environment variables like MP_MONGODB_URI
+// override fields, and `validate` tags are enforced with all failures reported together
+func Load(path string, cfg any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ConfigError{ErrType: ConfigErrorTypeFileError, Err: err}
+	}
+	return LoadBytes(data, cfg)
+}
+
+// LoadBytes loads YAML data into cfg like Load
+func LoadBytes(data []byte, cfg any) error {
+	// Parse into node tree first, so interpolated values are not parsed as YAML and keys in the file are known
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return ConfigError{ErrType: ConfigErrorTypeParseError, Err: err}
+	}
+	if err := interpolate(&document, os.LookupEnv); err != nil {
+		return err
+	}
+	var root *yaml.Node
+	if len(document.Content) > 0 {
+		root = document.Content[0]
+		if err := root.Decode(cfg); err != nil {
+			return ConfigError{ErrType: ConfigErrorTypeParseError, Err: err}
+		}
+	}
+
+	if err := applyDefaults(reflect.ValueOf(cfg).Elem(), root); err != nil {
+		return err
+	}
+	if _, err := applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, os.LookupEnv); err != nil {
+		return err
+	}
+	return Validate(cfg)
+}
+
+// yamlName returns the YAML key of the field, and if the field is inlined, empty name is skipped by YAML
+func yamlName(field reflect.StructField) (name string, inline bool) {
+	tag := field.Tag.Get("yaml")
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", false
+	}
+	if strings.Contains(options, "inline") {
+		return "", true
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, false
+}
+
+// mappingValue returns the value node of the key in the mapping node, it returns nil if the node is not a mapping, or
+// the key is missing or null
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node == nil || node.Kind != yaml.MappingNode || key == "" {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		value := node.Content[i+1]
+		if value.Kind == yaml.AliasNode {
+			value = value.Alias
+		}
+		if value.Kind == yaml.ScalarNode && value.ShortTag() == "!!null" {
+			return nil
+		}
+		return value
+	}
+	return nil
+}
+
+// applyDefaults sets values of `default` tags to zero fields whose keys are missing or null in the mapping node, so
+// explicit zero values like 0s are kept. Nested structs and non-nil pointers to struct are walked, nil pointers are
+// kept nil, and nil node means the whole struct is missing
+func applyDefaults(v reflect.Value, node *yaml.Node) error {
+	for i := 0; i < v.NumField(); i++ {
+		field, structField := v.Field(i), v.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		fieldNode := node
+		if name, inline := yamlName(structField); !inline {
+			fieldNode = mappingValue(node, name)
+		}
+		if defaultValue, ok := structField.Tag.Lookup("default"); ok {
+			if fieldNode == nil && field.IsZero() {
+				if err := setValue(field, defaultValue); err != nil {
+					return ConfigError{ErrType: ConfigErrorTypeDefaultError, Message: "invalid default value of " + structField.Name, Err: err}
+				}
+			}
+			continue
+		}
+		switch {
+		case field.Kind() == reflect.Struct:
+			if err := applyDefaults(field, fieldNode); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct && !field.IsNil():
+			if err := applyDefaults(field.Elem(), fieldNode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// durationType is the type of time.Duration, it is parsed from string like 10s
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue sets the field from string, slice of string is comma separated
+func setValue(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+		items := reflect.MakeSlice(field.Type(), 0, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = reflect.Append(items, reflect.ValueOf(item).Convert(field.Type().Elem()))
+			}
+		}
+		field.Set(items)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type testServerConfig struct {
+	Host    string        `yaml:"host" default:"localhost"`
+	Port    int           `yaml:"port" default:"8080" validate:"min=1,max=65535"`
+	Mode    string        `yaml:"mode" default:"release" validate:"oneof=debug release"`
+	Timeout time.Duration `yaml:"timeout" default:"10s"`
+}
+
+type testDBConfig struct {
+	URI      string `yaml:"uri" validate:"required"`
+	Password string `yaml:"password"`
+}
+
+type testConfig struct {
+	Server  testServerConfig  `yaml:"server"`
+	DB      *testDBConfig     `yaml:"db"`
+	Tags    []string          `yaml:"tags"`
+	Labels  map[string]string `yaml:"labels"`
+	Ignored string            `yaml:"-"`
+}
+
+func TestConfigError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      ConfigError
+		expected string
+	}{
+		{
+			name:     "default-message",
+			err:      ConfigError{ErrType: ConfigErrorTypeParseError, Err: os.ErrInvalid},
+			expected: "failed to parse config file: invalid argument",
+		},
+		{
+			name:     "with-message",
+			err:      ConfigError{ErrType: ConfigErrorTypeEnvError, Message: "invalid environment variable MP_PORT"},
+			expected: "invalid environment variable MP_PORT",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.err.Error())
+			assert.Equal(t, testCase.err.Err, testCase.err.Unwrap())
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("TEST_DB_PASSWORD", "p@ss$word #1")
+	t.Setenv("MP_SERVER_PORT", "9090")
+	t.Setenv("MP_TAGS", "a, b")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+server:
+  mode: debug
+  # Disable timeout by ${TEST_NOT_SET_TIMEOUT}
+  timeout: 0s
+db:
+  uri: mongodb://localhost:27017
+  password: ${TEST_DB_PASSWORD}
+labels:
+  team: ${TEST_TEAM:-platform}
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &testConfig{}
+	assert.NoError(t, Load(path, cfg))
+	assert.Equal(t, &testConfig{
+		Server: testServerConfig{
+			Host: "localhost",
+			Port: 9090,
+			Mode: "debug",
+		},
+		DB: &testDBConfig{
+			URI:      "mongodb://localhost:27017",
+			Password: "p@ss$word #1",
+		},
+		Tags:   []string{"a", "b"},
+		Labels: map[string]string{"team": "platform"},
+	}, cfg)
+}
+
+func TestLoad_Error(t *testing.T) {
+	testCases := []struct {
+		name            string
+		data            string
+		env             map[string]string
+		expectedErrType ConfigErrorType
+	}{
+		{
+			name:            "parse-error",
+			data:            "server: [",
+			expectedErrType: ConfigErrorTypeParseError,
+		},
+		{
+			name:            "interpolation-error",
+			data:            "db:\n  uri: ${TEST_NOT_SET_URI}",
+			expectedErrType: ConfigErrorTypeInterpolationError,
+		},
+		{
+			name:            "env-error",
+			data:            "",
+			env:             map[string]string{"MP_SERVER_PORT": "not-a-number"},
+			expectedErrType: ConfigErrorTypeEnvError,
+		},
+		{
+			name:            "validation-error",
+			data:            "server:\n  mode: verbose",
+			expectedErrType: ConfigErrorTypeValidationError,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+			err := LoadBytes([]byte(testCase.data), &testConfig{})
+			assert.ErrorAs(t, err, &ConfigError{})
+			assert.Equal(t, testCase.expectedErrType, err.(ConfigError).ErrType)
+		})
+	}
+
+	// File not found
+	err := Load(filepath.Join(t.TempDir(), "not-found.yaml"), &testConfig{})
+	assert.ErrorAs(t, err, &ConfigError{})
+	assert.Equal(t, ConfigErrorTypeFileError, err.(ConfigError).ErrType)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		cfg      *testConfig
+		expected testServerConfig
+	}{
+		{
+			name:     "no-node",
+			cfg:      &testConfig{Server: testServerConfig{Port: 9090}},
+			expected: testServerConfig{Host: "localhost", Port: 9090, Mode: "release", Timeout: 10 * time.Second},
+		},
+		{
+			name:     "missing-keys",
+			data:     "server:\n  port: 9090",
+			expected: testServerConfig{Host: "localhost", Port: 9090, Mode: "release", Timeout: 10 * time.Second},
+		},
+		{
+			name:     "explicit-zero",
+			data:     "server:\n  host: ''\n  timeout: 0s",
+			expected: testServerConfig{Port: 8080, Mode: "release"},
+		},
+		{
+			name:     "null",
+			data:     "server:\n  host:\n  timeout: ~",
+			expected: testServerConfig{Host: "localhost", Port: 8080, Mode: "release", Timeout: 10 * time.Second},
+		},
+		{
+			name:     "alias",
+			data:     "base: &base\n  timeout: 0s\nserver: *base",
+			expected: testServerConfig{Host: "localhost", Port: 8080, Mode: "release"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := testCase.cfg
+			if cfg == nil {
+				cfg = &testConfig{}
+			}
+			var root *yaml.Node
+			if testCase.data != "" {
+				var document yaml.Node
+				if err := yaml.Unmarshal([]byte(testCase.data), &document); err != nil {
+					t.Fatal(err)
+				}
+				if err := document.Decode(cfg); err != nil {
+					t.Fatal(err)
+				}
+				root = document.Content[0]
+			}
+
+			// Nil pointer is not created
+			assert.NoError(t, applyDefaults(reflect.ValueOf(cfg).Elem(), root))
+			assert.Equal(t, testCase.expected, cfg.Server)
+			assert.Nil(t, cfg.DB)
+		})
+	}
+
+	// Invalid default value
+	invalid := &struct {
+		Port int `default:"not-a-number"`
+	}{}
+	err := applyDefaults(reflect.ValueOf(invalid).Elem(), nil)
+	assert.ErrorAs(t, err, &ConfigError{})
+	assert.Equal(t, ConfigErrorTypeDefaultError, err.(ConfigError).ErrType)
+}
+
+func TestSetValue(t *testing.T) {
+	type namedString string
+	testCases := []struct {
+		name     string
+		target   any
+		value    string
+		expected any
+		isErr    bool
+	}{
+		{name: "string", target: new(string), value: "test", expected: "test"},
+		{name: "named-string", target: new(namedString), value: "test", expected: namedString("test")},
+		{name: "bool", target: new(bool), value: "true", expected: true},
+		{name: "int", target: new(int), value: "-1", expected: -1},
+		{name: "uint", target: new(uint64), value: "1", expected: uint64(1)},
+		{name: "float", target: new(float64), value: "0.5", expected: 0.5},
+		{name: "duration", target: new(time.Duration), value: "1m", expected: time.Minute},
+		{name: "string-slice", target: new([]string), value: "a,,b", expected: []string{"a", "b"}},
+		{name: "invalid-int", target: new(int), value: "a", isErr: true},
+		{name: "invalid-duration", target: new(time.Duration), value: "1", isErr: true},
+		{name: "unsupported-slice", target: new([]int), value: "1", isErr: true},
+		{name: "unsupported-map", target: new(map[string]string), value: "a", isErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := setValue(reflect.ValueOf(testCase.target).Elem(), testCase.value)
+			if testCase.isErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, reflect.ValueOf(testCase.target).Elem().Interface())
+		})
+	}
+}
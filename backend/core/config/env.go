@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolationRegexp matches ${NAME} and ${NAME:-default}, $NAME is not matched, so values like passwords may
+// contain $
+var interpolationRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// interpolate replaces ${NAME} in string scalars of the node tree with the environment variable, ${NAME:-default}
+// falls back to the default when the variable is unset or empty, unset variables without default are reported
+// together. Values are not parsed as YAML, and comments are not interpolated
+func interpolate(node *yaml.Node, lookupEnv func(string) (string, bool)) error {
+	missing := []string{}
+	interpolateNode(node, lookupEnv, &missing)
+	if len(missing) > 0 {
+		return ConfigError{
+			ErrType: ConfigErrorTypeInterpolationError,
+			Err:     fmt.Errorf("environment variables are not set: %s", strings.Join(missing, ", ")),
+		}
+	}
+	return nil
+}
+
+// interpolateNode interpolates string scalars of the node and its descendants, unset variables are appended to missing
+func interpolateNode(node *yaml.Node, lookupEnv func(string) (string, bool), missing *[]string) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+		value := interpolationRegexp.ReplaceAllStringFunc(node.Value, func(match string) string {
+			groups := interpolationRegexp.FindStringSubmatch(match)
+			name, hasDefault := groups[1], strings.Contains(match, ":-")
+			if value, ok := lookupEnv(name); ok && (value != "" || !hasDefault) {
+				return value
+			}
+			if hasDefault {
+				return groups[2]
+			}
+			*missing = append(*missing, name)
+			return match
+		})
+		if value != node.Value {
+			node.Value = value
+			// Type of plain scalar is resolved from the interpolated value, like ${PORT} into int, quoted scalar is
+			// kept string
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 && node.Tag == "!!str" {
+				node.Tag = ""
+			}
+		}
+	}
+	for _, child := range node.Content {
+		interpolateNode(child, lookupEnv, missing)
+	}
+}
+
+// applyEnv overrides fields by environment variables named by prefix and upper-cased YAML keys joined by _, like
+// MP_REDIS_PASSWORD. Nil pointer to struct is created only if any field of it is overridden, maps are not overridden
+func applyEnv(v reflect.Value, prefix string, lookupEnv func(string) (string, bool)) (bool, error) {
+	overridden := false
+	for i := 0; i < v.NumField(); i++ {
+		field, structField := v.Field(i), v.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		name, inline := yamlName(structField)
+		if name == "" && !inline {
+			continue
+		}
+		key := prefix
+		if !inline {
+			key = prefix + "_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		}
+
+		switch {
+		case field.Type() == durationType:
+		case field.Kind() == reflect.Struct:
+			ok, err := applyEnv(field, key, lookupEnv)
+			if err != nil {
+				return false, err
+			}
+			overridden = overridden || ok
+			continue
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct:
+			target := field
+			if field.IsNil() {
+				target = reflect.New(field.Type().Elem())
+			}
+			ok, err := applyEnv(target.Elem(), key, lookupEnv)
+			if err != nil {
+				return false, err
+			}
+			if ok && field.IsNil() {
+				field.Set(target)
+			}
+			overridden = overridden || ok
+			continue
+		case field.Kind() == reflect.Map || field.Kind() == reflect.Interface:
+			continue
+		}
+
+		value, ok := lookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setValue(field, value); err != nil {
+			return false, ConfigError{ErrType: ConfigErrorTypeEnvError, Message: "invalid environment variable " + key, Err: err}
+		}
+		overridden = true
+	}
+	return overridden, nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// mapLookupEnv returns a lookup of environment variables in the map
+func mapLookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{"USER": "admin", "EMPTY": "", "PORT": "9090", "PASSWORD": "p@ss: #word", "ALIAS": "*alias"}
+	testCases := []struct {
+		name     string
+		data     string
+		expected map[string]any
+		isErr    bool
+	}{
+		{name: "variable", data: "user: ${USER}", expected: map[string]any{"user": "admin"}},
+		{name: "default-unused", data: "user: ${USER:-guest}", expected: map[string]any{"user": "admin"}},
+		{name: "default-unset", data: "user: ${UNSET:-guest}", expected: map[string]any{"user": "guest"}},
+		{name: "default-empty", data: "user: ${EMPTY:-guest}", expected: map[string]any{"user": "guest"}},
+		{name: "empty", data: "user: ${EMPTY}", expected: map[string]any{"user": nil}},
+		{name: "in-string", data: "uri: redis://${USER}@localhost", expected: map[string]any{"uri": "redis://admin@localhost"}},
+		{name: "dollar-without-braces", data: "password: pa$$word$USER", expected: map[string]any{"password": "pa$$word$USER"}},
+		{name: "plain-resolved", data: "port: ${PORT}", expected: map[string]any{"port": 9090}},
+		{name: "quoted-kept-string", data: `port: "${PORT}"`, expected: map[string]any{"port": "9090"}},
+		{name: "value-not-parsed", data: "password: ${PASSWORD}\nalias: ${ALIAS}", expected: map[string]any{"password": "p@ss: #word", "alias": "*alias"}},
+		{name: "comment-not-interpolated", data: "# user: ${UNSET}\nuser: ${USER} # ${UNSET}", expected: map[string]any{"user": "admin"}},
+		{name: "nested", data: "db:\n  users:\n    - ${USER}", expected: map[string]any{"db": map[string]any{"users": []any{"admin"}}}},
+		{name: "unset", data: "a: ${UNSET_A}\nb:\n  - ${UNSET_B}", isErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(testCase.data), &node); err != nil {
+				t.Fatal(err)
+			}
+			err := interpolate(&node, mapLookupEnv(env))
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &ConfigError{})
+				assert.ErrorContains(t, err, "UNSET_A, UNSET_B")
+				return
+			}
+			assert.NoError(t, err)
+			data := map[string]any{}
+			assert.NoError(t, node.Decode(&data))
+			assert.Equal(t, testCase.expected, data)
+		})
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	type InlineConfig struct {
+		Name string `yaml:"name"`
+	}
+	type nestedConfig struct {
+		InlineConfig `yaml:",inline"`
+		DB           *testDBConfig `yaml:"db"`
+		Cache        *testDBConfig `yaml:"cache"`
+		Server       testServerConfig
+		Labels       map[string]string `yaml:"labels"`
+		Ignored      string            `yaml:"-"`
+	}
+
+	cfg := &nestedConfig{}
+	overridden, err := applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, mapLookupEnv(map[string]string{
+		"MP_NAME":           "test",
+		"MP_DB_URI":         "mongodb://localhost:27017",
+		"MP_SERVER_TIMEOUT": "5s",
+		"MP_LABELS":         "a=b",
+		"MP_IGNORED":        "ignored",
+		"MP_":               "ignored",
+	}))
+	assert.NoError(t, err)
+	assert.True(t, overridden)
+	assert.Equal(t, "test", cfg.Name)
+	assert.Equal(t, "mongodb://localhost:27017", cfg.DB.URI)
+	assert.Equal(t, "5s", cfg.Server.Timeout.String())
+	assert.Nil(t, cfg.Cache)
+	assert.Nil(t, cfg.Labels)
+	assert.Empty(t, cfg.Ignored)
+
+	// Nothing to override
+	overridden, err = applyEnv(reflect.ValueOf(&nestedConfig{}).Elem(), EnvPrefix, mapLookupEnv(nil))
+	assert.NoError(t, err)
+	assert.False(t, overridden)
+
+	// Invalid value
+	_, err = applyEnv(reflect.ValueOf(&nestedConfig{}).Elem(), EnvPrefix, mapLookupEnv(map[string]string{"MP_SERVER_PORT": "a"}))
+	assert.ErrorAs(t, err, &ConfigError{})
+	assert.Equal(t, ConfigErrorTypeEnvError, err.(ConfigError).ErrType)
+	assert.ErrorContains(t, err, "MP_SERVER_PORT")
+}
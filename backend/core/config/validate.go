@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is the validator of `validate` tags, fields are named by YAML keys in errors
+var validate = newValidator()
+
+// newValidator creates a validator naming fields by YAML keys
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _ := yamlName(field)
+		return name
+	})
+	return v
+}
+
+// FieldError is a failure of validating a field, Field is the YAML path like server.gin_mode
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+// Error returns the error message, value of the field is not included since it may be a secret
+func (e FieldError) Error() string {
+	switch e.Tag {
+	case "required":
+		return e.Field + " is required"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", e.Field, e.Param)
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s", e.Field, e.Param)
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s", e.Field, e.Param)
+	}
+	if e.Param != "" {
+		return fmt.Sprintf("%s must satisfy %s=%s", e.Field, e.Tag, e.Param)
+	}
+	return fmt.Sprintf("%s must satisfy %s", e.Field, e.Tag)
+}
+
+// FieldErrors is failures of validating fields
+type FieldErrors []FieldError
+
+// Error returns messages of all failures
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate enforces `validate` tags of cfg, all failures are reported together as FieldErrors
+func Validate(cfg any) error {
+	err := validate.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return ConfigError{ErrType: ConfigErrorTypeValidationError, Err: err}
+	}
+	fieldErrors := make(FieldErrors, len(validationErrors))
+	for i, validationError := range validationErrors {
+		// Namespace starts with name of the root struct
+		_, field, _ := strings.Cut(validationError.Namespace(), ".")
+		fieldErrors[i] = FieldError{
+			Field: field,
+			Tag:   validationError.Tag(),
+			Param: validationError.Param(),
+		}
+	}
+	return ConfigError{ErrType: ConfigErrorTypeValidationError, Err: fieldErrors}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      FieldError
+		expected string
+	}{
+		{name: "required", err: FieldError{Field: "db.uri", Tag: "required"}, expected: "db.uri is required"},
+		{name: "oneof", err: FieldError{Field: "server.mode", Tag: "oneof", Param: "debug release"}, expected: "server.mode must be one of [debug release]"},
+		{name: "min", err: FieldError{Field: "server.port", Tag: "min", Param: "1"}, expected: "server.port must be at least 1"},
+		{name: "max", err: FieldError{Field: "server.port", Tag: "max", Param: "65535"}, expected: "server.port must be at most 65535"},
+		{name: "with-param", err: FieldError{Field: "server.host", Tag: "len", Param: "3"}, expected: "server.host must satisfy len=3"},
+		{name: "without-param", err: FieldError{Field: "server.host", Tag: "hostname"}, expected: "server.host must satisfy hostname"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.err.Error())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// Valid config
+	assert.NoError(t, Validate(&testConfig{Server: testServerConfig{Port: 8080, Mode: "debug"}}))
+
+	// All failures are reported with YAML path, nil pointer is not validated
+	err := Validate(&testConfig{
+		Server: testServerConfig{Port: 0, Mode: "verbose"},
+		DB:     &testDBConfig{},
+	})
+	assert.ErrorAs(t, err, &ConfigError{})
+	assert.Equal(t, ConfigErrorTypeValidationError, err.(ConfigError).ErrType)
+	assert.Equal(t, FieldErrors{
+		{Field: "server.port", Tag: "min", Param: "1"},
+		{Field: "server.mode", Tag: "oneof", Param: "debug release"},
+		{Field: "db.uri", Tag: "required"},
+	}, err.(ConfigError).Err)
+	assert.Equal(t, "invalid config: server.port must be at least 1; server.mode must be one of [debug release]; db.uri is required", err.Error())
+
+	// Invalid argument
+	err = Validate("not-a-struct")
+	assert.ErrorAs(t, err, &ConfigError{})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/STLeee/mediation-platform/backend/core/metrics"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -54,7 +55,8 @@ type MongoDBTLSConfig struct {
 // MongoDB is a MongoDB client
 type MongoDB struct {
 	mongo.Client
-	cfg *MongoDBConfig
+	cfg     *MongoDBConfig
+	metrics *metrics.Metrics
 }
 
 // NewMongoDB creates a new MongoDB
@@ -130,9 +132,38 @@ func NewMongoDB(ctx context.Context, cfg *MongoDBConfig) (*MongoDB, error) {
 }
 
 // Close closes the MongoDB client
-func (db *MongoDB) Close() {
+func (db *MongoDB) Close() error {
 	ctx := context.Background()
 	timeoutCtx, cancel := context.WithTimeout(ctx, db.cfg.ConnectionTimeout)
 	defer cancel()
-	db.Disconnect(timeoutCtx)
+	if err := db.Disconnect(timeoutCtx); err != nil {
+		return DBError{
+			ErrType: DBErrorTypeServerError,
+			Message: "failed to disconnect MongoDB",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// CheckHealth pings MongoDB
+func (db *MongoDB) CheckHealth(ctx context.Context) error {
+	if err := db.Ping(ctx, nil); err != nil {
+		return DBError{
+			ErrType: DBErrorTypeServerError,
+			Message: "failed to ping MongoDB",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// SetMetrics sets metrics which repositories observe latency of operations to
+func (db *MongoDB) SetMetrics(metrics *metrics.Metrics) {
+	db.metrics = metrics
+}
+
+// Metrics returns metrics of the MongoDB, nil if metrics is not set
+func (db *MongoDB) Metrics() *metrics.Metrics {
+	return db.metrics
 }
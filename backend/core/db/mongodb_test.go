@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/metrics"
 )
 
 func TestNewAndCloseMongoDB(t *testing.T) {
@@ -19,6 +21,20 @@ func TestNewAndCloseMongoDB(t *testing.T) {
 	assert.NotNil(t, collection)
 }
 
+func TestMongoDB_Close(t *testing.T) {
+	mongodb, err := NewMongoDB(context.Background(), LocalMongoDBConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, mongodb.Close())
+
+	// Closing again fails
+	err = mongodb.Close()
+	assert.ErrorAs(t, err, &DBError{})
+	assert.Equal(t, DBErrorTypeServerError, err.(DBError).ErrType)
+	assert.Equal(t, "failed to disconnect MongoDB", err.(DBError).Message)
+}
+
 func TestNewMongoDBError(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -58,3 +74,29 @@ func TestNewMongoDBError(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDB_CheckHealth(t *testing.T) {
+	mongodb, err := NewMongoDB(context.Background(), LocalMongoDBConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, mongodb.CheckHealth(context.Background()))
+
+	// Closed client is unhealthy
+	mongodb.Close()
+	err = mongodb.CheckHealth(context.Background())
+	assert.ErrorAs(t, err, &DBError{})
+	assert.Equal(t, DBErrorTypeServerError, err.(DBError).ErrType)
+}
+
+func TestMongoDB_SetMetrics(t *testing.T) {
+	mongodb := &MongoDB{}
+	assert.Nil(t, mongodb.Metrics())
+
+	m, err := metrics.NewMetrics(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mongodb.SetMetrics(m)
+	assert.Equal(t, m, mongodb.Metrics())
+}
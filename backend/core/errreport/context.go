@@ -0,0 +1,66 @@
+package errreport
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/STLeee/mediation-platform/backend/core/logging"
+)
+
+type contextKey string
+
+const (
+	reporterContextKey contextKey = "error_reporter"
+	requestContextKey  contextKey = "request"
+)
+
+// defaultReporter is used when there is no reporter in context
+var defaultReporter ErrorReporter = NewLogReporter(&LogReporterConfig{})
+
+// WithReporter returns a copy of ctx carrying the reporter
+func WithReporter(ctx context.Context, reporter ErrorReporter) context.Context {
+	return context.WithValue(ctx, reporterContextKey, reporter)
+}
+
+// GetReporter gets the reporter carried by ctx, or the default log reporter if there is none
+func GetReporter(ctx context.Context) ErrorReporter {
+	if reporter, ok := ctx.Value(reporterContextKey).(ErrorReporter); ok && reporter != nil {
+		return reporter
+	}
+	return defaultReporter
+}
+
+// WithRequest returns a copy of ctx carrying info of the request where errors are recorded
+func WithRequest(ctx context.Context, request *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestContextKey, request)
+}
+
+// GetRequest gets the request info carried by ctx, or request info of only request ID carried by ctx if there is none
+func GetRequest(ctx context.Context) *RequestInfo {
+	if request, ok := ctx.Value(requestContextKey).(*RequestInfo); ok && request != nil {
+		return request
+	}
+	if requestID := logging.GetRequestID(ctx); requestID != "" {
+		return &RequestInfo{RequestID: requestID}
+	}
+	return nil
+}
+
+// WithUserID returns a copy of ctx whose request info has the user ID, request info carried by ctx is not modified
+// since it may be shared with background work of the request
+func WithUserID(ctx context.Context, userID string) context.Context {
+	request := &RequestInfo{}
+	if carried := GetRequest(ctx); carried != nil {
+		*request = *carried
+	}
+	request.UserID = userID
+	return WithRequest(ctx, request)
+}
+
+// Record reports the error with the reporter and request info carried by ctx, failures of reporting are logged and
+// do not affect the caller
+func Record(ctx context.Context, err error) {
+	if reportErr := GetReporter(ctx).Report(ctx, NewReport(err, GetRequest(ctx), 1)); reportErr != nil {
+		slog.ErrorContext(ctx, "failed to report error", "error", err.Error(), "report_error", reportErr.Error())
+	}
+}
@@ -0,0 +1,85 @@
+package errreport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/logging"
+)
+
+func TestGetRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected *RequestInfo
+	}{
+		{
+			name:     "no-request",
+			ctx:      context.Background(),
+			expected: nil,
+		},
+		{
+			name:     "request-id-only",
+			ctx:      logging.WithRequestID(context.Background(), "test-request-id"),
+			expected: &RequestInfo{RequestID: "test-request-id"},
+		},
+		{
+			name:     "request",
+			ctx:      WithRequest(context.Background(), &RequestInfo{Method: "GET", Path: "/test/:id", RequestID: "test-request-id"}),
+			expected: &RequestInfo{Method: "GET", Path: "/test/:id", RequestID: "test-request-id"},
+		},
+		{
+			name:     "request-with-user-id",
+			ctx:      WithUserID(WithRequest(context.Background(), &RequestInfo{Method: "GET", Path: "/test/:id"}), "test-user-id"),
+			expected: &RequestInfo{Method: "GET", Path: "/test/:id", UserID: "test-user-id"},
+		},
+		{
+			name:     "user-id-only",
+			ctx:      WithUserID(context.Background(), "test-user-id"),
+			expected: &RequestInfo{UserID: "test-user-id"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, GetRequest(testCase.ctx))
+		})
+	}
+}
+
+func TestWithUserID(t *testing.T) {
+	// Request info carried by parent context is not modified
+	request := &RequestInfo{Method: "GET", Path: "/test/:id"}
+	ctx := WithRequest(context.Background(), request)
+	WithUserID(ctx, "test-user-id")
+	assert.Empty(t, request.UserID)
+}
+
+func TestRecord(t *testing.T) {
+	reports := []*Report{}
+	reporter := &MockReporter{
+		Name: "mock",
+		ReportFunc: func(ctx context.Context, report *Report) error {
+			reports = append(reports, report)
+			return errors.New("test error")
+		},
+	}
+	ctx := WithRequest(WithReporter(context.Background(), reporter), &RequestInfo{Method: "GET", Path: "/test/:id"})
+
+	// Failure of reporting does not affect the caller
+	Record(ctx, errors.New("test-error"))
+	assert.Len(t, reports, 1)
+	assert.Equal(t, "test-error", reports[0].Message)
+	assert.Equal(t, &RequestInfo{Method: "GET", Path: "/test/:id"}, reports[0].Request)
+	assert.Equal(t, "github.com/STLeee/mediation-platform/backend/core/errreport.TestRecord", reports[0].Stack[0].Function)
+}
+
+func TestRecord_NoReporter(t *testing.T) {
+	// Default reporter is used
+	assert.Equal(t, defaultReporter, GetReporter(context.Background()))
+	assert.NotPanics(t, func() {
+		Record(context.Background(), errors.New("test-error"))
+	})
+}
@@ -0,0 +1,283 @@
+package errreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ReporterName is a name of error reporter sink
+type ReporterName string
+
+const (
+	ReporterNameLog  ReporterName = "log"
+	ReporterNameFile ReporterName = "file"
+	ReporterNameHTTP ReporterName = "http"
+)
+
+// MaxStackDepth is the max number of frames captured in stack
+const MaxStackDepth = 32
+
+// ErrorReporterConfig struct for error reporter configuration, every configured sink receives the reports,
+// log sink is used when no sink is configured
+type ErrorReporterConfig struct {
+	Log  *LogReporterConfig  `yaml:"log"`
+	File *FileReporterConfig `yaml:"file"`
+	HTTP *HTTPReporterConfig `yaml:"http"`
+}
+
+type ErrReportErrorType string
+
+const (
+	ErrReportErrorTypeServerError   ErrReportErrorType = "server_error"
+	ErrReportErrorTypeConfigError   ErrReportErrorType = "config_error"
+	ErrReportErrorTypeDeliveryError ErrReportErrorType = "delivery_error"
+)
+
+var ErrReportErrorDefaultMessages = map[ErrReportErrorType]string{
+	ErrReportErrorTypeServerError:   "server error",
+	ErrReportErrorTypeConfigError:   "config error",
+	ErrReportErrorTypeDeliveryError: "delivery error",
+}
+
+// ErrReportError struct for error reporter error
+type ErrReportError struct {
+	ErrType ErrReportErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e ErrReportError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := ErrReportErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e ErrReportError) Unwrap() error {
+	return e.Err
+}
+
+// RequestInfo is the request where the error occurs
+type RequestInfo struct {
+	Method    string `json:"method,omitempty"`
+	Path      string `json:"path,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// ChainedError is an error in the chain of wrapped errors
+type ChainedError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// StackFrame is a frame of stack
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Report is a report of error, Chain starts from the reported error to the innermost wrapped error
+type Report struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Message   string         `json:"message"`
+	Chain     []ChainedError `json:"chain"`
+	Stack     []StackFrame   `json:"stack"`
+	Request   *RequestInfo   `json:"request,omitempty"`
+}
+
+// StackTracer is an error carrying stack of where it is created
+type StackTracer interface {
+	StackTrace() []StackFrame
+}
+
+// NewReport creates a report of the error with stack of where the error is created, stack of caller is used when no
+// error in the chain carries stack, skip is the number of frames to skip above the caller
+func NewReport(err error, request *RequestInfo, skip int) *Report {
+	stack := originStack(err)
+	if len(stack) == 0 {
+		stack = captureStack(skip + 2)
+	}
+	return &Report{
+		Timestamp: time.Now(),
+		Message:   err.Error(),
+		Chain:     unwrapChain(err),
+		Stack:     stack,
+		Request:   request,
+	}
+}
+
+// originStack gets stack of the innermost error carrying stack in the chain, which is the closest to where the error
+// originates
+func originStack(err error) []StackFrame {
+	var stack []StackFrame
+	for ; err != nil; err = errors.Unwrap(err) {
+		if stackTracer, ok := err.(StackTracer); ok {
+			if errStack := stackTracer.StackTrace(); len(errStack) > 0 {
+				stack = errStack
+			}
+		}
+	}
+	return stack
+}
+
+// CaptureStack captures stack of the caller for errors carrying stack, skip is the number of frames to skip above the caller
+func CaptureStack(skip int) []StackFrame {
+	return captureStack(skip + 2)
+}
+
+// unwrapChain unwraps the error to its chain, joined errors are unwrapped in order
+func unwrapChain(err error) []ChainedError {
+	chain := []ChainedError{}
+	errs := []error{err}
+	for len(errs) > 0 {
+		err, errs = errs[0], errs[1:]
+		if err == nil {
+			continue
+		}
+		chain = append(chain, ChainedError{
+			Type:    fmt.Sprintf("%T", err),
+			Message: err.Error(),
+		})
+		switch wrapper := err.(type) {
+		case interface{ Unwrap() []error }:
+			errs = append(wrapper.Unwrap(), errs...)
+		default:
+			if wrapped := errors.Unwrap(err); wrapped != nil {
+				errs = append([]error{wrapped}, errs...)
+			}
+		}
+	}
+	return chain
+}
+
+// captureStack captures stack of the caller, skip is the number of frames to skip above captureStack
+func captureStack(skip int) []StackFrame {
+	pcs := make([]uintptr, MaxStackDepth)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := make([]StackFrame, 0, n)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, StackFrame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// ErrorReporter interface for reporting error, Close flushes pending reports and releases resources of the sink
+type ErrorReporter interface {
+	GetName() ReporterName
+	Report(ctx context.Context, report *Report) error
+	Close(ctx context.Context) error
+}
+
+// MultiReporter is a reporter which reports to every reporter
+type MultiReporter struct {
+	reporters []ErrorReporter
+}
+
+// NewMultiReporter creates a new MultiReporter
+func NewMultiReporter(reporters ...ErrorReporter) *MultiReporter {
+	return &MultiReporter{
+		reporters: reporters,
+	}
+}
+
+// GetName returns the names of reporters
+func (reporter *MultiReporter) GetName() ReporterName {
+	names := make([]string, len(reporter.reporters))
+	for i, r := range reporter.reporters {
+		names[i] = string(r.GetName())
+	}
+	return ReporterName(strings.Join(names, ","))
+}
+
+// Report reports to every reporter, failure of one reporter does not stop the others
+func (reporter *MultiReporter) Report(ctx context.Context, report *Report) error {
+	var errs []error
+	for _, r := range reporter.reporters {
+		if err := r.Report(ctx, report); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeDeliveryError,
+			Err:     errors.Join(errs...),
+		}
+	}
+	return nil
+}
+
+// Close closes every reporter, failure of one reporter does not stop the others
+func (reporter *MultiReporter) Close(ctx context.Context) error {
+	var errs []error
+	for _, r := range reporter.reporters {
+		if err := r.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "failed to close reporters",
+			Err:     errors.Join(errs...),
+		}
+	}
+	return nil
+}
+
+// NewErrorReporter creates a new error reporter with sinks enabled in config
+func NewErrorReporter(cfg *ErrorReporterConfig) (ErrorReporter, error) {
+	if cfg == nil {
+		cfg = &ErrorReporterConfig{}
+	}
+
+	reporters := []ErrorReporter{}
+	if cfg.Log != nil {
+		reporters = append(reporters, NewLogReporter(cfg.Log))
+	}
+	if cfg.File != nil {
+		fileReporter, err := NewFileReporter(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		reporters = append(reporters, fileReporter)
+	}
+	if cfg.HTTP != nil {
+		httpReporter, err := NewHTTPReporter(cfg.HTTP)
+		if err != nil {
+			return nil, err
+		}
+		reporters = append(reporters, httpReporter)
+	}
+
+	switch len(reporters) {
+	case 0:
+		return NewLogReporter(&LogReporterConfig{}), nil
+	case 1:
+		return reporters[0], nil
+	default:
+		return NewMultiReporter(reporters...), nil
+	}
+}
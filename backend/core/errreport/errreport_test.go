@@ -0,0 +1,240 @@
+package errreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockReporter struct {
+	Name       ReporterName
+	ReportFunc func(ctx context.Context, report *Report) error
+	CloseFunc  func(ctx context.Context) error
+}
+
+func (reporter *MockReporter) GetName() ReporterName {
+	return reporter.Name
+}
+
+func (reporter *MockReporter) Report(ctx context.Context, report *Report) error {
+	return reporter.ReportFunc(ctx, report)
+}
+
+func (reporter *MockReporter) Close(ctx context.Context) error {
+	return reporter.CloseFunc(ctx)
+}
+
+// stackError is an error carrying stack of where it is created
+type stackError struct {
+	stack []StackFrame
+}
+
+func newStackError() stackError {
+	return stackError{stack: CaptureStack(0)}
+}
+
+func (e stackError) Error() string {
+	return "stack error"
+}
+
+func (e stackError) StackTrace() []StackFrame {
+	return e.stack
+}
+
+type testError struct {
+	Err error
+}
+
+func (e testError) Error() string {
+	return "test error: " + e.Err.Error()
+}
+
+func (e testError) Unwrap() error {
+	return e.Err
+}
+
+func TestErrReportError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  ErrReportErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "delivery-error/no-message",
+			errType:  ErrReportErrorTypeDeliveryError,
+			err:      fmt.Errorf("test error"),
+			expected: "delivery error: test error",
+		},
+		{
+			name:     "config-error/with-message",
+			errType:  ErrReportErrorTypeConfigError,
+			message:  "test message",
+			expected: "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ErrReportError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, err.Error())
+			assert.Equal(t, testCase.err, err.Unwrap())
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		request       *RequestInfo
+		expectedChain []ChainedError
+	}{
+		{
+			name: "single",
+			err:  errors.New("root"),
+			expectedChain: []ChainedError{
+				{Type: "*errors.errorString", Message: "root"},
+			},
+		},
+		{
+			name:    "wrapped",
+			err:     fmt.Errorf("wrapper: %w", testError{Err: errors.New("root")}),
+			request: &RequestInfo{Method: "GET", Path: "/test", UserID: "test-user-id", RequestID: "test-request-id"},
+			expectedChain: []ChainedError{
+				{Type: "*fmt.wrapError", Message: "wrapper: test error: root"},
+				{Type: "errreport.testError", Message: "test error: root"},
+				{Type: "*errors.errorString", Message: "root"},
+			},
+		},
+		{
+			name: "joined",
+			err:  errors.Join(errors.New("first"), errors.New("second")),
+			expectedChain: []ChainedError{
+				{Type: "*errors.joinError", Message: "first\nsecond"},
+				{Type: "*errors.errorString", Message: "first"},
+				{Type: "*errors.errorString", Message: "second"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			report := NewReport(testCase.err, testCase.request, 0)
+			assert.Equal(t, testCase.err.Error(), report.Message)
+			assert.Equal(t, testCase.expectedChain, report.Chain)
+			assert.Equal(t, testCase.request, report.Request)
+			assert.False(t, report.Timestamp.IsZero())
+
+			// Stack starts from the caller
+			assert.NotEmpty(t, report.Stack)
+			assert.Equal(t, "github.com/STLeee/mediation-platform/backend/core/errreport.TestNewReport.func1", report.Stack[0].Function)
+		})
+	}
+}
+
+func TestNewReport_OriginStack(t *testing.T) {
+	// Stack of the innermost error carrying stack is used rather than stack of caller
+	err := fmt.Errorf("wrapper: %w", newStackError())
+	report := NewReport(err, nil, 0)
+	assert.Equal(t, "github.com/STLeee/mediation-platform/backend/core/errreport.newStackError", report.Stack[0].Function)
+
+	// Empty stack is ignored
+	report = NewReport(stackError{}, nil, 0)
+	assert.Equal(t, "github.com/STLeee/mediation-platform/backend/core/errreport.TestNewReport_OriginStack", report.Stack[0].Function)
+}
+
+func TestMultiReporter(t *testing.T) {
+	reported := []ReporterName{}
+	closed := []ReporterName{}
+	newMockReporter := func(name ReporterName, err error) *MockReporter {
+		return &MockReporter{
+			Name: name,
+			ReportFunc: func(ctx context.Context, report *Report) error {
+				reported = append(reported, name)
+				return err
+			},
+			CloseFunc: func(ctx context.Context) error {
+				closed = append(closed, name)
+				return err
+			},
+		}
+	}
+
+	reporter := NewMultiReporter(
+		newMockReporter(ReporterNameLog, nil),
+		newMockReporter(ReporterNameFile, errors.New("test error")),
+		newMockReporter(ReporterNameHTTP, nil),
+	)
+	assert.Equal(t, ReporterName("log,file,http"), reporter.GetName())
+
+	err := reporter.Report(context.Background(), NewReport(errors.New("test"), nil, 0))
+	assert.Equal(t, []ReporterName{ReporterNameLog, ReporterNameFile, ReporterNameHTTP}, reported)
+	assert.ErrorAs(t, err, &ErrReportError{})
+	assert.Equal(t, ErrReportErrorTypeDeliveryError, err.(ErrReportError).ErrType)
+	assert.Contains(t, err.Error(), "file: test error")
+
+	err = reporter.Close(context.Background())
+	assert.Equal(t, []ReporterName{ReporterNameLog, ReporterNameFile, ReporterNameHTTP}, closed)
+	assert.ErrorAs(t, err, &ErrReportError{})
+	assert.Contains(t, err.Error(), "file: test error")
+}
+
+func TestNewErrorReporter(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cfg          *ErrorReporterConfig
+		expectedName ReporterName
+		isErr        bool
+	}{
+		{
+			name:         "nil-config",
+			cfg:          nil,
+			expectedName: ReporterNameLog,
+		},
+		{
+			name:         "log",
+			cfg:          &ErrorReporterConfig{Log: &LogReporterConfig{WithStack: true}},
+			expectedName: ReporterNameLog,
+		},
+		{
+			name: "multiple",
+			cfg: &ErrorReporterConfig{
+				Log:  &LogReporterConfig{},
+				File: &FileReporterConfig{Path: filepath.Join(t.TempDir(), "errors.log")},
+				HTTP: &HTTPReporterConfig{DSN: "http://public-key@localhost:8000/1"},
+			},
+			expectedName: ReporterName("log,file,http"),
+		},
+		{
+			name:  "file/invalid-config",
+			cfg:   &ErrorReporterConfig{File: &FileReporterConfig{}},
+			isErr: true,
+		},
+		{
+			name:  "http/invalid-config",
+			cfg:   &ErrorReporterConfig{HTTP: &HTTPReporterConfig{DSN: "http://localhost:8000"}},
+			isErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reporter, err := NewErrorReporter(testCase.cfg)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &ErrReportError{})
+				assert.Equal(t, ErrReportErrorTypeConfigError, err.(ErrReportError).ErrType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedName, reporter.GetName())
+			assert.NoError(t, reporter.Close(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package errreport
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileReporterConfig struct for file reporter configuration
+type FileReporterConfig struct {
+	Path string `yaml:"path"`
+}
+
+// FileReporter is a reporter which appends reports to the file as JSON lines
+type FileReporter struct {
+	cfg  *FileReporterConfig
+	file *os.File
+	mu   sync.Mutex
+}
+
+// NewFileReporter creates a new FileReporter, the file is created if it does not exist
+func NewFileReporter(cfg *FileReporterConfig) (*FileReporter, error) {
+	if cfg.Path == "" {
+		return nil, ErrReportError{
+			ErrType: ErrReportErrorTypeConfigError,
+			Message: "file path is required",
+		}
+	}
+	file, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, ErrReportError{
+			ErrType: ErrReportErrorTypeConfigError,
+			Message: "failed to open file",
+			Err:     err,
+		}
+	}
+	return &FileReporter{
+		cfg:  cfg,
+		file: file,
+	}, nil
+}
+
+// GetName returns the name of reporter
+func (reporter *FileReporter) GetName() ReporterName {
+	return ReporterNameFile
+}
+
+// Report appends the report to the file as a JSON line
+func (reporter *FileReporter) Report(ctx context.Context, report *Report) error {
+	line, err := json.Marshal(report)
+	if err != nil {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "failed to marshal report",
+			Err:     err,
+		}
+	}
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	if _, err := reporter.file.Write(append(line, '\n')); err != nil {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeDeliveryError,
+			Message: "failed to write report",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// Close closes the file
+func (reporter *FileReporter) Close(ctx context.Context) error {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	if err := reporter.file.Close(); err != nil {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "failed to close file",
+			Err:     err,
+		}
+	}
+	return nil
+}
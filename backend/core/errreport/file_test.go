@@ -0,0 +1,68 @@
+package errreport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	reporter, err := NewFileReporter(&FileReporterConfig{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ReporterNameFile, reporter.GetName())
+
+	// Report twice, reports are appended as lines
+	request := &RequestInfo{Method: "POST", Path: "/api/v1/issue", UserID: "test-user-id", RequestID: "test-request-id"}
+	for _, message := range []string{"first error", "second error"} {
+		assert.NoError(t, reporter.Report(context.Background(), NewReport(errors.New(message), request, 0)))
+	}
+	assert.NoError(t, reporter.Close(context.Background()))
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	messages := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		report := &Report{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), report))
+		assert.Equal(t, request, report.Request)
+		assert.NotEmpty(t, report.Stack)
+		messages = append(messages, report.Message)
+	}
+	assert.Equal(t, []string{"first error", "second error"}, messages)
+}
+
+func TestNewFileReporter(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  *FileReporterConfig
+	}{
+		{
+			name: "empty-path",
+			cfg:  &FileReporterConfig{},
+		},
+		{
+			name: "directory-not-found",
+			cfg:  &FileReporterConfig{Path: filepath.Join(t.TempDir(), "not-found", "errors.log")},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewFileReporter(testCase.cfg)
+			assert.ErrorAs(t, err, &ErrReportError{})
+			assert.Equal(t, ErrReportErrorTypeConfigError, err.(ErrReportError).ErrType)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHTTPReporterTimeout   = 5 * time.Second
+	DefaultHTTPReporterQueueSize = 100
+	HTTPReporterAuthHeaderName   = "X-Sentry-Auth"
+	HTTPReporterClientName       = "mediation-platform/1.0"
+)
+
+// HTTPReporterConfig struct for HTTP reporter configuration, DSN is in the form of Sentry DSN like
+// http://public_key@localhost:8000/1, so a Sentry-compatible ingestion or a local stand-in receives the reports.
+// A report is not queued if QueueSize reports are waiting to be posted
+type HTTPReporterConfig struct {
+	DSN         string        `yaml:"dsn"`
+	Environment string        `yaml:"environment"`
+	Timeout     time.Duration `yaml:"timeout"`
+	QueueSize   int           `yaml:"queue_size"`
+}
+
+// queuedReport is a report queued to be posted
+type queuedReport struct {
+	ctx    context.Context
+	report *Report
+}
+
+// HTTPReporter is a reporter which posts reports as Sentry-style events to the store endpoint of DSN, reports are
+// queued and posted by a background worker, so slow endpoint does not block the caller
+type HTTPReporter struct {
+	cfg       *HTTPReporterConfig
+	client    *http.Client
+	storeURL  string
+	publicKey string
+
+	mu      sync.RWMutex
+	closed  bool
+	reports chan queuedReport
+	done    chan struct{}
+}
+
+// sentryEvent is an event of Sentry store endpoint
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Environment string            `json:"environment,omitempty"`
+	Message     string            `json:"message"`
+	Exception   sentryException   `json:"exception"`
+	Request     *sentryRequest    `json:"request,omitempty"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+type sentryException struct {
+	Values []sentryExceptionValue `json:"values"`
+}
+
+type sentryExceptionValue struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename"`
+	Lineno   int    `json:"lineno"`
+}
+
+type sentryRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+// NewHTTPReporter creates a new HTTPReporter
+func NewHTTPReporter(cfg *HTTPReporterConfig) (*HTTPReporter, error) {
+	storeURL, publicKey, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHTTPReporterTimeout
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultHTTPReporterQueueSize
+	}
+	reporter := &HTTPReporter{
+		cfg:       cfg,
+		client:    &http.Client{Timeout: timeout},
+		storeURL:  storeURL,
+		publicKey: publicKey,
+		reports:   make(chan queuedReport, queueSize),
+		done:      make(chan struct{}),
+	}
+	go reporter.postQueued()
+	return reporter, nil
+}
+
+// parseDSN parses DSN to the store URL and public key
+func parseDSN(dsn string) (string, string, error) {
+	if dsn == "" {
+		return "", "", ErrReportError{
+			ErrType: ErrReportErrorTypeConfigError,
+			Message: "DSN is required",
+		}
+	}
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", ErrReportError{
+			ErrType: ErrReportErrorTypeConfigError,
+			Message: "invalid DSN",
+			Err:     err,
+		}
+	}
+	projectID := strings.Trim(dsnURL.Path, "/")
+	if dsnURL.User == nil || dsnURL.User.Username() == "" || projectID == "" {
+		return "", "", ErrReportError{
+			ErrType: ErrReportErrorTypeConfigError,
+			Message: "DSN requires public key and project ID",
+		}
+	}
+	storeURL := fmt.Sprintf("%s://%s/api/%s/store/", dsnURL.Scheme, dsnURL.Host, projectID)
+	return storeURL, dsnURL.User.Username(), nil
+}
+
+// GetName returns the name of reporter
+func (reporter *HTTPReporter) GetName() ReporterName {
+	return ReporterNameHTTP
+}
+
+// Report queues the report without blocking, it fails if the queue is full or the reporter is closed, values of ctx
+// are kept by the queued report after Report returns
+func (reporter *HTTPReporter) Report(ctx context.Context, report *Report) error {
+	reporter.mu.RLock()
+	defer reporter.mu.RUnlock()
+	if reporter.closed {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "reporter is closed",
+		}
+	}
+	select {
+	case reporter.reports <- queuedReport{ctx: context.WithoutCancel(ctx), report: report}:
+		return nil
+	default:
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeDeliveryError,
+			Message: "report queue is full",
+		}
+	}
+}
+
+// postQueued posts queued reports until the queue is closed and drained, failures are logged
+func (reporter *HTTPReporter) postQueued() {
+	defer close(reporter.done)
+	for queued := range reporter.reports {
+		if err := reporter.post(queued.ctx, queued.report); err != nil {
+			slog.ErrorContext(queued.ctx, "failed to post error report", "message", queued.report.Message, "error", err.Error())
+		}
+	}
+}
+
+// Close stops accepting reports and waits until queued reports are posted or ctx is done
+func (reporter *HTTPReporter) Close(ctx context.Context) error {
+	reporter.mu.Lock()
+	if !reporter.closed {
+		reporter.closed = true
+		close(reporter.reports)
+	}
+	reporter.mu.Unlock()
+
+	select {
+	case <-reporter.done:
+		return nil
+	case <-ctx.Done():
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "failed to post queued reports",
+			Err:     ctx.Err(),
+		}
+	}
+}
+
+// post posts the report as an event to the store endpoint
+func (reporter *HTTPReporter) post(ctx context.Context, report *Report) error {
+	body, err := json.Marshal(reporter.newEvent(report))
+	if err != nil {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "failed to marshal event",
+			Err:     err,
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, reporter.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeServerError,
+			Message: "failed to create event request",
+			Err:     err,
+		}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HTTPReporterAuthHeaderName, fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", HTTPReporterClientName, reporter.publicKey))
+
+	response, err := reporter.client.Do(request)
+	if err != nil {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeDeliveryError,
+			Message: "failed to post event",
+			Err:     err,
+		}
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return ErrReportError{
+			ErrType: ErrReportErrorTypeDeliveryError,
+			Message: fmt.Sprintf("event endpoint responded with status code %d", response.StatusCode),
+		}
+	}
+	return nil
+}
+
+// newEvent converts the report to event, exceptions and frames are ordered from the oldest like Sentry
+func (reporter *HTTPReporter) newEvent(report *Report) *sentryEvent {
+	event := &sentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   report.Timestamp.UTC().Format(time.RFC3339Nano),
+		Level:       "error",
+		Platform:    "go",
+		Environment: reporter.cfg.Environment,
+		Message:     report.Message,
+	}
+
+	values := make([]sentryExceptionValue, len(report.Chain))
+	for i, chainedError := range report.Chain {
+		values[len(report.Chain)-1-i] = sentryExceptionValue{
+			Type:  chainedError.Type,
+			Value: chainedError.Message,
+		}
+	}
+	if len(values) > 0 {
+		frames := make([]sentryFrame, len(report.Stack))
+		for i, frame := range report.Stack {
+			frames[len(report.Stack)-1-i] = sentryFrame{
+				Function: frame.Function,
+				Filename: frame.File,
+				Lineno:   frame.Line,
+			}
+		}
+		values[len(values)-1].Stacktrace = &sentryStacktrace{Frames: frames}
+	}
+	event.Exception.Values = values
+
+	if report.Request != nil {
+		event.Request = &sentryRequest{
+			Method: report.Request.Method,
+			URL:    report.Request.Path,
+		}
+		if report.Request.UserID != "" {
+			event.User = &sentryUser{ID: report.Request.UserID}
+		}
+		if report.Request.RequestID != "" {
+			event.Tags = map[string]string{"request_id": report.Request.RequestID}
+		}
+	}
+	return event
+}
+
+// newEventID creates a random event ID of 32 hex characters
+func newEventID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,189 @@
+package errreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDSN(t *testing.T) {
+	testCases := []struct {
+		name              string
+		dsn               string
+		expectedStoreURL  string
+		expectedPublicKey string
+		isErr             bool
+	}{
+		{
+			name:              "success",
+			dsn:               "http://public-key@localhost:8000/1",
+			expectedStoreURL:  "http://localhost:8000/api/1/store/",
+			expectedPublicKey: "public-key",
+		},
+		{
+			name:  "empty",
+			dsn:   "",
+			isErr: true,
+		},
+		{
+			name:  "no-public-key",
+			dsn:   "http://localhost:8000/1",
+			isErr: true,
+		},
+		{
+			name:  "no-project-id",
+			dsn:   "http://public-key@localhost:8000",
+			isErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			storeURL, publicKey, err := parseDSN(testCase.dsn)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &ErrReportError{})
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedStoreURL, storeURL)
+			assert.Equal(t, testCase.expectedPublicKey, publicKey)
+		})
+	}
+}
+
+func TestHTTPReporter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		isErr      bool
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "error-status-code",
+			statusCode: http.StatusTooManyRequests,
+			isErr:      true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Local stand-in of the store endpoint
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/1/store/", r.URL.Path)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Contains(t, r.Header.Get(HTTPReporterAuthHeaderName), "sentry_key=public-key")
+
+				body, _ := io.ReadAll(r.Body)
+				event := &sentryEvent{}
+				assert.NoError(t, json.Unmarshal(body, event))
+				assert.Len(t, event.EventID, 32)
+				assert.Equal(t, "error", event.Level)
+				assert.Equal(t, "test", event.Environment)
+				assert.Equal(t, "wrapper: root", event.Message)
+				assert.Equal(t, "GET", event.Request.Method)
+				assert.Equal(t, "test-user-id", event.User.ID)
+				assert.Equal(t, "test-request-id", event.Tags["request_id"])
+
+				// Exceptions are ordered from the innermost, and the outermost has stack
+				assert.Len(t, event.Exception.Values, 2)
+				assert.Equal(t, "root", event.Exception.Values[0].Value)
+				assert.Equal(t, "wrapper: root", event.Exception.Values[1].Value)
+				assert.NotNil(t, event.Exception.Values[1].Stacktrace)
+				w.WriteHeader(testCase.statusCode)
+			}))
+			defer server.Close()
+
+			dsn := fmt.Sprintf("http://public-key@%s/1", strings.TrimPrefix(server.URL, "http://"))
+			reporter, err := NewHTTPReporter(&HTTPReporterConfig{DSN: dsn, Environment: "test"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reporter.Close(context.Background())
+			assert.Equal(t, ReporterNameHTTP, reporter.GetName())
+
+			request := &RequestInfo{Method: "GET", Path: "/api/v1/user/:user_id", UserID: "test-user-id", RequestID: "test-request-id"}
+			err = reporter.post(context.Background(), NewReport(fmt.Errorf("wrapper: %w", errors.New("root")), request, 0))
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &ErrReportError{})
+				assert.Equal(t, ErrReportErrorTypeDeliveryError, err.(ErrReportError).ErrType)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPReporter_Report(t *testing.T) {
+	// Store endpoint is blocked until released, so Report returns before the report is posted
+	release := make(chan struct{})
+	posted := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		event := &sentryEvent{}
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, event))
+		posted <- event.Message
+	}))
+	defer server.Close()
+
+	dsn := fmt.Sprintf("http://public-key@%s/1", strings.TrimPrefix(server.URL, "http://"))
+	reporter, err := NewHTTPReporter(&HTTPReporterConfig{DSN: dsn, QueueSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reports are detached from the caller context
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, reporter.Report(ctx, NewReport(errors.New("first"), nil, 0)))
+	cancel()
+
+	// One report is being posted and one is queued, the queue is full then
+	assert.Eventually(t, func() bool {
+		return len(reporter.reports) == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, reporter.Report(context.Background(), NewReport(errors.New("second"), nil, 0)))
+	err = reporter.Report(context.Background(), NewReport(errors.New("third"), nil, 0))
+	assert.ErrorContains(t, err, "report queue is full")
+
+	// Close times out while posting is blocked
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer timeoutCancel()
+	assert.ErrorIs(t, reporter.Close(timeoutCtx), context.DeadlineExceeded)
+
+	// Closed reporter does not accept reports, queued ones are still posted
+	err = reporter.Report(context.Background(), NewReport(errors.New("fourth"), nil, 0))
+	assert.ErrorContains(t, err, "reporter is closed")
+	close(release)
+	assert.NoError(t, reporter.Close(context.Background()))
+	assert.Equal(t, "first", <-posted)
+	assert.Equal(t, "second", <-posted)
+}
+
+func TestHTTPReporter_newEvent(t *testing.T) {
+	reporter := &HTTPReporter{cfg: &HTTPReporterConfig{}}
+	report := NewReport(errors.New("root"), nil, 0)
+	report.Stack = []StackFrame{
+		{Function: "inner", File: "inner.go", Line: 1},
+		{Function: "outer", File: "outer.go", Line: 2},
+	}
+
+	event := reporter.newEvent(report)
+	assert.Nil(t, event.Request)
+	assert.Nil(t, event.User)
+	assert.Empty(t, event.Tags)
+	assert.Equal(t, []sentryFrame{
+		{Function: "outer", Filename: "outer.go", Lineno: 2},
+		{Function: "inner", Filename: "inner.go", Lineno: 1},
+	}, event.Exception.Values[0].Stacktrace.Frames)
+}
@@ -0,0 +1,71 @@
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// LogReporterConfig struct for log reporter configuration
+type LogReporterConfig struct {
+	WithStack bool `yaml:"with_stack"`
+}
+
+// LogReporter is a reporter which writes reports to the logger, default logger of slog is used when logger is nil
+type LogReporter struct {
+	cfg    *LogReporterConfig
+	logger *slog.Logger
+}
+
+// NewLogReporter creates a new LogReporter
+func NewLogReporter(cfg *LogReporterConfig) *LogReporter {
+	return &LogReporter{
+		cfg: cfg,
+	}
+}
+
+// GetName returns the name of reporter
+func (reporter *LogReporter) GetName() ReporterName {
+	return ReporterNameLog
+}
+
+// Report writes the report as an error record, with request info and stack if enabled
+func (reporter *LogReporter) Report(ctx context.Context, report *Report) error {
+	logger := reporter.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.LogAttrs(ctx, slog.LevelError, report.Message, reportAttrs(report, reporter.cfg.WithStack)...)
+	return nil
+}
+
+// Close does nothing since reports are written to the logger synchronously
+func (reporter *LogReporter) Close(ctx context.Context) error {
+	return nil
+}
+
+// reportAttrs converts the report to log attributes
+func reportAttrs(report *Report, withStack bool) []slog.Attr {
+	attrs := []slog.Attr{}
+	if report.Request != nil {
+		requestAttrs := []any{
+			slog.String("method", report.Request.Method),
+			slog.String("path", report.Request.Path),
+		}
+		if report.Request.UserID != "" {
+			requestAttrs = append(requestAttrs, slog.String("user_id", report.Request.UserID))
+		}
+		if report.Request.RequestID != "" {
+			requestAttrs = append(requestAttrs, slog.String("request_id", report.Request.RequestID))
+		}
+		attrs = append(attrs, slog.Group("request", requestAttrs...))
+	}
+	if withStack {
+		stack := make([]string, len(report.Stack))
+		for i, frame := range report.Stack {
+			stack[i] = fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line)
+		}
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+	return attrs
+}
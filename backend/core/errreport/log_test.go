@@ -0,0 +1,56 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogReporter(t *testing.T) {
+	testCases := []struct {
+		name      string
+		withStack bool
+		request   *RequestInfo
+		expected  string
+	}{
+		{
+			name:     "without-request",
+			expected: `{"level":"ERROR","msg":"test error"}` + "\n",
+		},
+		{
+			name:     "with-request",
+			request:  &RequestInfo{Method: "GET", Path: "/api/v1/user/:user_id", UserID: "test-user-id", RequestID: "test-request-id"},
+			expected: `{"level":"ERROR","msg":"test error","request":{"method":"GET","path":"/api/v1/user/:user_id","user_id":"test-user-id","request_id":"test-request-id"}}` + "\n",
+		},
+		{
+			name:      "with-stack",
+			withStack: true,
+			expected:  `{"level":"ERROR","msg":"test error","stack":["main.main main.go:10"]}` + "\n",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			reporter := NewLogReporter(&LogReporterConfig{WithStack: testCase.withStack})
+			reporter.logger = slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if attr.Key == slog.TimeKey && len(groups) == 0 {
+						return slog.Attr{}
+					}
+					return attr
+				},
+			}))
+			assert.Equal(t, ReporterNameLog, reporter.GetName())
+
+			report := NewReport(errors.New("test error"), testCase.request, 0)
+			report.Stack = []StackFrame{{Function: "main.main", File: "main.go", Line: 10}}
+			assert.NoError(t, reporter.Report(context.Background(), report))
+			assert.Equal(t, testCase.expected, buffer.String())
+			assert.NoError(t, reporter.Close(context.Background()))
+		})
+	}
+}
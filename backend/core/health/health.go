@@ -0,0 +1,177 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Default values
+const (
+	DefaultTimeout  = 2 * time.Second
+	DefaultCacheTTL = 5 * time.Second
+)
+
+// Checker interface for health check of a dependency, nil error means the dependency is healthy
+type Checker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckerFunc is a function implementing Checker
+type CheckerFunc func(ctx context.Context) error
+
+// CheckHealth calls the function
+func (f CheckerFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Status is a health status
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Dependency is a named dependency to be checked, failure of critical dependency makes the report down
+type Dependency struct {
+	Name     string
+	Checker  Checker
+	Critical bool
+}
+
+// CheckResult is a result of checking a dependency
+type CheckResult struct {
+	Status   Status
+	Critical bool
+	Latency  time.Duration
+	Error    string
+}
+
+// Report is an aggregated result of checking all dependencies, it is down without checks when shutting down
+type Report struct {
+	Status       Status
+	ShuttingDown bool
+	CheckedAt    time.Time
+	Checks       map[string]*CheckResult
+}
+
+// HealthCheckerConfig struct for health checker config, Timeout limits every check, and report is reused within CacheTTL
+type HealthCheckerConfig struct {
+	Timeout  time.Duration `yaml:"timeout"`
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+// HealthChecker checks dependencies concurrently and aggregates the results, report is cached so frequent probes
+// do not overload dependencies
+type HealthChecker struct {
+	dependencies []Dependency
+	cfg          *HealthCheckerConfig
+
+	mu        sync.Mutex
+	report    *Report
+	expiresAt time.Time
+
+	shuttingDown atomic.Bool
+}
+
+// NewHealthChecker creates a new health checker of the dependencies
+func NewHealthChecker(cfg *HealthCheckerConfig, dependencies ...Dependency) *HealthChecker {
+	if cfg == nil {
+		cfg = &HealthCheckerConfig{}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = DefaultCacheTTL
+	}
+	return &HealthChecker{
+		dependencies: dependencies,
+		cfg:          cfg,
+	}
+}
+
+// Check returns the cached report, or checks dependencies if the report expires, concurrent calls wait for the
+// same check instead of checking again
+func (hc *HealthChecker) Check(ctx context.Context) *Report {
+	if hc.shuttingDown.Load() {
+		return &Report{
+			Status:       StatusDown,
+			ShuttingDown: true,
+			CheckedAt:    time.Now(),
+			Checks:       map[string]*CheckResult{},
+		}
+	}
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if hc.report != nil && time.Now().Before(hc.expiresAt) {
+		return hc.report
+	}
+	hc.report = hc.check(ctx)
+	hc.expiresAt = time.Now().Add(hc.cfg.CacheTTL)
+	return hc.report
+}
+
+// Shutdown makes reports down without checking dependencies, so the service is taken out of traffic before it
+// stops serving, the signature fits shutdown hooks of lifecycle
+func (hc *HealthChecker) Shutdown(ctx context.Context) error {
+	hc.shuttingDown.Store(true)
+	return nil
+}
+
+// check checks all dependencies concurrently
+func (hc *HealthChecker) check(ctx context.Context) *Report {
+	results := make([]*CheckResult, len(hc.dependencies))
+	var wg sync.WaitGroup
+	for i, dependency := range hc.dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = hc.checkDependency(ctx, dependency)
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{
+		Status:    StatusUp,
+		CheckedAt: time.Now(),
+		Checks:    make(map[string]*CheckResult, len(hc.dependencies)),
+	}
+	for i, dependency := range hc.dependencies {
+		report.Checks[dependency.Name] = results[i]
+		if results[i].Status == StatusDown && dependency.Critical {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// checkDependency checks the dependency with timeout, the report is shared by callers, so cancellation of the
+// calling request does not fail the check. Failure is logged once per check rather than once per cached report
+func (hc *HealthChecker) checkDependency(ctx context.Context, dependency Dependency) *CheckResult {
+	timeoutCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hc.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dependency.Checker.CheckHealth(timeoutCtx)
+	result := &CheckResult{
+		Status:   StatusUp,
+		Critical: dependency.Critical,
+		Latency:  time.Since(start),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+		slog.WarnContext(ctx, "dependency is unhealthy",
+			"dependency", dependency.Name,
+			"critical", dependency.Critical,
+			"error", result.Error,
+		)
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthChecker(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      *HealthCheckerConfig
+		expected *HealthCheckerConfig
+	}{
+		{
+			name:     "nil-config",
+			cfg:      nil,
+			expected: &HealthCheckerConfig{Timeout: DefaultTimeout, CacheTTL: DefaultCacheTTL},
+		},
+		{
+			name:     "custom-config",
+			cfg:      &HealthCheckerConfig{Timeout: time.Second, CacheTTL: time.Minute},
+			expected: &HealthCheckerConfig{Timeout: time.Second, CacheTTL: time.Minute},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			healthChecker := NewHealthChecker(testCase.cfg)
+			assert.Equal(t, testCase.expected, healthChecker.cfg)
+		})
+	}
+}
+
+func TestHealthChecker_Check(t *testing.T) {
+	up := CheckerFunc(func(ctx context.Context) error { return nil })
+	down := CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+	slow := CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	testCases := []struct {
+		name           string
+		dependencies   []Dependency
+		expectedStatus Status
+		expectedChecks map[string]Status
+	}{
+		{
+			name: "all-up",
+			dependencies: []Dependency{
+				{Name: "mongodb", Checker: up, Critical: true},
+				{Name: "redis", Checker: up},
+			},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]Status{"mongodb": StatusUp, "redis": StatusUp},
+		},
+		{
+			name: "critical-down",
+			dependencies: []Dependency{
+				{Name: "mongodb", Checker: down, Critical: true},
+				{Name: "redis", Checker: up},
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]Status{"mongodb": StatusDown, "redis": StatusUp},
+		},
+		{
+			name: "non-critical-down",
+			dependencies: []Dependency{
+				{Name: "mongodb", Checker: up, Critical: true},
+				{Name: "redis", Checker: down},
+			},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]Status{"mongodb": StatusUp, "redis": StatusDown},
+		},
+		{
+			name: "critical-timeout",
+			dependencies: []Dependency{
+				{Name: "auth", Checker: slow, Critical: true},
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]Status{"auth": StatusDown},
+		},
+		{
+			name:           "no-dependencies",
+			dependencies:   nil,
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]Status{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			healthChecker := NewHealthChecker(&HealthCheckerConfig{Timeout: 50 * time.Millisecond}, testCase.dependencies...)
+			report := healthChecker.Check(context.Background())
+			assert.Equal(t, testCase.expectedStatus, report.Status)
+			checks := map[string]Status{}
+			for name, result := range report.Checks {
+				checks[name] = result.Status
+				if result.Status == StatusDown {
+					assert.NotEmpty(t, result.Error)
+				}
+			}
+			assert.Equal(t, testCase.expectedChecks, checks)
+		})
+	}
+}
+
+func TestHealthChecker_Check_Cache(t *testing.T) {
+	var count atomic.Int32
+	counter := CheckerFunc(func(ctx context.Context) error {
+		count.Add(1)
+		return nil
+	})
+	healthChecker := NewHealthChecker(&HealthCheckerConfig{CacheTTL: 50 * time.Millisecond}, Dependency{Name: "counter", Checker: counter})
+
+	// Concurrent calls within TTL share one check
+	var wg sync.WaitGroup
+	reports := make([]*Report, 10)
+	for i := range reports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i] = healthChecker.Check(context.Background())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), count.Load())
+	for _, report := range reports {
+		assert.Equal(t, reports[0], report)
+	}
+
+	// Check again after TTL
+	time.Sleep(60 * time.Millisecond)
+	healthChecker.Check(context.Background())
+	assert.Equal(t, int32(2), count.Load())
+}
+
+func TestHealthChecker_Check_CancelledContext(t *testing.T) {
+	checker := CheckerFunc(func(ctx context.Context) error { return ctx.Err() })
+	healthChecker := NewHealthChecker(nil, Dependency{Name: "checker", Checker: checker, Critical: true})
+
+	// Cancellation of caller does not fail the shared report
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, StatusUp, healthChecker.Check(ctx).Status)
+}
+
+func TestHealthChecker_Shutdown(t *testing.T) {
+	var count atomic.Int32
+	counter := CheckerFunc(func(ctx context.Context) error {
+		count.Add(1)
+		return nil
+	})
+	healthChecker := NewHealthChecker(nil, Dependency{Name: "counter", Checker: counter, Critical: true})
+	report := healthChecker.Check(context.Background())
+	assert.Equal(t, StatusUp, report.Status)
+	assert.False(t, report.ShuttingDown)
+
+	// Report is down immediately after shutdown, even if the cached report is up
+	assert.NoError(t, healthChecker.Shutdown(context.Background()))
+	report = healthChecker.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.True(t, report.ShuttingDown)
+	assert.Empty(t, report.Checks)
+	assert.Equal(t, int32(1), count.Load())
+}
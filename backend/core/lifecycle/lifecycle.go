@@ -0,0 +1,196 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Default values
+const (
+	DefaultShutdownTimeout = 15 * time.Second
+)
+
+// LifecycleConfig struct for lifecycle config, ShutdownTimeout limits closing of every hook unless HookTimeouts has
+// the timeout of the hook name, so a slow hook does not use up the time of the following ones. ShutdownDelay is
+// waited by the delay hook registered by RegisterDelay
+type LifecycleConfig struct {
+	ShutdownTimeout time.Duration            `yaml:"shutdown_timeout"`
+	HookTimeouts    map[string]time.Duration `yaml:"hook_timeouts"`
+	ShutdownDelay   time.Duration            `yaml:"shutdown_delay"`
+}
+
+type LifecycleErrorType string
+
+const (
+	LifecycleErrorTypeServeError    LifecycleErrorType = "serve_error"
+	LifecycleErrorTypeShutdownError LifecycleErrorType = "shutdown_error"
+)
+
+var LifecycleErrorDefaultMessages = map[LifecycleErrorType]string{
+	LifecycleErrorTypeServeError:    "serve error",
+	LifecycleErrorTypeShutdownError: "shutdown error",
+}
+
+// LifecycleError struct for lifecycle error
+type LifecycleError struct {
+	ErrType LifecycleErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e LifecycleError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := LifecycleErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e LifecycleError) Unwrap() error {
+	return e.Err
+}
+
+// hook is a named function called on shutdown, timeout of config is used if timeout is not set
+type hook struct {
+	name    string
+	timeout time.Duration
+	close   func(ctx context.Context) error
+}
+
+// Lifecycle runs a service until it fails or a termination signal is received, then closes registered hooks in
+// reverse order of registration, so resources are closed after the ones depending on them
+type Lifecycle struct {
+	cfg *LifecycleConfig
+
+	mu    sync.Mutex
+	hooks []hook
+
+	shuttingDown atomic.Bool
+	shutdownOnce sync.Once
+	shutdownErr  error
+}
+
+// NewLifecycle creates a new lifecycle
+func NewLifecycle(cfg *LifecycleConfig) *Lifecycle {
+	if cfg == nil {
+		cfg = &LifecycleConfig{}
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	return &Lifecycle{cfg: cfg}
+}
+
+// Register registers a hook called on shutdown, it should be registered right after the resource is created
+func (l *Lifecycle) Register(name string, close func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook{name: name, close: close})
+}
+
+// RegisterDelay registers a hook which waits for ShutdownDelay on shutdown, so hooks closed before it take effect
+// before hooks closed after it, like readiness turned down is observed by load balancer before the server stops
+// accepting requests. No hook is registered if ShutdownDelay is not set
+func (l *Lifecycle) RegisterDelay(name string) {
+	if l.cfg.ShutdownDelay <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook{
+		name:    name,
+		timeout: l.cfg.ShutdownDelay,
+		close: func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+	})
+}
+
+// hookTimeout returns the timeout of the hook
+func (l *Lifecycle) hookTimeout(h hook) time.Duration {
+	if h.timeout > 0 {
+		return h.timeout
+	}
+	if timeout, ok := l.cfg.HookTimeouts[h.name]; ok && timeout > 0 {
+		return timeout
+	}
+	return l.cfg.ShutdownTimeout
+}
+
+// ShuttingDown returns true once shutdown begins
+func (l *Lifecycle) ShuttingDown() bool {
+	return l.shuttingDown.Load()
+}
+
+// Run runs serve until it returns, ctx is done, or SIGINT or SIGTERM is received, then shuts down. serve should
+// block until it is stopped by a hook, error of serve is returned with errors of shutdown
+func (l *Lifecycle) Run(ctx context.Context, serve func() error) error {
+	signalCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serveErrCh := make(chan error, 1)
+	go func() {
+		serveErrCh <- serve()
+	}()
+
+	var serveErr error
+	select {
+	case <-signalCtx.Done():
+		slog.InfoContext(ctx, "shutdown is requested")
+	case err := <-serveErrCh:
+		if err != nil {
+			serveErr = LifecycleError{
+				ErrType: LifecycleErrorTypeServeError,
+				Err:     err,
+			}
+		}
+	}
+	return errors.Join(serveErr, l.Shutdown(context.WithoutCancel(ctx)))
+}
+
+// Shutdown closes hooks in reverse order of registration, each within its own timeout, a failed hook does not stop
+// the following ones. It closes hooks only once, and later calls return the same result
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.shutdownOnce.Do(func() {
+		l.shuttingDown.Store(true)
+
+		l.mu.Lock()
+		hooks := l.hooks
+		l.mu.Unlock()
+
+		errs := []error{}
+		for i := len(hooks) - 1; i >= 0; i-- {
+			start := time.Now()
+			timeoutCtx, cancel := context.WithTimeout(ctx, l.hookTimeout(hooks[i]))
+			err := hooks[i].close(timeoutCtx)
+			cancel()
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to close", "name", hooks[i].name, "error", err.Error())
+				errs = append(errs, LifecycleError{
+					ErrType: LifecycleErrorTypeShutdownError,
+					Message: "failed to close " + hooks[i].name,
+					Err:     err,
+				})
+				continue
+			}
+			slog.InfoContext(ctx, "closed", "name", hooks[i].name, "duration", time.Since(start))
+		}
+		l.shutdownErr = errors.Join(errs...)
+	})
+	return l.shutdownErr
+}
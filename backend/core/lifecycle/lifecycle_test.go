@@ -0,0 +1,213 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  LifecycleErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "serve-error/no-message",
+			errType:  LifecycleErrorTypeServeError,
+			err:      fmt.Errorf("test error"),
+			expected: "serve error: test error",
+		},
+		{
+			name:     "shutdown-error/with-message",
+			errType:  LifecycleErrorTypeShutdownError,
+			message:  "failed to close test",
+			err:      fmt.Errorf("test error"),
+			expected: "failed to close test: test error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := LifecycleError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, err.Error())
+			assert.Equal(t, testCase.err, err.Unwrap())
+		})
+	}
+}
+
+func TestNewLifecycle(t *testing.T) {
+	assert.Equal(t, DefaultShutdownTimeout, NewLifecycle(nil).cfg.ShutdownTimeout)
+	assert.Equal(t, time.Second, NewLifecycle(&LifecycleConfig{ShutdownTimeout: time.Second}).cfg.ShutdownTimeout)
+}
+
+func TestLifecycle_Shutdown(t *testing.T) {
+	lifecycle := NewLifecycle(&LifecycleConfig{ShutdownTimeout: 50 * time.Millisecond})
+	closed := []string{}
+	lifecycle.Register("first", func(ctx context.Context) error {
+		// Every hook has its own timeout, so the timed out hook does not use up the time of the following ones
+		assert.NoError(t, ctx.Err())
+		closed = append(closed, "first")
+		return nil
+	})
+	lifecycle.Register("failed", func(ctx context.Context) error {
+		closed = append(closed, "failed")
+		return errors.New("test error")
+	})
+	lifecycle.Register("timeout", func(ctx context.Context) error {
+		closed = append(closed, "timeout")
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	lifecycle.Register("last", func(ctx context.Context) error {
+		// Shutdown begins before hooks are closed
+		assert.True(t, lifecycle.ShuttingDown())
+		closed = append(closed, "last")
+		return nil
+	})
+	assert.False(t, lifecycle.ShuttingDown())
+
+	// Hooks are closed in reverse order, and failed hooks do not stop the following ones
+	err := lifecycle.Shutdown(context.Background())
+	assert.Equal(t, []string{"last", "timeout", "failed", "first"}, closed)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failed to close failed: test error")
+	assert.ErrorContains(t, err, "failed to close timeout")
+
+	// Hooks are closed only once
+	assert.Equal(t, err, lifecycle.Shutdown(context.Background()))
+	assert.Len(t, closed, 4)
+}
+
+func TestLifecycle_HookTimeouts(t *testing.T) {
+	lifecycle := NewLifecycle(&LifecycleConfig{
+		ShutdownTimeout: time.Second,
+		HookTimeouts:    map[string]time.Duration{"short": 10 * time.Millisecond},
+	})
+	timeouts := map[string]time.Duration{}
+	for _, name := range []string{"default", "short"} {
+		lifecycle.Register(name, func(ctx context.Context) error {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			timeouts[name] = time.Until(deadline)
+			return nil
+		})
+	}
+
+	assert.NoError(t, lifecycle.Shutdown(context.Background()))
+	assert.Greater(t, timeouts["default"], 500*time.Millisecond)
+	assert.LessOrEqual(t, timeouts["short"], 10*time.Millisecond)
+}
+
+func TestLifecycle_RegisterDelay(t *testing.T) {
+	testCases := []struct {
+		name          string
+		delay         time.Duration
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "delay",
+			delay:         50 * time.Millisecond,
+			expectedDelay: 50 * time.Millisecond,
+		},
+		{
+			name:  "no-delay",
+			delay: 0,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Delay is waited between hooks registered around it, and it is not a failure
+			lifecycle := NewLifecycle(&LifecycleConfig{ShutdownDelay: testCase.delay})
+			closedAt := map[string]time.Time{}
+			lifecycle.Register("after", func(ctx context.Context) error {
+				closedAt["after"] = time.Now()
+				return nil
+			})
+			lifecycle.RegisterDelay("delay")
+			lifecycle.Register("before", func(ctx context.Context) error {
+				closedAt["before"] = time.Now()
+				return nil
+			})
+
+			assert.NoError(t, lifecycle.Shutdown(context.Background()))
+			assert.GreaterOrEqual(t, closedAt["after"].Sub(closedAt["before"]), testCase.expectedDelay)
+			assert.Less(t, closedAt["after"].Sub(closedAt["before"]), testCase.expectedDelay+time.Second)
+		})
+	}
+}
+
+func TestLifecycle_Run(t *testing.T) {
+	testCases := []struct {
+		name          string
+		serveErr      error
+		cancel        bool
+		signal        bool
+		expectedError bool
+	}{
+		{
+			name:          "serve-error",
+			serveErr:      errors.New("test error"),
+			expectedError: true,
+		},
+		{
+			name:   "context-done",
+			cancel: true,
+		},
+		{
+			name:   "signal",
+			signal: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lifecycle := NewLifecycle(nil)
+
+			// Serve until the hook stops it, or fail immediately
+			stopped := make(chan struct{})
+			lifecycle.Register("server", func(ctx context.Context) error {
+				close(stopped)
+				return nil
+			})
+			serve := func() error {
+				if testCase.serveErr != nil {
+					return testCase.serveErr
+				}
+				<-stopped
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				if testCase.cancel {
+					cancel()
+				}
+				if testCase.signal {
+					syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+				}
+			}()
+
+			err := lifecycle.Run(ctx, serve)
+			if testCase.expectedError {
+				assert.ErrorAs(t, err, &LifecycleError{})
+				assert.ErrorIs(t, err, testCase.serveErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.True(t, lifecycle.ShuttingDown())
+			<-stopped
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// RequestIDAttrKey is the attribute key of request ID in log records
+const RequestIDAttrKey = "request_id"
+
+type contextKey string
+
+const requestIDContextKey contextKey = "request_id"
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// GetRequestID gets the request ID carried by ctx, empty if there is none
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// NewRequestID creates a random request ID of 32 hex characters
+func NewRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// ContextHandler is a slog handler which attaches request ID of context to records
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler creates a new ContextHandler wrapping the handler
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: handler}
+}
+
+// Handle attaches request ID of context to the record, and passes it to the wrapped handler
+func (handler *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := GetRequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String(RequestIDAttrKey, requestID))
+	}
+	return handler.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a ContextHandler wrapping the handler with attributes
+func (handler *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewContextHandler(handler.Handler.WithAttrs(attrs))
+}
+
+// WithGroup returns a ContextHandler wrapping the handler with group
+func (handler *ContextHandler) WithGroup(name string) slog.Handler {
+	return NewContextHandler(handler.Handler.WithGroup(name))
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDContext(t *testing.T) {
+	assert.Equal(t, "", GetRequestID(context.Background()))
+	assert.Equal(t, "", GetRequestID(nil))
+	assert.Equal(t, "test-request-id", GetRequestID(WithRequestID(context.Background(), "test-request-id")))
+}
+
+func TestNewRequestID(t *testing.T) {
+	requestID := NewRequestID()
+	assert.Len(t, requestID, 32)
+	assert.NotEqual(t, requestID, NewRequestID())
+}
+
+func TestContextHandler(t *testing.T) {
+	testCases := []struct {
+		name              string
+		ctx               context.Context
+		expectedRequestID any
+	}{
+		{
+			name:              "with-request-id",
+			ctx:               WithRequestID(context.Background(), "test-request-id"),
+			expectedRequestID: "test-request-id",
+		},
+		{
+			name:              "without-request-id",
+			ctx:               context.Background(),
+			expectedRequestID: nil,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			logger := slog.New(NewContextHandler(slog.NewJSONHandler(&buffer, nil)))
+
+			// Attributes of logger are kept
+			logger.With("service", "test-service").InfoContext(testCase.ctx, "test message")
+			record := map[string]any{}
+			assert.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+			assert.Equal(t, "test-service", record["service"])
+			assert.Equal(t, testCase.expectedRequestID, record[RequestIDAttrKey])
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// LogLevel is a level of logging
+type LogLevel string
+
+const (
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+	LogLevelError LogLevel = "error"
+)
+
+// logLevels maps log levels to slog levels
+var logLevels = map[LogLevel]slog.Level{
+	LogLevelDebug: slog.LevelDebug,
+	LogLevelInfo:  slog.LevelInfo,
+	LogLevelWarn:  slog.LevelWarn,
+	LogLevelError: slog.LevelError,
+}
+
+type LoggingErrorType string
+
+const (
+	LoggingErrorTypeConfigError LoggingErrorType = "config_error"
+)
+
+var LoggingErrorDefaultMessages = map[LoggingErrorType]string{
+	LoggingErrorTypeConfigError: "config error",
+}
+
+// LoggingError struct for logging error
+type LoggingError struct {
+	ErrType LoggingErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e LoggingError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := LoggingErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e LoggingError) Unwrap() error {
+	return e.Err
+}
+
+// ParseLogLevel parses the log level to slog level, empty level is info
+func ParseLogLevel(level LogLevel) (slog.Level, error) {
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	slogLevel, ok := logLevels[LogLevel(strings.ToLower(string(level)))]
+	if !ok {
+		return slog.LevelInfo, LoggingError{
+			ErrType: LoggingErrorTypeConfigError,
+			Message: "invalid log level " + string(level),
+		}
+	}
+	return slogLevel, nil
+}
+
+// NewLogger creates a logger writing JSON lines to w, records are attached with request ID of context
+func NewLogger(w io.Writer, level LogLevel) (*slog.Logger, error) {
+	slogLevel, err := ParseLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slogLevel})
+	return slog.New(NewContextHandler(handler)), nil
+}
@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggingError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  LoggingErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "config-error/no-message",
+			errType:  LoggingErrorTypeConfigError,
+			err:      fmt.Errorf("test error"),
+			expected: "config error: test error",
+		},
+		{
+			name:     "config-error/with-message",
+			errType:  LoggingErrorTypeConfigError,
+			message:  "test message",
+			expected: "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := LoggingError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, err.Error())
+			assert.Equal(t, testCase.err, err.Unwrap())
+		})
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    LogLevel
+		expected slog.Level
+		isErr    bool
+	}{
+		{
+			name:     "empty",
+			level:    "",
+			expected: slog.LevelInfo,
+		},
+		{
+			name:     "debug",
+			level:    LogLevelDebug,
+			expected: slog.LevelDebug,
+		},
+		{
+			name:     "upper-case",
+			level:    "WARN",
+			expected: slog.LevelWarn,
+		},
+		{
+			name:     "error",
+			level:    LogLevelError,
+			expected: slog.LevelError,
+		},
+		{
+			name:  "invalid",
+			level: "verbose",
+			isErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			level, err := ParseLogLevel(testCase.level)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &LoggingError{})
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, level)
+		})
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	logger, err := NewLogger(&buffer, LogLevelInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Debug record is filtered out by level
+	logger.Debug("debug message")
+	assert.Empty(t, buffer.String())
+
+	// Record is in JSON with request ID of context
+	logger.InfoContext(WithRequestID(context.Background(), "test-request-id"), "info message", "user_id", "test-user-id")
+	record := map[string]any{}
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "info message", record["msg"])
+	assert.Equal(t, "test-user-id", record["user_id"])
+	assert.Equal(t, "test-request-id", record[RequestIDAttrKey])
+
+	_, err = NewLogger(&buffer, "verbose")
+	assert.ErrorAs(t, err, &LoggingError{})
+}
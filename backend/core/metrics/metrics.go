@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace is the namespace of metrics
+const Namespace = "mediation_platform"
+
+// AuthOutcome is an outcome of token authentication
+type AuthOutcome string
+
+const (
+	AuthOutcomeCacheHit         AuthOutcome = "cache_hit"
+	AuthOutcomeCacheNegativeHit AuthOutcome = "cache_negative_hit"
+	AuthOutcomeProviderVerified AuthOutcome = "provider_verified"
+	AuthOutcomeMissingToken     AuthOutcome = "missing_token"
+	AuthOutcomeInvalidToken     AuthOutcome = "invalid_token"
+	AuthOutcomeProviderFailure  AuthOutcome = "provider_failure"
+	AuthOutcomeInternalError    AuthOutcome = "internal_error"
+)
+
+type MetricsErrorType string
+
+const (
+	MetricsErrorTypeConfigError MetricsErrorType = "config_error"
+)
+
+var MetricsErrorDefaultMessages = map[MetricsErrorType]string{
+	MetricsErrorTypeConfigError: "config error",
+}
+
+// MetricsError struct for metrics error
+type MetricsError struct {
+	ErrType MetricsErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e MetricsError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := MetricsErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e MetricsError) Unwrap() error {
+	return e.Err
+}
+
+// Metrics is a set of Prometheus collectors registered to the registry, methods of nil Metrics do nothing,
+// so instrumented components work without metrics
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequestsTotal        *prometheus.CounterVec
+	httpRequestDuration      *prometheus.HistogramVec
+	authOutcomesTotal        *prometheus.CounterVec
+	mongoDBOperationDuration *prometheus.HistogramVec
+	redisCommandDuration     *prometheus.HistogramVec
+	redisCommandErrorsTotal  *prometheus.CounterVec
+}
+
+// NewMetrics creates metrics and registers them to the registry, a new registry is created when registry is nil
+func NewMetrics(registry *prometheus.Registry) (*Metrics, error) {
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+	}
+	metrics := &Metrics{
+		Registry: registry,
+		httpRequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		authOutcomesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "auth_outcomes_total",
+			Help:      "Number of token authentications by outcome.",
+		}, []string{"outcome"}),
+		mongoDBOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "mongodb_operation_duration_seconds",
+			Help:      "Latency of MongoDB operations by collection and operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"collection", "operation"}),
+		redisCommandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "redis_command_duration_seconds",
+			Help:      "Latency of Redis commands by command.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command"}),
+		redisCommandErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "redis_command_errors_total",
+			Help:      "Number of failed Redis commands by command.",
+		}, []string{"command"}),
+	}
+
+	collectors := []prometheus.Collector{
+		metrics.httpRequestsTotal,
+		metrics.httpRequestDuration,
+		metrics.authOutcomesTotal,
+		metrics.mongoDBOperationDuration,
+		metrics.redisCommandDuration,
+		metrics.redisCommandErrorsTotal,
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return nil, MetricsError{
+				ErrType: MetricsErrorTypeConfigError,
+				Message: "failed to register collector",
+				Err:     err,
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// Handler returns the HTTP handler exposing metrics of the registry
+func (metrics *Metrics) Handler() http.Handler {
+	if metrics == nil {
+		return promhttp.HandlerFor(prometheus.NewRegistry(), promhttp.HandlerOpts{})
+	}
+	return promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest counts the HTTP request and observes its latency
+func (metrics *Metrics) ObserveHTTPRequest(method, route string, statusCode int, duration time.Duration) {
+	if metrics == nil {
+		return
+	}
+	status := strconv.Itoa(statusCode)
+	metrics.httpRequestsTotal.WithLabelValues(method, route, status).Inc()
+	metrics.httpRequestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+// IncAuthOutcome counts the outcome of token authentication
+func (metrics *Metrics) IncAuthOutcome(outcome AuthOutcome) {
+	if metrics == nil {
+		return
+	}
+	metrics.authOutcomesTotal.WithLabelValues(string(outcome)).Inc()
+}
+
+// ObserveMongoDBOperation observes latency of the MongoDB operation on the collection
+func (metrics *Metrics) ObserveMongoDBOperation(collection, operation string, duration time.Duration) {
+	if metrics == nil {
+		return
+	}
+	metrics.mongoDBOperationDuration.WithLabelValues(collection, operation).Observe(duration.Seconds())
+}
+
+// ObserveRedisCommand observes latency of the Redis command, and counts it as failed when err is not nil
+func (metrics *Metrics) ObserveRedisCommand(command string, duration time.Duration, err error) {
+	if metrics == nil {
+		return
+	}
+	metrics.redisCommandDuration.WithLabelValues(command).Observe(duration.Seconds())
+	if err != nil {
+		metrics.redisCommandErrorsTotal.WithLabelValues(command).Inc()
+	}
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  MetricsErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "config-error/no-message",
+			errType:  MetricsErrorTypeConfigError,
+			err:      fmt.Errorf("test error"),
+			expected: "config error: test error",
+		},
+		{
+			name:     "config-error/with-message",
+			errType:  MetricsErrorTypeConfigError,
+			message:  "test message",
+			expected: "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := MetricsError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, err.Error())
+			assert.Equal(t, testCase.err, err.Unwrap())
+		})
+	}
+}
+
+func TestNewMetrics(t *testing.T) {
+	// New registry is created
+	metrics, err := NewMetrics(nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, metrics.Registry)
+
+	// Collectors can not be registered twice
+	_, err = NewMetrics(metrics.Registry)
+	assert.ErrorAs(t, err, &MetricsError{})
+}
+
+func TestMetrics_ObserveHTTPRequest(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.ObserveHTTPRequest("GET", "/api/v1/user/:user_id", http.StatusOK, 10*time.Millisecond)
+	metrics.ObserveHTTPRequest("GET", "/api/v1/user/:user_id", http.StatusOK, 20*time.Millisecond)
+	metrics.ObserveHTTPRequest("GET", "/api/v1/user/:user_id", http.StatusNotFound, 5*time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.httpRequestsTotal.WithLabelValues("GET", "/api/v1/user/:user_id", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.httpRequestsTotal.WithLabelValues("GET", "/api/v1/user/:user_id", "404")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.httpRequestDuration))
+}
+
+func TestMetrics_IncAuthOutcome(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.IncAuthOutcome(AuthOutcomeCacheHit)
+	metrics.IncAuthOutcome(AuthOutcomeCacheHit)
+	metrics.IncAuthOutcome(AuthOutcomeProviderFailure)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.authOutcomesTotal.WithLabelValues(string(AuthOutcomeCacheHit))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.authOutcomesTotal.WithLabelValues(string(AuthOutcomeProviderFailure))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.authOutcomesTotal.WithLabelValues(string(AuthOutcomeProviderVerified))))
+}
+
+func TestMetrics_ObserveMongoDBOperation(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.ObserveMongoDBOperation("user", "find_one", 10*time.Millisecond)
+	metrics.ObserveMongoDBOperation("issue", "find", 10*time.Millisecond)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.mongoDBOperationDuration))
+}
+
+func TestMetrics_ObserveRedisCommand(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.ObserveRedisCommand("get", time.Millisecond, nil)
+	metrics.ObserveRedisCommand("set", time.Millisecond, errors.New("test error"))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.redisCommandDuration))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.redisCommandErrorsTotal.WithLabelValues("get")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.redisCommandErrorsTotal.WithLabelValues("set")))
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var metrics *Metrics
+	assert.NotPanics(t, func() {
+		metrics.ObserveHTTPRequest("GET", "/", http.StatusOK, time.Millisecond)
+		metrics.IncAuthOutcome(AuthOutcomeCacheHit)
+		metrics.ObserveMongoDBOperation("user", "find_one", time.Millisecond)
+		metrics.ObserveRedisCommand("get", time.Millisecond, nil)
+	})
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics.IncAuthOutcome(AuthOutcomeProviderVerified)
+
+	httpRecorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(httpRecorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusOK, httpRecorder.Code)
+	assert.True(t, strings.Contains(httpRecorder.Body.String(), `mediation_platform_auth_outcomes_total{outcome="provider_verified"} 1`))
+}
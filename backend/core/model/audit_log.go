@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuditAction is an action recorded in audit log
+type AuditAction string
+
+const (
+	AuditActionUserDisabled    AuditAction = "user_disabled"
+	AuditActionUserEnabled     AuditAction = "user_enabled"
+	AuditActionUserForceLogout AuditAction = "user_force_logout"
+)
+
+// AuditLog is a record of an operation done by an actor to a target user
+type AuditLog struct {
+	AuditLogID string      `json:"audit_log_id" bson:"-"`
+	ActorID    string      `json:"actor_id" bson:"actor_id"`
+	Action     AuditAction `json:"action" bson:"action"`
+	TargetID   string      `json:"target_id" bson:"target_id"`
+	CreatedAt  time.Time   `json:"created_at" bson:"created_at"`
+}
+
+// AuditLogInMongoDB is an audit log in MongoDB
+type AuditLogInMongoDB struct {
+	ID       bson.ObjectID `bson:"_id"`
+	AuditLog `bson:",inline"`
+}
+
+func NewAuditLogInMongoDB(auditLog *AuditLog) (*AuditLogInMongoDB, error) {
+	var objectID bson.ObjectID
+	var err error
+	if auditLog.AuditLogID != "" {
+		objectID, err = bson.ObjectIDFromHex(auditLog.AuditLogID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		objectID = bson.NewObjectID()
+	}
+	return &AuditLogInMongoDB{
+		ID:       objectID,
+		AuditLog: *auditLog,
+	}, nil
+}
+
+func (auditLogInMongoDB *AuditLogInMongoDB) SetupDataFromDocument() error {
+	auditLogInMongoDB.AuditLog.AuditLogID = auditLogInMongoDB.ID.Hex()
+	return nil
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogInMongoDB(t *testing.T) {
+	testCases := []struct {
+		name     string
+		auditLog *AuditLog
+		isValid  bool
+	}{
+		{
+			name: "valid-audit-log",
+			auditLog: &AuditLog{
+				AuditLogID: "5f4b8f1f9d1e4b0001f3f3b1",
+				ActorID:    "000000000000000000000001",
+				Action:     AuditActionUserDisabled,
+				TargetID:   "000000000000000000000002",
+			},
+			isValid: true,
+		},
+		{
+			name: "empty-audit-log-id",
+			auditLog: &AuditLog{
+				AuditLogID: "",
+				Action:     AuditActionUserEnabled,
+			},
+			isValid: true,
+		},
+		{
+			name: "invalid-audit-log-id",
+			auditLog: &AuditLog{
+				AuditLogID: "invalid-id",
+				Action:     AuditActionUserForceLogout,
+			},
+			isValid: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			auditLogInMongoDB, err := NewAuditLogInMongoDB(testCase.auditLog)
+			if testCase.isValid {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if testCase.auditLog.AuditLogID != "" {
+					exceptedAuditLogID := testCase.auditLog.AuditLogID
+
+					// Check if the audit log ID is converted to ObjectID
+					assert.Equal(t, utils.ConvertStringToObjectID(exceptedAuditLogID), auditLogInMongoDB.ID)
+
+					// Check if the ObjectID is set to the audit log
+					auditLogInMongoDB.AuditLog.AuditLogID = ""
+					auditLogInMongoDB.SetupDataFromDocument()
+					assert.Equal(t, exceptedAuditLogID, auditLogInMongoDB.AuditLog.AuditLogID)
+				} else {
+					// Check if the ObjectID is set to the audit log
+					auditLogInMongoDB.SetupDataFromDocument()
+					assert.NotEmpty(t, auditLogInMongoDB.AuditLog.AuditLogID)
+				}
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
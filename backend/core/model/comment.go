@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Comment is a comment on an issue, replies refer to their parent comment
+type Comment struct {
+	CommentID       string    `json:"comment_id" bson:"-"`
+	IssueID         string    `json:"issue_id" bson:"issue_id"`
+	AuthorID        string    `json:"author_id" bson:"author_id"`
+	Body            string    `json:"body" bson:"body"`
+	ParentCommentID string    `json:"parent_comment_id" bson:"parent_comment_id"`
+	Edited          bool      `json:"edited" bson:"edited"`
+	Deleted         bool      `json:"deleted" bson:"deleted"`
+	IsAIGenerated   bool      `json:"is_ai_generated" bson:"is_ai_generated"`
+	CreatedAt       time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// CommentInMongoDB is a comment in MongoDB
+type CommentInMongoDB struct {
+	ID      bson.ObjectID `bson:"_id"`
+	Comment `bson:",inline"`
+}
+
+func NewCommentInMongoDB(comment *Comment) (*CommentInMongoDB, error) {
+	var objectID bson.ObjectID
+	var err error
+	if comment.CommentID != "" {
+		objectID, err = bson.ObjectIDFromHex(comment.CommentID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		objectID = bson.NewObjectID()
+	}
+	return &CommentInMongoDB{
+		ID:      objectID,
+		Comment: *comment,
+	}, nil
+}
+
+func (commentInMongoDB *CommentInMongoDB) SetupDataFromDocument() error {
+	commentInMongoDB.Comment.CommentID = commentInMongoDB.ID.Hex()
+	return nil
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentInMongoDB(t *testing.T) {
+	testCases := []struct {
+		name    string
+		comment *Comment
+		isValid bool
+	}{
+		{
+			name: "valid-comment",
+			comment: &Comment{
+				CommentID: "5f4b8f1f9d1e4b0001f3f3b1",
+				IssueID:   "5f4b8f1f9d1e4b0001f3f3b2",
+				AuthorID:  "000000000000000000000001",
+				Body:      "body",
+			},
+			isValid: true,
+		},
+		{
+			name: "empty-comment-id",
+			comment: &Comment{
+				CommentID: "",
+				Body:      "body",
+			},
+			isValid: true,
+		},
+		{
+			name: "invalid-comment-id",
+			comment: &Comment{
+				CommentID: "invalid-id",
+				Body:      "body",
+			},
+			isValid: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			commentInMongoDB, err := NewCommentInMongoDB(testCase.comment)
+			if testCase.isValid {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if testCase.comment.CommentID != "" {
+					exceptedCommentID := testCase.comment.CommentID
+
+					// Check if the comment ID is converted to ObjectID
+					assert.Equal(t, utils.ConvertStringToObjectID(exceptedCommentID), commentInMongoDB.ID)
+
+					// Check if the ObjectID is set to the comment
+					commentInMongoDB.Comment.CommentID = ""
+					commentInMongoDB.SetupDataFromDocument()
+					assert.Equal(t, exceptedCommentID, commentInMongoDB.Comment.CommentID)
+				} else {
+					// Check if the ObjectID is set to the comment
+					commentInMongoDB.SetupDataFromDocument()
+					assert.NotEmpty(t, commentInMongoDB.Comment.CommentID)
+				}
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// IssueStatus is a status of issue
+type IssueStatus string
+
+const (
+	IssueStatusOpen   IssueStatus = "open"
+	IssueStatusClosed IssueStatus = "closed"
+)
+
+// Issue is an issue mediated between parties
+type Issue struct {
+	IssueID     string      `json:"issue_id" bson:"-"`
+	Title       string      `json:"title" bson:"title"`
+	Description string      `json:"description" bson:"description"`
+	Parties     []string    `json:"parties" bson:"parties"`
+	Status      IssueStatus `json:"status" bson:"status"`
+	CreatedBy   string      `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time   `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" bson:"updated_at"`
+	ClosedAt    time.Time   `json:"closed_at" bson:"closed_at"`
+}
+
+// IsParticipant checks if the user is the creator or one of the parties of the issue
+func (issue *Issue) IsParticipant(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	return issue.CreatedBy == userID || slices.Contains(issue.Parties, userID)
+}
+
+// IssueInMongoDB is an issue in MongoDB
+type IssueInMongoDB struct {
+	ID    bson.ObjectID `bson:"_id"`
+	Issue `bson:",inline"`
+}
+
+func NewIssueInMongoDB(issue *Issue) (*IssueInMongoDB, error) {
+	var objectID bson.ObjectID
+	var err error
+	if issue.IssueID != "" {
+		objectID, err = bson.ObjectIDFromHex(issue.IssueID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		objectID = bson.NewObjectID()
+	}
+	return &IssueInMongoDB{
+		ID:    objectID,
+		Issue: *issue,
+	}, nil
+}
+
+func (issueInMongoDB *IssueInMongoDB) SetupDataFromDocument() error {
+	issueInMongoDB.Issue.IssueID = issueInMongoDB.ID.Hex()
+	return nil
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueInMongoDB(t *testing.T) {
+	testCases := []struct {
+		name    string
+		issue   *Issue
+		isValid bool
+	}{
+		{
+			name: "valid-issue",
+			issue: &Issue{
+				IssueID:   "5f4b8f1f9d1e4b0001f3f3b1",
+				Title:     "title",
+				CreatedBy: "000000000000000000000001",
+				Parties:   []string{"000000000000000000000001", "000000000000000000000002"},
+				Status:    IssueStatusOpen,
+			},
+			isValid: true,
+		},
+		{
+			name: "empty-issue-id",
+			issue: &Issue{
+				IssueID: "",
+				Title:   "title",
+			},
+			isValid: true,
+		},
+		{
+			name: "invalid-issue-id",
+			issue: &Issue{
+				IssueID: "invalid-id",
+				Title:   "title",
+			},
+			isValid: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issueInMongoDB, err := NewIssueInMongoDB(testCase.issue)
+			if testCase.isValid {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if testCase.issue.IssueID != "" {
+					exceptedIssueID := testCase.issue.IssueID
+
+					// Check if the issue ID is converted to ObjectID
+					assert.Equal(t, utils.ConvertStringToObjectID(exceptedIssueID), issueInMongoDB.ID)
+
+					// Check if the ObjectID is set to the issue
+					issueInMongoDB.Issue.IssueID = ""
+					issueInMongoDB.SetupDataFromDocument()
+					assert.Equal(t, exceptedIssueID, issueInMongoDB.Issue.IssueID)
+				} else {
+					// Check if the ObjectID is set to the issue
+					issueInMongoDB.SetupDataFromDocument()
+					assert.NotEmpty(t, issueInMongoDB.Issue.IssueID)
+				}
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestIssue_IsParticipant(t *testing.T) {
+	issue := &Issue{
+		CreatedBy: "creator",
+		Parties:   []string{"creator", "party"},
+	}
+
+	testCases := []struct {
+		name     string
+		userID   string
+		expected bool
+	}{
+		{
+			name:     "creator",
+			userID:   "creator",
+			expected: true,
+		},
+		{
+			name:     "party",
+			userID:   "party",
+			expected: true,
+		},
+		{
+			name:     "stranger",
+			userID:   "stranger",
+			expected: false,
+		},
+		{
+			name:     "empty-user-id",
+			userID:   "",
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, issue.IsParticipant(testCase.userID))
+		})
+	}
+}
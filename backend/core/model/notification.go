@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NotificationType is a type of notification
+type NotificationType string
+
+const (
+	NotificationTypeIssueInvited   NotificationType = "issue_invited"
+	NotificationTypeIssueClosed    NotificationType = "issue_closed"
+	NotificationTypeCommentCreated NotificationType = "comment_created"
+)
+
+// Notification is a notification sent to a user
+type Notification struct {
+	NotificationID string           `json:"notification_id" bson:"-"`
+	RecipientID    string           `json:"recipient_id" bson:"recipient_id"`
+	Type           NotificationType `json:"type" bson:"type"`
+	Payload        map[string]any   `json:"payload" bson:"payload"`
+	ReadAt         *time.Time       `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" bson:"updated_at"`
+}
+
+// IsRead checks if the notification is read
+func (notification *Notification) IsRead() bool {
+	return notification.ReadAt != nil
+}
+
+// NotificationInMongoDB is a notification in MongoDB
+type NotificationInMongoDB struct {
+	ID           bson.ObjectID `bson:"_id"`
+	Notification `bson:",inline"`
+}
+
+func NewNotificationInMongoDB(notification *Notification) (*NotificationInMongoDB, error) {
+	var objectID bson.ObjectID
+	var err error
+	if notification.NotificationID != "" {
+		objectID, err = bson.ObjectIDFromHex(notification.NotificationID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		objectID = bson.NewObjectID()
+	}
+	return &NotificationInMongoDB{
+		ID:           objectID,
+		Notification: *notification,
+	}, nil
+}
+
+func (notificationInMongoDB *NotificationInMongoDB) SetupDataFromDocument() error {
+	notificationInMongoDB.Notification.NotificationID = notificationInMongoDB.ID.Hex()
+	return nil
+}
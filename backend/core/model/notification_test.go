@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationIsRead(t *testing.T) {
+	readAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, (&Notification{}).IsRead())
+	assert.True(t, (&Notification{ReadAt: &readAt}).IsRead())
+}
+
+func TestNotificationInMongoDB(t *testing.T) {
+	testCases := []struct {
+		name         string
+		notification *Notification
+		isValid      bool
+	}{
+		{
+			name: "valid-notification",
+			notification: &Notification{
+				NotificationID: "5f4b8f1f9d1e4b0001f3f3b1",
+				RecipientID:    "000000000000000000000001",
+				Type:           NotificationTypeCommentCreated,
+				Payload:        map[string]any{"issue_id": "5f4b8f1f9d1e4b0001f3f3b2"},
+			},
+			isValid: true,
+		},
+		{
+			name: "empty-notification-id",
+			notification: &Notification{
+				NotificationID: "",
+				Type:           NotificationTypeIssueInvited,
+			},
+			isValid: true,
+		},
+		{
+			name: "invalid-notification-id",
+			notification: &Notification{
+				NotificationID: "invalid-id",
+				Type:           NotificationTypeIssueInvited,
+			},
+			isValid: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notificationInMongoDB, err := NewNotificationInMongoDB(testCase.notification)
+			if testCase.isValid {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if testCase.notification.NotificationID != "" {
+					exceptedNotificationID := testCase.notification.NotificationID
+
+					// Check if the notification ID is converted to ObjectID
+					assert.Equal(t, utils.ConvertStringToObjectID(exceptedNotificationID), notificationInMongoDB.ID)
+
+					// Check if the ObjectID is set to the notification
+					notificationInMongoDB.Notification.NotificationID = ""
+					notificationInMongoDB.SetupDataFromDocument()
+					assert.Equal(t, exceptedNotificationID, notificationInMongoDB.Notification.NotificationID)
+				} else {
+					// Check if the ObjectID is set to the notification
+					notificationInMongoDB.SetupDataFromDocument()
+					assert.NotEmpty(t, notificationInMongoDB.Notification.NotificationID)
+				}
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
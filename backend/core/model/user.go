@@ -6,18 +6,37 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// UserRole is a role of user for authorization
+type UserRole string
+
+const (
+	UserRoleUser     UserRole = "user"
+	UserRoleMediator UserRole = "mediator"
+	UserRoleAdmin    UserRole = "admin"
+)
+
 // User is a user
 type User struct {
-	UserID      string    `json:"user_id" bson:"-"`
-	FirebaseUID string    `json:"firebase_uid" bson:"firebase_uid"`
-	DisplayName string    `json:"display_name" bson:"display_name"`
-	Email       string    `json:"email" bson:"email"`
-	PhoneNumber string    `json:"phone_number" bson:"phone_number"`
-	PhotoURL    string    `json:"photo_url" bson:"photo_url"`
-	Disabled    bool      `json:"disabled" bson:"disabled"`
-	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
-	LastLoginAt time.Time `json:"last_login_at" bson:"last_login_at"`
+	UserID           string    `json:"user_id" bson:"-"`
+	FirebaseUID      string    `json:"firebase_uid" bson:"firebase_uid"`
+	DisplayName      string    `json:"display_name" bson:"display_name"`
+	Email            string    `json:"email" bson:"email"`
+	PhoneNumber      string    `json:"phone_number" bson:"phone_number"`
+	PhotoURL         string    `json:"photo_url" bson:"photo_url"`
+	Role             UserRole  `json:"role,omitempty" bson:"role,omitempty"`
+	Disabled         bool      `json:"disabled" bson:"disabled"`
+	DisabledSyncedAt time.Time `json:"disabled_synced_at" bson:"disabled_synced_at"`
+	CreatedAt        time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" bson:"updated_at"`
+	LastLoginAt      time.Time `json:"last_login_at" bson:"last_login_at"`
+}
+
+// GetRole returns the role of user, user without role is a normal user
+func (user *User) GetRole() UserRole {
+	if user.Role == "" {
+		return UserRoleUser
+	}
+	return user.Role
 }
 
 // UserInMongoDB is a user in MongoDB
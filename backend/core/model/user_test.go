@@ -73,3 +73,33 @@ func TestUserInMongoDB(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_GetRole(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     UserRole
+		expected UserRole
+	}{
+		{
+			name:     "empty-role",
+			role:     "",
+			expected: UserRoleUser,
+		},
+		{
+			name:     "mediator",
+			role:     UserRoleMediator,
+			expected: UserRoleMediator,
+		},
+		{
+			name:     "admin",
+			role:     UserRoleAdmin,
+			expected: UserRoleAdmin,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			user := &User{Role: testCase.role}
+			assert.Equal(t, testCase.expected, user.GetRole())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// EmailChannelConfig struct for email channel configuration
+type EmailChannelConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	From     string `yaml:"from"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// EmailChannel is a channel which sends notification by email through SMTP
+type EmailChannel struct {
+	cfg        *EmailChannelConfig
+	userDBRepo repository.UserDBRepository
+	sendMail   func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailChannel creates a new EmailChannel
+func NewEmailChannel(cfg *EmailChannelConfig, userDBRepo repository.UserDBRepository) *EmailChannel {
+	return &EmailChannel{
+		cfg:        cfg,
+		userDBRepo: userDBRepo,
+		sendMail:   smtp.SendMail,
+	}
+}
+
+// GetName returns the name of channel
+func (channel *EmailChannel) GetName() ChannelName {
+	return ChannelNameEmail
+}
+
+// Send sends notification to the email of recipient, recipient without email is skipped
+func (channel *EmailChannel) Send(ctx context.Context, notification *model.Notification) error {
+	user, err := channel.userDBRepo.GetUserByID(ctx, notification.RecipientID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return nil
+	}
+
+	// Set auth if username is configured
+	var auth smtp.Auth
+	if channel.cfg.Username != "" {
+		auth = smtp.PlainAuth("", channel.cfg.Username, channel.cfg.Password, channel.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(channel.cfg.Host, strconv.Itoa(channel.cfg.Port))
+	message := buildEmailMessage(channel.cfg.From, user.Email, notification)
+	if err := channel.sendMail(addr, auth, channel.cfg.From, []string{user.Email}, message); err != nil {
+		return NotificationError{
+			ErrType: NotificationErrorTypeDeliveryError,
+			Message: "failed to send email",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// buildEmailMessage builds a plain text email message of notification
+func buildEmailMessage(from, to string, notification *model.Notification) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "From: %s\r\n", from)
+	fmt.Fprintf(&builder, "To: %s\r\n", to)
+	fmt.Fprintf(&builder, "Subject: [Mediation Platform] %s\r\n", notification.Type)
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+
+	// Write payload in stable order
+	keys := make([]string, 0, len(notification.Payload))
+	for key := range notification.Payload {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&builder, "%s: %v\r\n", key, notification.Payload[key])
+	}
+	return []byte(builder.String())
+}
@@ -0,0 +1,104 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+func TestEmailChannel(t *testing.T) {
+	cfg := &EmailChannelConfig{
+		Host: "localhost",
+		Port: 1025,
+		From: "noreply@mediation-platform.com",
+	}
+
+	testCases := []struct {
+		name        string
+		user        *model.User
+		getUserErr  error
+		sendMailErr error
+		isSent      bool
+		isErr       bool
+	}{
+		{
+			name:   "success",
+			user:   &model.User{UserID: "test-user-id", Email: "test@mediation-platform.com"},
+			isSent: true,
+		},
+		{
+			name: "no-email",
+			user: &model.User{UserID: "test-user-id"},
+		},
+		{
+			name:       "user-not-found",
+			getUserErr: repository.RepositoryError{ErrType: repository.RepositoryErrorTypeRecordNotFound},
+			isErr:      true,
+		},
+		{
+			name:        "send-mail-error",
+			user:        &model.User{UserID: "test-user-id", Email: "test@mediation-platform.com"},
+			sendMailErr: fmt.Errorf("test error"),
+			isSent:      true,
+			isErr:       true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			channel := NewEmailChannel(cfg, &MockUserDBRepository{
+				GetUserByIDFunc: func(ctx context.Context, userID string) (*model.User, error) {
+					assert.Equal(t, "test-user-id", userID)
+					return testCase.user, testCase.getUserErr
+				},
+			})
+			assert.Equal(t, ChannelNameEmail, channel.GetName())
+
+			isSent := false
+			channel.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+				isSent = true
+				assert.Equal(t, "localhost:1025", addr)
+				assert.Nil(t, a)
+				assert.Equal(t, cfg.From, from)
+				assert.Equal(t, []string{testCase.user.Email}, to)
+				assert.Contains(t, string(msg), "Subject: [Mediation Platform] comment_created\r\n")
+				assert.Contains(t, string(msg), "issue_id: test-issue-id\r\n")
+				return testCase.sendMailErr
+			}
+
+			err := channel.Send(context.Background(), &model.Notification{
+				RecipientID: "test-user-id",
+				Type:        model.NotificationTypeCommentCreated,
+				Payload:     map[string]any{"issue_id": "test-issue-id"},
+			})
+			assert.Equal(t, testCase.isSent, isSent)
+			if testCase.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildEmailMessage(t *testing.T) {
+	message := buildEmailMessage("from@test.com", "to@test.com", &model.Notification{
+		Type: model.NotificationTypeIssueInvited,
+		Payload: map[string]any{
+			"title":    "test-title",
+			"issue_id": "test-issue-id",
+		},
+	})
+	assert.Equal(t, "From: from@test.com\r\n"+
+		"To: to@test.com\r\n"+
+		"Subject: [Mediation Platform] issue_invited\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"\r\n"+
+		"issue_id: test-issue-id\r\n"+
+		"title: test-title\r\n", string(message))
+}
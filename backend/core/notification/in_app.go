@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// InAppChannel is a channel which stores notification to the inbox of recipient
+type InAppChannel struct {
+	notificationDBRepo repository.NotificationDBRepository
+}
+
+// NewInAppChannel creates a new InAppChannel
+func NewInAppChannel(notificationDBRepo repository.NotificationDBRepository) *InAppChannel {
+	return &InAppChannel{
+		notificationDBRepo: notificationDBRepo,
+	}
+}
+
+// GetName returns the name of channel
+func (channel *InAppChannel) GetName() ChannelName {
+	return ChannelNameInApp
+}
+
+// Send stores notification and sets the notification ID back
+func (channel *InAppChannel) Send(ctx context.Context, notification *model.Notification) error {
+	notificationID, err := channel.notificationDBRepo.CreateNotification(ctx, notification)
+	if err != nil {
+		return err
+	}
+	notification.NotificationID = notificationID
+	return nil
+}
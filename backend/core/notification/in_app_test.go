@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+func TestInAppChannel(t *testing.T) {
+	testCases := []struct {
+		name      string
+		createErr error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:      "db-error",
+			createErr: repository.RepositoryError{ErrType: repository.RepositoryErrorTypeServerError},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			channel := NewInAppChannel(&MockNotificationDBRepository{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) (string, error) {
+					if testCase.createErr != nil {
+						return "", testCase.createErr
+					}
+					return "000000000000000000000001", nil
+				},
+			})
+			assert.Equal(t, ChannelNameInApp, channel.GetName())
+
+			notification := &model.Notification{RecipientID: "test-recipient-id"}
+			err := channel.Send(context.Background(), notification)
+			if testCase.createErr != nil {
+				assert.Equal(t, testCase.createErr, err)
+				assert.Empty(t, notification.NotificationID)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "000000000000000000000001", notification.NotificationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+// ChannelName is a name of notification delivery channel
+type ChannelName string
+
+const (
+	ChannelNameInApp   ChannelName = "in_app"
+	ChannelNameEmail   ChannelName = "email"
+	ChannelNameWebhook ChannelName = "webhook"
+)
+
+// DefaultChannelNames are used for notification types without route
+var DefaultChannelNames = []ChannelName{ChannelNameInApp}
+
+// InlineChannelNames are channels which persist notification, they are sent in Notify, other channels are delivered
+// by background workers after that
+var InlineChannelNames = []ChannelName{ChannelNameInApp}
+
+// Default values of delivery
+const (
+	DefaultDeliveryWorkers   = 4
+	DefaultDeliveryQueueSize = 1000
+	DefaultDeliveryTimeout   = 30 * time.Second
+)
+
+// NotificationConfig struct for notification configuration
+type NotificationConfig struct {
+	Channels        ChannelsConfig                           `yaml:"channels"`
+	Routes          map[model.NotificationType][]ChannelName `yaml:"routes"`
+	DefaultChannels []ChannelName                            `yaml:"default_channels"`
+	Delivery        DeliveryConfig                           `yaml:"delivery"`
+}
+
+// DeliveryConfig struct for background delivery configuration, Workers deliver queued notifications concurrently,
+// a notification is not queued if QueueSize notifications are waiting, and Timeout limits every delivery
+type DeliveryConfig struct {
+	Workers   int           `yaml:"workers"`
+	QueueSize int           `yaml:"queue_size"`
+	Timeout   time.Duration `yaml:"timeout"`
+}
+
+// ChannelsConfig struct for optional channels configuration, in-app channel is always enabled
+type ChannelsConfig struct {
+	Email   *EmailChannelConfig   `yaml:"email"`
+	Webhook *WebhookChannelConfig `yaml:"webhook"`
+}
+
+type NotificationErrorType string
+
+const (
+	NotificationErrorTypeServerError   NotificationErrorType = "server_error"
+	NotificationErrorTypeConfigError   NotificationErrorType = "config_error"
+	NotificationErrorTypeDeliveryError NotificationErrorType = "delivery_error"
+)
+
+var NotificationErrorDefaultMessages = map[NotificationErrorType]string{
+	NotificationErrorTypeServerError:   "server error",
+	NotificationErrorTypeConfigError:   "config error",
+	NotificationErrorTypeDeliveryError: "delivery error",
+}
+
+// NotificationError struct for notification error
+type NotificationError struct {
+	ErrType NotificationErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e NotificationError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := NotificationErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e NotificationError) Unwrap() error {
+	return e.Err
+}
+
+// Notifier interface for sending notification, Close stops accepting notifications and waits for pending deliveries
+type Notifier interface {
+	Notify(ctx context.Context, notification *model.Notification) error
+	Close(ctx context.Context) error
+}
+
+// Channel interface for notification delivery channel
+type Channel interface {
+	GetName() ChannelName
+	Send(ctx context.Context, notification *model.Notification) error
+}
+
+// delivery is a notification queued to be sent to the channel
+type delivery struct {
+	ctx          context.Context
+	channel      Channel
+	notification *model.Notification
+}
+
+// Dispatcher is a notifier which sends notification to channels routed by notification type, inline channels are sent
+// in Notify and the others are queued for background workers, so slow channels do not block the caller
+type Dispatcher struct {
+	channels        map[ChannelName]Channel
+	routes          map[model.NotificationType][]ChannelName
+	defaultChannels []ChannelName
+	timeout         time.Duration
+
+	mu         sync.RWMutex
+	closed     bool
+	deliveries chan delivery
+	workers    sync.WaitGroup
+}
+
+// NewDispatcher creates a new Dispatcher and starts its delivery workers
+func NewDispatcher(channels []Channel, routes map[model.NotificationType][]ChannelName, defaultChannels []ChannelName, deliveryCfg *DeliveryConfig) (*Dispatcher, error) {
+	if deliveryCfg == nil {
+		deliveryCfg = &DeliveryConfig{}
+	}
+	workers := deliveryCfg.Workers
+	if workers <= 0 {
+		workers = DefaultDeliveryWorkers
+	}
+	queueSize := deliveryCfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultDeliveryQueueSize
+	}
+	timeout := deliveryCfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultDeliveryTimeout
+	}
+
+	dispatcher := &Dispatcher{
+		channels:        make(map[ChannelName]Channel, len(channels)),
+		routes:          routes,
+		defaultChannels: defaultChannels,
+		timeout:         timeout,
+		deliveries:      make(chan delivery, queueSize),
+	}
+	for _, channel := range channels {
+		dispatcher.channels[channel.GetName()] = channel
+	}
+	if len(dispatcher.defaultChannels) == 0 {
+		dispatcher.defaultChannels = DefaultChannelNames
+	}
+
+	// Check if all routed channels are enabled
+	routedChannelNames := append([]ChannelName{}, dispatcher.defaultChannels...)
+	for _, channelNames := range dispatcher.routes {
+		routedChannelNames = append(routedChannelNames, channelNames...)
+	}
+	for _, channelName := range routedChannelNames {
+		if _, ok := dispatcher.channels[channelName]; !ok {
+			return nil, NotificationError{
+				ErrType: NotificationErrorTypeConfigError,
+				Message: fmt.Sprintf("channel %q is not enabled", channelName),
+			}
+		}
+	}
+
+	// Start delivery workers
+	for range workers {
+		dispatcher.workers.Add(1)
+		go dispatcher.deliver()
+	}
+	return dispatcher, nil
+}
+
+// Notify sends notification to inline channels routed by notification type, then queues it for the other channels so
+// queued deliveries carry the ID of persisted notification, values of ctx are kept by queued deliveries after Notify
+// returns, so ctx should be the request context rather than a pooled one like gin context
+func (dispatcher *Dispatcher) Notify(ctx context.Context, notification *model.Notification) error {
+	channelNames, ok := dispatcher.routes[notification.Type]
+	if !ok {
+		channelNames = dispatcher.defaultChannels
+	}
+
+	// Send to inline channels, failure of one channel does not stop the others
+	var errs []error
+	queuedChannels := []Channel{}
+	for _, channelName := range channelNames {
+		channel := dispatcher.channels[channelName]
+		if !slices.Contains(InlineChannelNames, channelName) {
+			queuedChannels = append(queuedChannels, channel)
+			continue
+		}
+		if err := channel.Send(ctx, notification); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channelName, err))
+		}
+	}
+
+	// Queue for the other channels, deliveries outlive the caller
+	for _, channel := range queuedChannels {
+		if err := dispatcher.enqueue(delivery{
+			ctx:          context.WithoutCancel(ctx),
+			channel:      channel,
+			notification: notification,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return NotificationError{
+			ErrType: NotificationErrorTypeDeliveryError,
+			Err:     errors.Join(errs...),
+		}
+	}
+	return nil
+}
+
+// enqueue queues the delivery without blocking, it fails if the queue is full or the dispatcher is closed
+func (dispatcher *Dispatcher) enqueue(d delivery) error {
+	dispatcher.mu.RLock()
+	defer dispatcher.mu.RUnlock()
+	if dispatcher.closed {
+		return NotificationError{
+			ErrType: NotificationErrorTypeServerError,
+			Message: "notifier is closed",
+		}
+	}
+	select {
+	case dispatcher.deliveries <- d:
+		return nil
+	default:
+		return NotificationError{
+			ErrType: NotificationErrorTypeServerError,
+			Message: "delivery queue is full",
+		}
+	}
+}
+
+// deliver sends queued notifications until the queue is closed and drained, failures are logged
+func (dispatcher *Dispatcher) deliver() {
+	defer dispatcher.workers.Done()
+	for d := range dispatcher.deliveries {
+		ctx, cancel := context.WithTimeout(d.ctx, dispatcher.timeout)
+		if err := d.channel.Send(ctx, d.notification); err != nil {
+			slog.ErrorContext(ctx, "failed to deliver notification",
+				"channel", d.channel.GetName(),
+				"notification_id", d.notification.NotificationID,
+				"recipient_id", d.notification.RecipientID,
+				"error", err.Error(),
+			)
+		}
+		cancel()
+	}
+}
+
+// Close stops accepting notifications and waits until queued notifications are delivered or ctx is done
+func (dispatcher *Dispatcher) Close(ctx context.Context) error {
+	dispatcher.mu.Lock()
+	if !dispatcher.closed {
+		dispatcher.closed = true
+		close(dispatcher.deliveries)
+	}
+	dispatcher.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		dispatcher.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return NotificationError{
+			ErrType: NotificationErrorTypeServerError,
+			Message: "failed to deliver queued notifications",
+			Err:     ctx.Err(),
+		}
+	}
+}
+
+// NewNotifier creates a new notifier with channels enabled in config
+func NewNotifier(cfg *NotificationConfig, notificationDBRepo repository.NotificationDBRepository, userDBRepo repository.UserDBRepository) (Notifier, error) {
+	if cfg == nil {
+		cfg = &NotificationConfig{}
+	}
+
+	channels := []Channel{NewInAppChannel(notificationDBRepo)}
+	if cfg.Channels.Email != nil {
+		channels = append(channels, NewEmailChannel(cfg.Channels.Email, userDBRepo))
+	}
+	if cfg.Channels.Webhook != nil {
+		webhookChannel, err := NewWebhookChannel(cfg.Channels.Webhook)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, webhookChannel)
+	}
+	return NewDispatcher(channels, cfg.Routes, cfg.DefaultChannels, &cfg.Delivery)
+}
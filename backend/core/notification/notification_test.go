@@ -0,0 +1,282 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/repository"
+)
+
+type MockNotificationDBRepository struct {
+	repository.NotificationDBRepository
+	CreateNotificationFunc func(ctx context.Context, notification *model.Notification) (string, error)
+}
+
+func (repo *MockNotificationDBRepository) CreateNotification(ctx context.Context, notification *model.Notification) (string, error) {
+	return repo.CreateNotificationFunc(ctx, notification)
+}
+
+type MockUserDBRepository struct {
+	repository.UserDBRepository
+	GetUserByIDFunc func(ctx context.Context, userID string) (*model.User, error)
+}
+
+func (repo *MockUserDBRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	return repo.GetUserByIDFunc(ctx, userID)
+}
+
+type MockChannel struct {
+	Name     ChannelName
+	SendFunc func(ctx context.Context, notification *model.Notification) error
+}
+
+func (channel *MockChannel) GetName() ChannelName {
+	return channel.Name
+}
+
+func (channel *MockChannel) Send(ctx context.Context, notification *model.Notification) error {
+	return channel.SendFunc(ctx, notification)
+}
+
+func TestNotificationError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  NotificationErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "delivery-error/no-message",
+			errType:  NotificationErrorTypeDeliveryError,
+			err:      fmt.Errorf("test error"),
+			expected: "delivery error: test error",
+		},
+		{
+			name:     "config-error/with-message",
+			errType:  NotificationErrorTypeConfigError,
+			message:  "test message",
+			expected: "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ne := NotificationError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, ne.Error())
+			assert.Equal(t, testCase.err, ne.Unwrap())
+		})
+	}
+}
+
+func TestDispatcherNotify(t *testing.T) {
+	routes := map[model.NotificationType][]ChannelName{
+		model.NotificationTypeIssueInvited: {ChannelNameInApp, ChannelNameEmail},
+		model.NotificationTypeIssueClosed:  {},
+	}
+
+	testCases := []struct {
+		name             string
+		notificationType model.NotificationType
+		failedChannels   []ChannelName
+		expectedChannels []ChannelName
+		isErr            bool
+	}{
+		{
+			name:             "routed",
+			notificationType: model.NotificationTypeIssueInvited,
+			expectedChannels: []ChannelName{ChannelNameInApp, ChannelNameEmail},
+		},
+		{
+			name:             "routed-to-no-channel",
+			notificationType: model.NotificationTypeIssueClosed,
+			expectedChannels: []ChannelName{},
+		},
+		{
+			name:             "default",
+			notificationType: model.NotificationTypeCommentCreated,
+			expectedChannels: []ChannelName{ChannelNameInApp},
+		},
+		{
+			name:             "inline-channel-failed",
+			notificationType: model.NotificationTypeIssueInvited,
+			failedChannels:   []ChannelName{ChannelNameInApp},
+			expectedChannels: []ChannelName{ChannelNameInApp, ChannelNameEmail},
+			isErr:            true,
+		},
+		{
+			name:             "queued-channel-failed",
+			notificationType: model.NotificationTypeIssueInvited,
+			failedChannels:   []ChannelName{ChannelNameEmail},
+			expectedChannels: []ChannelName{ChannelNameInApp, ChannelNameEmail},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var mu sync.Mutex
+			sentChannels := []ChannelName{}
+			channels := []Channel{}
+			for _, channelName := range []ChannelName{ChannelNameInApp, ChannelNameEmail} {
+				channels = append(channels, &MockChannel{
+					Name: channelName,
+					SendFunc: func(ctx context.Context, notification *model.Notification) error {
+						mu.Lock()
+						defer mu.Unlock()
+						sentChannels = append(sentChannels, channelName)
+						for _, failedChannel := range testCase.failedChannels {
+							if failedChannel == channelName {
+								return fmt.Errorf("test error")
+							}
+						}
+						return nil
+					},
+				})
+			}
+			dispatcher, err := NewDispatcher(channels, routes, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Queued deliveries are sent before close returns
+			err = dispatcher.Notify(context.Background(), &model.Notification{Type: testCase.notificationType})
+			assert.NoError(t, dispatcher.Close(context.Background()))
+			assert.Equal(t, testCase.expectedChannels, sentChannels)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &NotificationError{})
+				assert.Equal(t, NotificationErrorTypeDeliveryError, err.(NotificationError).ErrType)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDispatcherNotify_Queued(t *testing.T) {
+	routes := map[model.NotificationType][]ChannelName{
+		model.NotificationTypeIssueInvited: {ChannelNameInApp, ChannelNameWebhook},
+	}
+
+	// Webhook is blocked until released, so Notify returns before it is delivered
+	release := make(chan struct{})
+	delivered := make(chan *model.Notification, 2)
+	channels := []Channel{
+		&MockChannel{
+			Name: ChannelNameInApp,
+			SendFunc: func(ctx context.Context, notification *model.Notification) error {
+				notification.NotificationID = "test-notification-id"
+				return nil
+			},
+		},
+		&MockChannel{
+			Name: ChannelNameWebhook,
+			SendFunc: func(ctx context.Context, notification *model.Notification) error {
+				<-release
+				assert.NoError(t, ctx.Err())
+				delivered <- notification
+				return nil
+			},
+		},
+	}
+	dispatcher, err := NewDispatcher(channels, routes, nil, &DeliveryConfig{Workers: 1, QueueSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deliveries are detached from the caller context
+	ctx, cancel := context.WithCancel(context.Background())
+	err = dispatcher.Notify(ctx, &model.Notification{Type: model.NotificationTypeIssueInvited})
+	assert.NoError(t, err)
+	cancel()
+
+	// One delivery is in progress and one is queued, the queue is full then
+	assert.Eventually(t, func() bool {
+		return len(dispatcher.deliveries) == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, dispatcher.Notify(context.Background(), &model.Notification{Type: model.NotificationTypeIssueInvited}))
+	err = dispatcher.Notify(context.Background(), &model.Notification{Type: model.NotificationTypeIssueInvited})
+	assert.ErrorContains(t, err, "delivery queue is full")
+
+	// Close times out while deliveries are blocked
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer timeoutCancel()
+	assert.ErrorIs(t, dispatcher.Close(timeoutCtx), context.DeadlineExceeded)
+
+	// Closed dispatcher does not accept notifications, queued ones are still delivered
+	err = dispatcher.Notify(context.Background(), &model.Notification{Type: model.NotificationTypeIssueInvited})
+	assert.ErrorContains(t, err, "notifier is closed")
+	close(release)
+	assert.NoError(t, dispatcher.Close(context.Background()))
+	assert.Len(t, delivered, 2)
+	assert.Equal(t, "test-notification-id", (<-delivered).NotificationID)
+}
+
+func TestNewNotifier(t *testing.T) {
+	testCases := []struct {
+		name  string
+		cfg   *NotificationConfig
+		isErr bool
+	}{
+		{
+			name: "nil-config",
+			cfg:  nil,
+		},
+		{
+			name: "all-channels",
+			cfg: &NotificationConfig{
+				Channels: ChannelsConfig{
+					Email:   &EmailChannelConfig{Host: "localhost", Port: 1025},
+					Webhook: &WebhookChannelConfig{URL: "http://localhost:8081"},
+				},
+				Routes: map[model.NotificationType][]ChannelName{
+					model.NotificationTypeIssueInvited: {ChannelNameInApp, ChannelNameEmail, ChannelNameWebhook},
+				},
+			},
+		},
+		{
+			name: "routed-channel-not-enabled",
+			cfg: &NotificationConfig{
+				Routes: map[model.NotificationType][]ChannelName{
+					model.NotificationTypeIssueInvited: {ChannelNameEmail},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "default-channel-not-enabled",
+			cfg: &NotificationConfig{
+				DefaultChannels: []ChannelName{ChannelNameWebhook},
+			},
+			isErr: true,
+		},
+		{
+			name: "webhook-without-url",
+			cfg: &NotificationConfig{
+				Channels: ChannelsConfig{
+					Webhook: &WebhookChannelConfig{},
+				},
+			},
+			isErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notifier, err := NewNotifier(testCase.cfg, &MockNotificationDBRepository{}, &MockUserDBRepository{})
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &NotificationError{})
+				assert.Equal(t, NotificationErrorTypeConfigError, err.(NotificationError).ErrType)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, notifier)
+			}
+		})
+	}
+}
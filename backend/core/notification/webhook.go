@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+const (
+	DefaultWebhookTimeout      = 5 * time.Second
+	WebhookSignatureHeaderName = "X-Mediation-Signature"
+)
+
+// WebhookChannelConfig struct for webhook channel configuration
+type WebhookChannelConfig struct {
+	URL     string        `yaml:"url"`
+	Secret  string        `yaml:"secret"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// WebhookChannel is a channel which posts notification as JSON to the webhook URL
+type WebhookChannel struct {
+	cfg    *WebhookChannelConfig
+	client *http.Client
+}
+
+// NewWebhookChannel creates a new WebhookChannel
+func NewWebhookChannel(cfg *WebhookChannelConfig) (*WebhookChannel, error) {
+	if cfg.URL == "" {
+		return nil, NotificationError{
+			ErrType: NotificationErrorTypeConfigError,
+			Message: "webhook URL is required",
+		}
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	return &WebhookChannel{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// GetName returns the name of channel
+func (channel *WebhookChannel) GetName() ChannelName {
+	return ChannelNameWebhook
+}
+
+// Send posts notification to the webhook URL, the body is signed with HMAC-SHA256 if secret is configured
+func (channel *WebhookChannel) Send(ctx context.Context, notification *model.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return NotificationError{
+			ErrType: NotificationErrorTypeServerError,
+			Message: "failed to marshal notification",
+			Err:     err,
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return NotificationError{
+			ErrType: NotificationErrorTypeServerError,
+			Message: "failed to create webhook request",
+			Err:     err,
+		}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if channel.cfg.Secret != "" {
+		request.Header.Set(WebhookSignatureHeaderName, signWebhookBody(channel.cfg.Secret, body))
+	}
+
+	response, err := channel.client.Do(request)
+	if err != nil {
+		return NotificationError{
+			ErrType: NotificationErrorTypeDeliveryError,
+			Message: "failed to post webhook",
+			Err:     err,
+		}
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return NotificationError{
+			ErrType: NotificationErrorTypeDeliveryError,
+			Message: fmt.Sprintf("webhook responded with status code %d", response.StatusCode),
+		}
+	}
+	return nil
+}
+
+// signWebhookBody signs body with HMAC-SHA256
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+func TestWebhookChannel(t *testing.T) {
+	testCases := []struct {
+		name       string
+		secret     string
+		statusCode int
+		isErr      bool
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "success/signed",
+			secret:     "test-secret",
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "error-status-code",
+			statusCode: http.StatusInternalServerError,
+			isErr:      true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notification := &model.Notification{
+				NotificationID: "test-notification-id",
+				RecipientID:    "test-recipient-id",
+				Type:           model.NotificationTypeIssueClosed,
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				if testCase.secret != "" {
+					assert.Equal(t, signWebhookBody(testCase.secret, body), r.Header.Get(WebhookSignatureHeaderName))
+				} else {
+					assert.Empty(t, r.Header.Get(WebhookSignatureHeaderName))
+				}
+				received := &model.Notification{}
+				assert.NoError(t, json.Unmarshal(body, received))
+				assert.Equal(t, notification.NotificationID, received.NotificationID)
+				w.WriteHeader(testCase.statusCode)
+			}))
+			defer server.Close()
+
+			channel, err := NewWebhookChannel(&WebhookChannelConfig{URL: server.URL, Secret: testCase.secret})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, ChannelNameWebhook, channel.GetName())
+
+			err = channel.Send(context.Background(), notification)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &NotificationError{})
+				assert.Equal(t, NotificationErrorTypeDeliveryError, err.(NotificationError).ErrType)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignWebhookBody(t *testing.T) {
+	assert.Equal(t, "sha256=dc46983557fea127b43af721467eb9b3fde2338fe3e14f51952aa8478c13d355", signWebhookBody("secret", []byte("body")))
+}
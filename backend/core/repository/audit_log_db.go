@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/STLeee/mediation-platform/backend/core/db"
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// AuditLogDBRepository is an interface for audit log repository in database
+type AuditLogDBRepository interface {
+	CreateAuditLog(ctx context.Context, auditLog *model.AuditLog) (string, error)
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter, pageOptions *PageOptions) (*Page[*model.AuditLog], error)
+}
+
+// AuditLogFilter is a filter of audit logs, empty fields are not filtered
+type AuditLogFilter struct {
+	ActorID  string
+	TargetID string
+	Action   model.AuditAction
+}
+
+// toMap converts the filter to filter of MongoDB
+func (filter *AuditLogFilter) toMap() map[string]any {
+	filterMap := map[string]any{}
+	if filter == nil {
+		return filterMap
+	}
+	if filter.ActorID != "" {
+		filterMap["actor_id"] = filter.ActorID
+	}
+	if filter.TargetID != "" {
+		filterMap["target_id"] = filter.TargetID
+	}
+	if filter.Action != "" {
+		filterMap["action"] = filter.Action
+	}
+	return filterMap
+}
+
+// AuditLogMongoDBRepository is a MongoDB repository for audit log
+type AuditLogMongoDBRepository struct {
+	TypedMongoDBRepository[model.AuditLogInMongoDB, *model.AuditLogInMongoDB]
+}
+
+// NewAuditLogMongoDBRepository creates a new AuditLogMongoDBRepository
+func NewAuditLogMongoDBRepository(mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *AuditLogMongoDBRepository {
+	return &AuditLogMongoDBRepository{
+		TypedMongoDBRepository: *NewTypedMongoDBRepository[model.AuditLogInMongoDB](mongoDB, cfg),
+	}
+}
+
+// CreateAuditLog creates an audit log
+func (repo *AuditLogMongoDBRepository) CreateAuditLog(ctx context.Context, auditLog *model.AuditLog) (string, error) {
+	// Set created at, audit logs are never updated
+	auditLog.CreatedAt = time.Now()
+
+	// Insert one
+	auditLogInMongoDB, err := model.NewAuditLogInMongoDB(auditLog)
+	if err != nil {
+		return "", repo.NewInvalidIDError(err)
+	}
+	return repo.Insert(ctx, auditLogInMongoDB)
+}
+
+// GetAuditLogByID gets an audit log by audit log ID
+func (repo *AuditLogMongoDBRepository) GetAuditLogByID(ctx context.Context, auditLogID string) (*model.AuditLog, error) {
+	auditLogInMongoDB, err := repo.Get(ctx, auditLogID)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLogInMongoDB.AuditLog, nil
+}
+
+// ListAuditLogs lists a page of audit logs by filter
+func (repo *AuditLogMongoDBRepository) ListAuditLogs(ctx context.Context, filter *AuditLogFilter, pageOptions *PageOptions) (*Page[*model.AuditLog], error) {
+	page, err := repo.FindMany(ctx, filter.toMap(), pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(page, func(auditLogInMongoDB *model.AuditLogInMongoDB) *model.AuditLog {
+		return &auditLogInMongoDB.AuditLog
+	}), nil
+}
+
+// DeleteAuditLogByID deletes an audit log by audit log ID
+func (repo *AuditLogMongoDBRepository) DeleteAuditLogByID(ctx context.Context, auditLogID string) error {
+	return repo.Delete(ctx, auditLogID)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func TestAuditLogMongoDBRepository_CreateAndGetAuditLog(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		auditLog    *model.AuditLog
+		expectedErr error
+	}{
+		{
+			name: "insert-audit-log",
+			auditLog: &model.AuditLog{
+				ActorID:  localUsers[0].UserID,
+				Action:   model.AuditActionUserDisabled,
+				TargetID: localUsers[1].UserID,
+			},
+		},
+		{
+			name: "insert-audit-log/invalid-audit-log-id",
+			auditLog: &model.AuditLog{
+				AuditLogID: "invalid-id",
+				Action:     model.AuditActionUserEnabled,
+			},
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidID,
+				Database:   LocalRepositoryConfigs.AuditLogDB.Database,
+				Collection: LocalRepositoryConfigs.AuditLogDB.Collection,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			auditLogID, err := auditLogMongoDBRepository.CreateAuditLog(ctx, testCase.auditLog)
+			if testCase.expectedErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Defer clean up
+				defer func() {
+					err := auditLogMongoDBRepository.DeleteAuditLogByID(ctx, auditLogID)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}()
+
+				// Check if the audit log is created
+				auditLog, err := auditLogMongoDBRepository.GetAuditLogByID(ctx, auditLogID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, auditLogID, auditLog.AuditLogID)
+				assert.Equal(t, testCase.auditLog.ActorID, auditLog.ActorID)
+				assert.Equal(t, testCase.auditLog.Action, auditLog.Action)
+				assert.Equal(t, testCase.auditLog.TargetID, auditLog.TargetID)
+				assert.True(t, utils.SimplyValidTimestamp(auditLog.CreatedAt))
+			} else {
+				assertError(t, testCase.expectedErr, err)
+				assert.Empty(t, auditLogID)
+			}
+		})
+	}
+}
+
+func TestAuditLogMongoDBRepository_ListAuditLogs(t *testing.T) {
+	ctx := context.Background()
+
+	// Insert audit logs, the latter is newer
+	auditLogs := []*model.AuditLog{
+		{ActorID: localUsers[0].UserID, Action: model.AuditActionUserDisabled, TargetID: localUsers[1].UserID},
+		{ActorID: localUsers[0].UserID, Action: model.AuditActionUserEnabled, TargetID: localUsers[1].UserID},
+		{ActorID: localUsers[0].UserID, Action: model.AuditActionUserForceLogout, TargetID: localUsers[2].UserID},
+	}
+	auditLogIDs := []string{}
+	for _, auditLog := range auditLogs {
+		auditLogID, err := auditLogMongoDBRepository.CreateAuditLog(ctx, auditLog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		auditLogIDs = append(auditLogIDs, auditLogID)
+	}
+	defer func() {
+		for _, auditLogID := range auditLogIDs {
+			if err := auditLogMongoDBRepository.DeleteAuditLogByID(ctx, auditLogID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+
+	testCases := []struct {
+		name                string
+		filter              *AuditLogFilter
+		pageOptions         *PageOptions
+		expectedAuditLogIDs []string
+		expectedCursor      bool
+	}{
+		{
+			name:                "list-by-target",
+			filter:              &AuditLogFilter{TargetID: localUsers[1].UserID},
+			pageOptions:         &PageOptions{Descending: true},
+			expectedAuditLogIDs: []string{auditLogIDs[1], auditLogIDs[0]},
+		},
+		{
+			name:                "list-by-actor/paginated",
+			filter:              &AuditLogFilter{ActorID: localUsers[0].UserID},
+			pageOptions:         &PageOptions{Limit: 2, Descending: true},
+			expectedAuditLogIDs: []string{auditLogIDs[2], auditLogIDs[1]},
+			expectedCursor:      true,
+		},
+		{
+			name:                "list-by-action",
+			filter:              &AuditLogFilter{TargetID: localUsers[2].UserID, Action: model.AuditActionUserForceLogout},
+			expectedAuditLogIDs: []string{auditLogIDs[2]},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			page, err := auditLogMongoDBRepository.ListAuditLogs(ctx, testCase.filter, testCase.pageOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := []string{}
+			for _, auditLog := range page.Items {
+				ids = append(ids, auditLog.AuditLogID)
+			}
+			assert.Equal(t, testCase.expectedAuditLogIDs, ids)
+			assert.Equal(t, testCase.expectedCursor, page.NextCursor != "")
+		})
+	}
+}
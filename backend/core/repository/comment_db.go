@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/STLeee/mediation-platform/backend/core/db"
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// CommentDBRepository is an interface for comment repository in database
+type CommentDBRepository interface {
+	CreateComment(ctx context.Context, comment *model.Comment) (string, error)
+	GetCommentByID(ctx context.Context, commentID string) (*model.Comment, error)
+	ListCommentsByIssueID(ctx context.Context, issueID string, pageOptions *PageOptions) (*Page[*model.Comment], error)
+	ListLatestCommentsByIssueID(ctx context.Context, issueID string, limit int64) ([]*model.Comment, error)
+	EditCommentByID(ctx context.Context, commentID string, body string) error
+	SoftDeleteCommentByID(ctx context.Context, commentID string) error
+}
+
+// CommentMongoDBRepository is a MongoDB repository for comment
+type CommentMongoDBRepository struct {
+	TypedMongoDBRepository[model.CommentInMongoDB, *model.CommentInMongoDB]
+}
+
+// NewCommentMongoDBRepository creates a new CommentMongoDBRepository
+func NewCommentMongoDBRepository(mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *CommentMongoDBRepository {
+	return &CommentMongoDBRepository{
+		TypedMongoDBRepository: *NewTypedMongoDBRepository[model.CommentInMongoDB](mongoDB, cfg),
+	}
+}
+
+// CreateComment creates a comment
+func (repo *CommentMongoDBRepository) CreateComment(ctx context.Context, comment *model.Comment) (string, error) {
+	// Set created at and updated at
+	now := time.Now()
+	comment.CreatedAt = now
+	comment.UpdatedAt = now
+
+	// Insert one
+	commentInMongoDB, err := model.NewCommentInMongoDB(comment)
+	if err != nil {
+		return "", repo.NewInvalidIDError(err)
+	}
+	return repo.Insert(ctx, commentInMongoDB)
+}
+
+// GetCommentByID gets a comment by comment ID
+func (repo *CommentMongoDBRepository) GetCommentByID(ctx context.Context, commentID string) (*model.Comment, error) {
+	commentInMongoDB, err := repo.Get(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return &commentInMongoDB.Comment, nil
+}
+
+// ListCommentsByIssueID lists a page of comments of the issue
+func (repo *CommentMongoDBRepository) ListCommentsByIssueID(ctx context.Context, issueID string, pageOptions *PageOptions) (*Page[*model.Comment], error) {
+	filter := map[string]any{
+		"issue_id": issueID,
+	}
+	page, err := repo.FindMany(ctx, filter, pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(page, func(commentInMongoDB *model.CommentInMongoDB) *model.Comment {
+		return &commentInMongoDB.Comment
+	}), nil
+}
+
+// ListLatestCommentsByIssueID lists the latest comments of the issue, newest first
+func (repo *CommentMongoDBRepository) ListLatestCommentsByIssueID(ctx context.Context, issueID string, limit int64) ([]*model.Comment, error) {
+	filter := map[string]any{
+		"issue_id": issueID,
+	}
+	findOptions := &FindOptions{
+		Sort:  bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		Limit: limit,
+	}
+	commentsInMongoDB, err := repo.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*model.Comment, 0, len(commentsInMongoDB))
+	for _, commentInMongoDB := range commentsInMongoDB {
+		comments = append(comments, &commentInMongoDB.Comment)
+	}
+	return comments, nil
+}
+
+// EditCommentByID replaces the body of a comment and marks it as edited
+func (repo *CommentMongoDBRepository) EditCommentByID(ctx context.Context, commentID string, body string) error {
+	return repo.Update(ctx, commentID, map[string]any{
+		"body":   body,
+		"edited": true,
+	})
+}
+
+// SoftDeleteCommentByID clears the body of a comment and marks it as deleted, so replies keep their parent
+func (repo *CommentMongoDBRepository) SoftDeleteCommentByID(ctx context.Context, commentID string) error {
+	return repo.Update(ctx, commentID, map[string]any{
+		"body":    "",
+		"deleted": true,
+	})
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func newTestingComment(issueID string) *model.Comment {
+	return &model.Comment{
+		IssueID:  issueID,
+		AuthorID: localUsers[0].UserID,
+		Body:     "test-comment",
+	}
+}
+
+func TestCommentMongoDBRepository_CreateAndGetComment(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		comment     *model.Comment
+		expectedErr error
+	}{
+		{
+			name:    "insert-comment",
+			comment: newTestingComment("111111111111111111111111"),
+		},
+		{
+			name: "insert-comment/reply",
+			comment: &model.Comment{
+				IssueID:         "111111111111111111111111",
+				AuthorID:        localUsers[1].UserID,
+				Body:            "test-reply",
+				ParentCommentID: "222222222222222222222222",
+			},
+		},
+		{
+			name: "insert-comment/ai-generated",
+			comment: &model.Comment{
+				IssueID:       "111111111111111111111111",
+				AuthorID:      localUsers[0].UserID,
+				Body:          "test-ai-comment",
+				IsAIGenerated: true,
+			},
+		},
+		{
+			name: "insert-comment/invalid-comment-id",
+			comment: &model.Comment{
+				CommentID: "invalid-id",
+				Body:      "test-comment",
+			},
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidID,
+				Database:   LocalRepositoryConfigs.CommentDB.Database,
+				Collection: LocalRepositoryConfigs.CommentDB.Collection,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			commentID, err := commentMongoDBRepository.CreateComment(ctx, testCase.comment)
+			if testCase.expectedErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Defer clean up
+				defer func() {
+					err := commentMongoDBRepository.Delete(ctx, commentID)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}()
+
+				// Check if the comment is created
+				comment, err := commentMongoDBRepository.GetCommentByID(ctx, commentID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, commentID, comment.CommentID)
+				assert.Equal(t, testCase.comment.IssueID, comment.IssueID)
+				assert.Equal(t, testCase.comment.AuthorID, comment.AuthorID)
+				assert.Equal(t, testCase.comment.Body, comment.Body)
+				assert.Equal(t, testCase.comment.ParentCommentID, comment.ParentCommentID)
+				assert.Equal(t, testCase.comment.IsAIGenerated, comment.IsAIGenerated)
+				assert.False(t, comment.Edited)
+				assert.False(t, comment.Deleted)
+				assert.True(t, utils.SimplyValidTimestamp(comment.CreatedAt))
+			} else {
+				assertError(t, testCase.expectedErr, err)
+				assert.Empty(t, commentID)
+			}
+		})
+	}
+}
+
+func TestCommentMongoDBRepository_ListCommentsByIssueID(t *testing.T) {
+	ctx := context.Background()
+
+	// Insert test comments
+	issueID := "111111111111111111111111"
+	commentIDs := []string{}
+	for i := 0; i < 3; i++ {
+		commentID, err := commentMongoDBRepository.CreateComment(ctx, newTestingComment(issueID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		commentIDs = append(commentIDs, commentID)
+	}
+	defer func() {
+		for _, commentID := range commentIDs {
+			err := commentMongoDBRepository.Delete(ctx, commentID)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+
+	testCases := []struct {
+		name               string
+		issueID            string
+		pageOptions        *PageOptions
+		expectedCommentIDs []string
+		expectedCursor     bool
+	}{
+		{
+			name:               "all",
+			issueID:            issueID,
+			expectedCommentIDs: commentIDs,
+		},
+		{
+			name:               "limit",
+			issueID:            issueID,
+			pageOptions:        &PageOptions{Limit: 2},
+			expectedCommentIDs: commentIDs[:2],
+			expectedCursor:     true,
+		},
+		{
+			name:               "offset",
+			issueID:            issueID,
+			pageOptions:        &PageOptions{Offset: 1},
+			expectedCommentIDs: commentIDs[1:],
+		},
+		{
+			name:               "other-issue",
+			issueID:            "aaaaaaaaaaaaaaaaaaaaaaaa",
+			expectedCommentIDs: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			page, err := commentMongoDBRepository.ListCommentsByIssueID(ctx, testCase.issueID, testCase.pageOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actualCommentIDs := []string{}
+			for _, comment := range page.Items {
+				actualCommentIDs = append(actualCommentIDs, comment.CommentID)
+			}
+			assert.Equal(t, testCase.expectedCommentIDs, actualCommentIDs)
+			assert.Equal(t, testCase.expectedCursor, page.NextCursor != "")
+		})
+	}
+}
+
+func TestCommentMongoDBRepository_ListLatestCommentsByIssueID(t *testing.T) {
+	ctx := context.Background()
+
+	// Insert test comments
+	issueID := "111111111111111111111111"
+	commentIDs := []string{}
+	for i := 0; i < 3; i++ {
+		commentID, err := commentMongoDBRepository.CreateComment(ctx, newTestingComment(issueID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		commentIDs = append(commentIDs, commentID)
+	}
+	defer func() {
+		for _, commentID := range commentIDs {
+			err := commentMongoDBRepository.Delete(ctx, commentID)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+
+	testCases := []struct {
+		name               string
+		issueID            string
+		limit              int64
+		expectedCommentIDs []string
+	}{
+		{
+			name:               "all",
+			issueID:            issueID,
+			expectedCommentIDs: []string{commentIDs[2], commentIDs[1], commentIDs[0]},
+		},
+		{
+			name:               "limit",
+			issueID:            issueID,
+			limit:              2,
+			expectedCommentIDs: []string{commentIDs[2], commentIDs[1]},
+		},
+		{
+			name:               "other-issue",
+			issueID:            "aaaaaaaaaaaaaaaaaaaaaaaa",
+			expectedCommentIDs: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			comments, err := commentMongoDBRepository.ListLatestCommentsByIssueID(ctx, testCase.issueID, testCase.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actualCommentIDs := []string{}
+			for _, comment := range comments {
+				actualCommentIDs = append(actualCommentIDs, comment.CommentID)
+			}
+			assert.Equal(t, testCase.expectedCommentIDs, actualCommentIDs)
+		})
+	}
+}
+
+func TestCommentMongoDBRepository_EditAndSoftDeleteComment(t *testing.T) {
+	ctx := context.Background()
+
+	// Insert test comment
+	commentID, err := commentMongoDBRepository.CreateComment(ctx, newTestingComment("111111111111111111111111"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err := commentMongoDBRepository.Delete(ctx, commentID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Edit comment
+	err = commentMongoDBRepository.EditCommentByID(ctx, commentID, "test-comment-edited")
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err := commentMongoDBRepository.GetCommentByID(ctx, commentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test-comment-edited", comment.Body)
+	assert.True(t, comment.Edited)
+
+	// Soft delete comment
+	err = commentMongoDBRepository.SoftDeleteCommentByID(ctx, commentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err = commentMongoDBRepository.GetCommentByID(ctx, commentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, comment.Body)
+	assert.True(t, comment.Deleted)
+
+	// Edit comment not found
+	err = commentMongoDBRepository.EditCommentByID(ctx, "aaaaaaaaaaaaaaaaaaaaaaaa", "test-comment-edited")
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeRecordNotFound,
+		Database:   LocalRepositoryConfigs.CommentDB.Database,
+		Collection: LocalRepositoryConfigs.CommentDB.Collection,
+	}, err)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/STLeee/mediation-platform/backend/core/db"
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// IssueDBRepository is an interface for issue repository in database
+type IssueDBRepository interface {
+	CreateIssue(ctx context.Context, issue *model.Issue) (string, error)
+	GetIssueByID(ctx context.Context, issueID string) (*model.Issue, error)
+	ListIssuesByUserID(ctx context.Context, userID string, pageOptions *PageOptions) (*Page[*model.Issue], error)
+	UpdateIssueByID(ctx context.Context, issueID string, updateData map[string]any) error
+	CloseIssueByID(ctx context.Context, issueID string) error
+}
+
+// IssueMongoDBRepository is a MongoDB repository for issue
+type IssueMongoDBRepository struct {
+	TypedMongoDBRepository[model.IssueInMongoDB, *model.IssueInMongoDB]
+}
+
+// NewIssueMongoDBRepository creates a new IssueMongoDBRepository
+func NewIssueMongoDBRepository(mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *IssueMongoDBRepository {
+	return &IssueMongoDBRepository{
+		TypedMongoDBRepository: *NewTypedMongoDBRepository[model.IssueInMongoDB](mongoDB, cfg),
+	}
+}
+
+// CreateIssue creates an issue
+func (repo *IssueMongoDBRepository) CreateIssue(ctx context.Context, issue *model.Issue) (string, error) {
+	// Set status, created at and updated at
+	now := time.Now()
+	if issue.Status == "" {
+		issue.Status = model.IssueStatusOpen
+	}
+	issue.CreatedAt = now
+	issue.UpdatedAt = now
+
+	// Insert one
+	issueInMongoDB, err := model.NewIssueInMongoDB(issue)
+	if err != nil {
+		return "", repo.NewInvalidIDError(err)
+	}
+	return repo.Insert(ctx, issueInMongoDB)
+}
+
+// GetIssueByID gets an issue by issue ID
+func (repo *IssueMongoDBRepository) GetIssueByID(ctx context.Context, issueID string) (*model.Issue, error) {
+	issueInMongoDB, err := repo.Get(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return &issueInMongoDB.Issue, nil
+}
+
+// ListIssuesByUserID lists a page of issues the user participates in
+func (repo *IssueMongoDBRepository) ListIssuesByUserID(ctx context.Context, userID string, pageOptions *PageOptions) (*Page[*model.Issue], error) {
+	filter := map[string]any{
+		"$or": bson.A{
+			bson.M{"created_by": userID},
+			bson.M{"parties": userID},
+		},
+	}
+	page, err := repo.FindMany(ctx, filter, pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(page, func(issueInMongoDB *model.IssueInMongoDB) *model.Issue {
+		return &issueInMongoDB.Issue
+	}), nil
+}
+
+// UpdateIssueByID updates an issue by issue ID
+func (repo *IssueMongoDBRepository) UpdateIssueByID(ctx context.Context, issueID string, updateData map[string]any) error {
+	return repo.Update(ctx, issueID, updateData)
+}
+
+// CloseIssueByID closes an issue by issue ID
+func (repo *IssueMongoDBRepository) CloseIssueByID(ctx context.Context, issueID string) error {
+	return repo.Update(ctx, issueID, map[string]any{
+		"status":    model.IssueStatusClosed,
+		"closed_at": time.Now(),
+	})
+}
@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func newTestingIssue() *model.Issue {
+	return &model.Issue{
+		Title:       "test-issue",
+		Description: "test-issue-description",
+		Parties:     []string{localUsers[0].UserID, localUsers[1].UserID},
+		CreatedBy:   localUsers[0].UserID,
+	}
+}
+
+func TestIssueMongoDBRepository_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		issue       *model.Issue
+		expectedErr error
+	}{
+		{
+			name: "insert-issue/with-issue-id",
+			issue: &model.Issue{
+				IssueID:   "111111111111111111111111",
+				Title:     "test-create-issue",
+				Parties:   []string{localUsers[0].UserID},
+				CreatedBy: localUsers[0].UserID,
+			},
+		},
+		{
+			name:  "insert-issue/without-issue-id",
+			issue: newTestingIssue(),
+		},
+		{
+			name: "insert-issue/invalid-issue-id",
+			issue: &model.Issue{
+				IssueID: "invalid-id",
+				Title:   "test-create-issue",
+			},
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidID,
+				Database:   LocalRepositoryConfigs.IssueDB.Database,
+				Collection: LocalRepositoryConfigs.IssueDB.Collection,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issueID, err := issueMongoDBRepository.CreateIssue(ctx, testCase.issue)
+			if testCase.expectedErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Defer clean up
+				defer func() {
+					err := issueMongoDBRepository.Delete(ctx, issueID)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}()
+
+				// Check issue id
+				if testCase.issue.IssueID != "" {
+					assert.Equal(t, testCase.issue.IssueID, issueID)
+				} else {
+					assert.NotEmpty(t, issueID)
+				}
+
+				// Check if the issue is created
+				issue, err := issueMongoDBRepository.GetIssueByID(ctx, issueID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, issueID, issue.IssueID)
+				assert.Equal(t, testCase.issue.Title, issue.Title)
+				assert.Equal(t, testCase.issue.Parties, issue.Parties)
+				assert.Equal(t, model.IssueStatusOpen, issue.Status)
+				assert.True(t, utils.SimplyValidTimestamp(issue.CreatedAt))
+			} else {
+				assertError(t, testCase.expectedErr, err)
+				assert.Empty(t, issueID)
+			}
+		})
+	}
+}
+
+func TestIssueMongoDBRepository_GetIssueByID(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		issueID     string
+		expectedErr error
+	}{
+		{
+			name:    "issue-not-found",
+			issueID: "aaaaaaaaaaaaaaaaaaaaaaaa",
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeRecordNotFound,
+				Database:   LocalRepositoryConfigs.IssueDB.Database,
+				Collection: LocalRepositoryConfigs.IssueDB.Collection,
+			},
+		},
+		{
+			name:    "invalid-id",
+			issueID: "invalid-id",
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidID,
+				Database:   LocalRepositoryConfigs.IssueDB.Database,
+				Collection: LocalRepositoryConfigs.IssueDB.Collection,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issue, err := issueMongoDBRepository.GetIssueByID(ctx, testCase.issueID)
+			assert.Nil(t, issue)
+			assertError(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestIssueMongoDBRepository_ListIssuesByUserID(t *testing.T) {
+	ctx := context.Background()
+
+	// Insert test issues
+	issueIDs := []string{}
+	for i := 0; i < 3; i++ {
+		issueID, err := issueMongoDBRepository.CreateIssue(ctx, newTestingIssue())
+		if err != nil {
+			t.Fatal(err)
+		}
+		issueIDs = append(issueIDs, issueID)
+	}
+	defer func() {
+		for _, issueID := range issueIDs {
+			err := issueMongoDBRepository.Delete(ctx, issueID)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+
+	testCases := []struct {
+		name          string
+		userID        string
+		pageOptions   *PageOptions
+		expectedCount int
+	}{
+		{
+			name:          "creator",
+			userID:        localUsers[0].UserID,
+			expectedCount: 3,
+		},
+		{
+			name:          "party",
+			userID:        localUsers[1].UserID,
+			expectedCount: 3,
+		},
+		{
+			name:          "limit",
+			userID:        localUsers[0].UserID,
+			pageOptions:   &PageOptions{Limit: 2},
+			expectedCount: 2,
+		},
+		{
+			name:          "skip",
+			userID:        localUsers[0].UserID,
+			pageOptions:   &PageOptions{Offset: 2},
+			expectedCount: 1,
+		},
+		{
+			name:          "not-participant",
+			userID:        localUsers[2].UserID,
+			expectedCount: 0,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			page, err := issueMongoDBRepository.ListIssuesByUserID(ctx, testCase.userID, testCase.pageOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Len(t, page.Items, testCase.expectedCount)
+			for _, issue := range page.Items {
+				assert.True(t, issue.IsParticipant(testCase.userID))
+			}
+		})
+	}
+
+	// List by cursor, newest first
+	pageOptions := &PageOptions{Limit: 2, SortField: "created_at", Descending: true}
+	page, err := issueMongoDBRepository.ListIssuesByUserID(ctx, localUsers[0].UserID, pageOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{issueIDs[2], issueIDs[1]}, []string{page.Items[0].IssueID, page.Items[1].IssueID})
+	pageOptions.Cursor = page.NextCursor
+	page, err = issueMongoDBRepository.ListIssuesByUserID(ctx, localUsers[0].UserID, pageOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, issueIDs[0], page.Items[0].IssueID)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestIssueMongoDBRepository_UpdateAndCloseIssue(t *testing.T) {
+	ctx := context.Background()
+
+	// Insert test issue
+	issueID, err := issueMongoDBRepository.CreateIssue(ctx, newTestingIssue())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err := issueMongoDBRepository.Delete(ctx, issueID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Update issue
+	err = issueMongoDBRepository.UpdateIssueByID(ctx, issueID, map[string]any{
+		"title": "test-issue-updated",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue, err := issueMongoDBRepository.GetIssueByID(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test-issue-updated", issue.Title)
+
+	// Close issue
+	err = issueMongoDBRepository.CloseIssueByID(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue, err = issueMongoDBRepository.GetIssueByID(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, model.IssueStatusClosed, issue.Status)
+	assert.True(t, utils.SimplyValidTimestamp(issue.ClosedAt))
+
+	// Close issue not found
+	err = issueMongoDBRepository.CloseIssueByID(ctx, "aaaaaaaaaaaaaaaaaaaaaaaa")
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeRecordNotFound,
+		Database:   LocalRepositoryConfigs.IssueDB.Database,
+		Collection: LocalRepositoryConfigs.IssueDB.Collection,
+	}, err)
+}
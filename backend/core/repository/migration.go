@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/STLeee/mediation-platform/backend/core/db"
+)
+
+// MongoDBIndexes are indexes of collections of MongoDB repositories, they are kept in sync with mongodb/init/mongodb-init.js
+var MongoDBIndexes = map[RepositoryName][]mongo.IndexModel{
+	RepositoryNameUserDB: {
+		{Keys: bson.D{{Key: "firebase_uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "phone_number", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	RepositoryNameIssueDB: {
+		{Keys: bson.D{{Key: "created_by", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "parties", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+	RepositoryNameCommentDB: {
+		{Keys: bson.D{{Key: "issue_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	},
+	RepositoryNameNotificationDB: {
+		{Keys: bson.D{{Key: "recipient_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+	RepositoryNameAuditLogDB: {
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "_id", Value: -1}}},
+	},
+}
+
+// MongoDBConfigs returns configs of MongoDB repositories by name, repositories without config are not included
+func (cfgs *RepositoryConfigs) MongoDBConfigs() map[RepositoryName]*MongoDBRepositoryConfig {
+	mongoDBConfigs := map[RepositoryName]*MongoDBRepositoryConfig{
+		RepositoryNameUserDB:         cfgs.UserDB,
+		RepositoryNameIssueDB:        cfgs.IssueDB,
+		RepositoryNameCommentDB:      cfgs.CommentDB,
+		RepositoryNameNotificationDB: cfgs.NotificationDB,
+		RepositoryNameAuditLogDB:     cfgs.AuditLogDB,
+	}
+	maps.DeleteFunc(mongoDBConfigs, func(_ RepositoryName, cfg *MongoDBRepositoryConfig) bool {
+		return cfg == nil
+	})
+	return mongoDBConfigs
+}
+
+// CreateIndexes creates indexes of the collection and returns their names, creating an existing index is a no-op
+func (repo *MongoDBRepository) CreateIndexes(ctx context.Context, indexes []mongo.IndexModel) ([]string, error) {
+	if len(indexes) == 0 {
+		return []string{}, nil
+	}
+
+	opCtx, end := repo.startOperation(ctx, "create_indexes")
+	names, err := repo.collection.Indexes().CreateMany(opCtx, indexes)
+	end(err)
+	if err != nil {
+		return nil, RepositoryError{
+			ErrType:    RepositoryErrorTypeServerError,
+			Database:   repo.cfg.Database,
+			Collection: repo.cfg.Collection,
+			Message:    "failed to create indexes",
+			Err:        err,
+		}
+	}
+	return names, nil
+}
+
+// ReplaceManyByID replaces documents with same ID and inserts the others, it returns count of inserted and modified
+func (repo *MongoDBRepository) ReplaceManyByID(ctx context.Context, documents []bson.D) (int64, error) {
+	if len(documents) == 0 {
+		return 0, nil
+	}
+
+	// Build replace models, documents without ID can not be matched
+	models := make([]mongo.WriteModel, len(documents))
+	for i, document := range documents {
+		index := slices.IndexFunc(document, func(element bson.E) bool {
+			return element.Key == "_id"
+		})
+		if index < 0 {
+			return 0, RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidData,
+				Database:   repo.cfg.Database,
+				Collection: repo.cfg.Collection,
+				Message:    "document without ID",
+			}
+		}
+		models[i] = mongo.NewReplaceOneModel().SetFilter(bson.D{{Key: "_id", Value: document[index].Value}}).SetReplacement(document).SetUpsert(true)
+	}
+
+	// Bulk write
+	opCtx, end := repo.startOperation(ctx, "bulk_write")
+	res, err := repo.collection.BulkWrite(opCtx, models)
+	end(err)
+	if err != nil {
+		return 0, repo.newWriteError("failed to replace many by ID", err)
+	}
+	return res.UpsertedCount + res.ModifiedCount, nil
+}
+
+// Migrate creates indexes of collections of MongoDB repositories, it returns names of indexes by repository name
+func Migrate(ctx context.Context, mongoDB *db.MongoDB, cfgs *RepositoryConfigs) (map[RepositoryName][]string, error) {
+	mongoDBConfigs := cfgs.MongoDBConfigs()
+	indexNames := make(map[RepositoryName][]string, len(mongoDBConfigs))
+	for _, name := range slices.Sorted(maps.Keys(mongoDBConfigs)) {
+		names, err := NewMongoDBRepository(mongoDB, mongoDBConfigs[name]).CreateIndexes(ctx, MongoDBIndexes[name])
+		if err != nil {
+			return nil, err
+		}
+		indexNames[name] = names
+	}
+	return indexNames, nil
+}
+
+// Seed writes documents of the extended JSON array into the collection, documents are replaced by ID so seeding is
+// repeatable, it returns count of inserted and modified
+func Seed(ctx context.Context, mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig, data []byte) (int64, error) {
+	var documents []bson.D
+	if err := bson.UnmarshalExtJSON(data, false, &documents); err != nil {
+		return 0, RepositoryError{
+			ErrType:    RepositoryErrorTypeInvalidData,
+			Database:   cfg.Database,
+			Collection: cfg.Collection,
+			Message:    "failed to parse documents",
+			Err:        err,
+		}
+	}
+	return NewMongoDBRepository(mongoDB, cfg).ReplaceManyByID(ctx, documents)
+}
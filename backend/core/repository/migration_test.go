@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var migrationTestingRepositoryConfigs = &RepositoryConfigs{
+	UserDB: &MongoDBRepositoryConfig{
+		Database:   "mediation-platform-migration-testing",
+		Collection: "user",
+	},
+	AuditLogDB: &MongoDBRepositoryConfig{
+		Database:   "mediation-platform-migration-testing",
+		Collection: "audit_log",
+	},
+}
+
+func TestRepositoryConfigs_MongoDBConfigs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfgs     *RepositoryConfigs
+		expected map[RepositoryName]*MongoDBRepositoryConfig
+	}{
+		{
+			name: "all-configs",
+			cfgs: LocalRepositoryConfigs,
+			expected: map[RepositoryName]*MongoDBRepositoryConfig{
+				RepositoryNameUserDB:         LocalRepositoryConfigs.UserDB,
+				RepositoryNameIssueDB:        LocalRepositoryConfigs.IssueDB,
+				RepositoryNameCommentDB:      LocalRepositoryConfigs.CommentDB,
+				RepositoryNameNotificationDB: LocalRepositoryConfigs.NotificationDB,
+				RepositoryNameAuditLogDB:     LocalRepositoryConfigs.AuditLogDB,
+			},
+		},
+		{
+			name: "partial-configs",
+			cfgs: migrationTestingRepositoryConfigs,
+			expected: map[RepositoryName]*MongoDBRepositoryConfig{
+				RepositoryNameUserDB:     migrationTestingRepositoryConfigs.UserDB,
+				RepositoryNameAuditLogDB: migrationTestingRepositoryConfigs.AuditLogDB,
+			},
+		},
+		{
+			name:     "no-config",
+			cfgs:     &RepositoryConfigs{},
+			expected: map[RepositoryName]*MongoDBRepositoryConfig{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.cfgs.MongoDBConfigs())
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	mongoDB := userMongoDBRepository.mongoDB
+	defer mongoDB.Database(migrationTestingRepositoryConfigs.UserDB.Database).Drop(ctx)
+
+	// Migrate twice, indexes are created once
+	for range 2 {
+		indexNames, err := Migrate(ctx, mongoDB, migrationTestingRepositoryConfigs)
+		assert.NoError(t, err)
+		assert.Equal(t, map[RepositoryName][]string{
+			RepositoryNameUserDB:     {"firebase_uid_1", "email_1", "phone_number_1"},
+			RepositoryNameAuditLogDB: {"target_id_1__id_-1", "actor_id_1__id_-1"},
+		}, indexNames)
+	}
+
+}
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	mongoDB := userMongoDBRepository.mongoDB
+	cfg := migrationTestingRepositoryConfigs.UserDB
+	defer mongoDB.Database(cfg.Database).Drop(ctx)
+
+	testCases := []struct {
+		name          string
+		data          string
+		expectedCount int64
+		expectedErr   error
+	}{
+		{
+			name:          "insert",
+			data:          `[{"_id":{"$oid":"000000000000000000000001"},"display_name":"TestingUser1"},{"_id":{"$oid":"000000000000000000000002"},"display_name":"TestingUser2"}]`,
+			expectedCount: 2,
+		},
+		{
+			name:          "replace",
+			data:          `[{"_id":{"$oid":"000000000000000000000001"},"display_name":"TestingUser1"},{"_id":{"$oid":"000000000000000000000002"},"display_name":"ReplacedUser2"}]`,
+			expectedCount: 1,
+		},
+		{
+			name:          "empty",
+			data:          `[]`,
+			expectedCount: 0,
+		},
+		{
+			name: "document-without-id",
+			data: `[{"display_name":"TestingUser1"}]`,
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidData,
+				Database:   cfg.Database,
+				Collection: cfg.Collection,
+			},
+		},
+		{
+			name: "invalid-json",
+			data: `{`,
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidData,
+				Database:   cfg.Database,
+				Collection: cfg.Collection,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			count, err := Seed(ctx, mongoDB, cfg, []byte(testCase.data))
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, count)
+			} else {
+				assertError(t, testCase.expectedErr, err)
+			}
+		})
+	}
+
+	// Seeded documents are replaced
+	var document bson.M
+	err := mongoDB.Database(cfg.Database).Collection(cfg.Collection).FindOne(ctx, bson.M{"display_name": "ReplacedUser2"}).Decode(&document)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/STLeee/mediation-platform/backend/core/db"
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// NotificationDBRepository is an interface for notification repository in database
+type NotificationDBRepository interface {
+	CreateNotification(ctx context.Context, notification *model.Notification) (string, error)
+	ListNotificationsByRecipientID(ctx context.Context, recipientID string, unreadOnly bool, pageOptions *PageOptions) (*Page[*model.Notification], error)
+	MarkNotificationAsRead(ctx context.Context, recipientID, notificationID string) error
+	MarkAllNotificationsAsRead(ctx context.Context, recipientID string) (int64, error)
+	CountUnreadNotifications(ctx context.Context, recipientID string) (int64, error)
+}
+
+// NotificationMongoDBRepository is a MongoDB repository for notification
+type NotificationMongoDBRepository struct {
+	TypedMongoDBRepository[model.NotificationInMongoDB, *model.NotificationInMongoDB]
+}
+
+// NewNotificationMongoDBRepository creates a new NotificationMongoDBRepository
+func NewNotificationMongoDBRepository(mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *NotificationMongoDBRepository {
+	return &NotificationMongoDBRepository{
+		TypedMongoDBRepository: *NewTypedMongoDBRepository[model.NotificationInMongoDB](mongoDB, cfg),
+	}
+}
+
+// CreateNotification creates a notification
+func (repo *NotificationMongoDBRepository) CreateNotification(ctx context.Context, notification *model.Notification) (string, error) {
+	// Set created at and updated at
+	now := time.Now()
+	notification.CreatedAt = now
+	notification.UpdatedAt = now
+
+	// Insert one
+	notificationInMongoDB, err := model.NewNotificationInMongoDB(notification)
+	if err != nil {
+		return "", repo.NewInvalidIDError(err)
+	}
+	return repo.Insert(ctx, notificationInMongoDB)
+}
+
+// ListNotificationsByRecipientID lists a page of notifications of the recipient
+func (repo *NotificationMongoDBRepository) ListNotificationsByRecipientID(ctx context.Context, recipientID string, unreadOnly bool, pageOptions *PageOptions) (*Page[*model.Notification], error) {
+	filter := map[string]any{
+		"recipient_id": recipientID,
+	}
+	if unreadOnly {
+		filter["read_at"] = nil
+	}
+	page, err := repo.FindMany(ctx, filter, pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(page, func(notificationInMongoDB *model.NotificationInMongoDB) *model.Notification {
+		return &notificationInMongoDB.Notification
+	}), nil
+}
+
+// MarkNotificationAsRead marks a notification of the recipient as read
+func (repo *NotificationMongoDBRepository) MarkNotificationAsRead(ctx context.Context, recipientID, notificationID string) error {
+	objectID, err := bson.ObjectIDFromHex(notificationID)
+	if err != nil {
+		return repo.NewInvalidIDError(err)
+	}
+
+	// Filter by recipient too, so users can only mark their own notifications
+	filter := map[string]any{
+		"_id":          objectID,
+		"recipient_id": recipientID,
+	}
+	return repo.UpdateOneByFilter(ctx, filter, map[string]any{
+		"read_at": time.Now(),
+	})
+}
+
+// MarkAllNotificationsAsRead marks all unread notifications of the recipient as read
+func (repo *NotificationMongoDBRepository) MarkAllNotificationsAsRead(ctx context.Context, recipientID string) (int64, error) {
+	filter := map[string]any{
+		"recipient_id": recipientID,
+		"read_at":      nil,
+	}
+	return repo.UpdateManyByFilter(ctx, filter, map[string]any{
+		"read_at": time.Now(),
+	})
+}
+
+// CountUnreadNotifications counts unread notifications of the recipient
+func (repo *NotificationMongoDBRepository) CountUnreadNotifications(ctx context.Context, recipientID string) (int64, error) {
+	filter := map[string]any{
+		"recipient_id": recipientID,
+		"read_at":      nil,
+	}
+	return repo.Count(ctx, filter)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/utils"
+)
+
+func newTestingNotification(recipientID string) *model.Notification {
+	return &model.Notification{
+		RecipientID: recipientID,
+		Type:        model.NotificationTypeCommentCreated,
+		Payload: map[string]any{
+			"issue_id": "111111111111111111111111",
+		},
+	}
+}
+
+func TestNotificationMongoDBRepository_CreateAndGetNotification(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		notification *model.Notification
+		expectedErr  error
+	}{
+		{
+			name:         "insert-notification",
+			notification: newTestingNotification(localUsers[0].UserID),
+		},
+		{
+			name: "insert-notification/invalid-notification-id",
+			notification: &model.Notification{
+				NotificationID: "invalid-id",
+				Type:           model.NotificationTypeIssueInvited,
+			},
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeInvalidID,
+				Database:   LocalRepositoryConfigs.NotificationDB.Database,
+				Collection: LocalRepositoryConfigs.NotificationDB.Collection,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notificationID, err := notificationMongoDBRepository.CreateNotification(ctx, testCase.notification)
+			if testCase.expectedErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Defer clean up
+				defer func() {
+					err := notificationMongoDBRepository.Delete(ctx, notificationID)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}()
+
+				// Check if the notification is created
+				notificationInMongoDB, err := notificationMongoDBRepository.Get(ctx, notificationID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				notification := notificationInMongoDB.Notification
+				assert.Equal(t, notificationID, notification.NotificationID)
+				assert.Equal(t, testCase.notification.RecipientID, notification.RecipientID)
+				assert.Equal(t, testCase.notification.Type, notification.Type)
+				assert.Equal(t, testCase.notification.Payload, notification.Payload)
+				assert.False(t, notification.IsRead())
+				assert.True(t, utils.SimplyValidTimestamp(notification.CreatedAt))
+			} else {
+				assertError(t, testCase.expectedErr, err)
+				assert.Empty(t, notificationID)
+			}
+		})
+	}
+}
+
+func TestNotificationMongoDBRepository_Inbox(t *testing.T) {
+	ctx := context.Background()
+	recipientID := localUsers[0].UserID
+
+	// Insert test notifications
+	notificationIDs := []string{}
+	for i := 0; i < 3; i++ {
+		notificationID, err := notificationMongoDBRepository.CreateNotification(ctx, newTestingNotification(recipientID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		notificationIDs = append(notificationIDs, notificationID)
+	}
+	otherNotificationID, err := notificationMongoDBRepository.CreateNotification(ctx, newTestingNotification(localUsers[1].UserID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, notificationID := range append(notificationIDs, otherNotificationID) {
+			err := notificationMongoDBRepository.Delete(ctx, notificationID)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+
+	// List notifications, newest first
+	pageOptions := &PageOptions{SortField: "created_at", Descending: true, WithTotal: true}
+	page, err := notificationMongoDBRepository.ListNotificationsByRecipientID(ctx, recipientID, false, pageOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actualNotificationIDs := []string{}
+	for _, notification := range page.Items {
+		actualNotificationIDs = append(actualNotificationIDs, notification.NotificationID)
+	}
+	assert.Equal(t, []string{notificationIDs[2], notificationIDs[1], notificationIDs[0]}, actualNotificationIDs)
+	assert.Equal(t, int64(3), *page.Total)
+
+	// Mark one as read
+	err = notificationMongoDBRepository.MarkNotificationAsRead(ctx, recipientID, notificationIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := notificationMongoDBRepository.CountUnreadNotifications(ctx, recipientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2), count)
+	page, err = notificationMongoDBRepository.ListNotificationsByRecipientID(ctx, recipientID, true, pageOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 2)
+
+	// Mark notification of other recipient as read
+	err = notificationMongoDBRepository.MarkNotificationAsRead(ctx, recipientID, otherNotificationID)
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeRecordNotFound,
+		Database:   LocalRepositoryConfigs.NotificationDB.Database,
+		Collection: LocalRepositoryConfigs.NotificationDB.Collection,
+	}, err)
+
+	// Mark all as read
+	modifiedCount, err := notificationMongoDBRepository.MarkAllNotificationsAsRead(ctx, recipientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2), modifiedCount)
+	count, err = notificationMongoDBRepository.CountUnreadNotifications(ctx, recipientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(0), count)
+
+	// Other recipient is not affected
+	count, err = notificationMongoDBRepository.CountUnreadNotifications(ctx, localUsers[1].UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// DefaultPageSortField is the sort field of page when not set, ObjectID is increasing by creation time
+const DefaultPageSortField = "_id"
+
+// PageOptions struct for options of finding a page, cursor takes precedence over offset
+type PageOptions struct {
+	Limit      int64
+	Offset     int64
+	Cursor     string
+	SortField  string
+	Descending bool
+	WithTotal  bool
+}
+
+// getSortField returns the sort field, use default when not set
+func (opts *PageOptions) getSortField() string {
+	if opts.SortField == "" {
+		return DefaultPageSortField
+	}
+	return opts.SortField
+}
+
+// getSort returns the sort of page, _id is always the tie-breaker
+func (opts *PageOptions) getSort() bson.D {
+	direction := 1
+	if opts.Descending {
+		direction = -1
+	}
+	sortField := opts.getSortField()
+	sort := bson.D{{Key: sortField, Value: direction}}
+	if sortField != DefaultPageSortField {
+		sort = append(sort, bson.E{Key: DefaultPageSortField, Value: direction})
+	}
+	return sort
+}
+
+// Page struct for a page of documents
+type Page[D any] struct {
+	Items      []D
+	NextCursor string
+	Total      *int64
+}
+
+// pageCursor struct for position of the last document in page, sort of page is kept so the cursor can not be used
+// with another sort
+type pageCursor struct {
+	SortField  string        `bson:"s"`
+	Descending bool          `bson:"d"`
+	Value      bson.RawValue `bson:"v"`
+	ID         bson.ObjectID `bson:"id"`
+}
+
+// encodePageCursor encodes the position of document to an opaque cursor
+func encodePageCursor(position *pageCursor) (string, error) {
+	data, err := bson.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageCursor decodes the opaque cursor to position of document, the cursor must be created with same sort
+func decodePageCursor(cursor string, sortField string, descending bool) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var position pageCursor
+	if err := bson.Unmarshal(data, &position); err != nil {
+		return nil, err
+	}
+	if position.SortField != sortField || position.Descending != descending {
+		return nil, fmt.Errorf("cursor is sorted by %s, not %s", position.sortName(), (&pageCursor{SortField: sortField, Descending: descending}).sortName())
+	}
+	return &position, nil
+}
+
+// sortName returns the name of sort of the cursor for error message
+func (position *pageCursor) sortName() string {
+	if position.Descending {
+		return position.SortField + " descending"
+	}
+	return position.SortField + " ascending"
+}
+
+// newPageCursorFilter creates the filter of documents after the cursor position
+func newPageCursorFilter(position *pageCursor) bson.M {
+	operator := "$gt"
+	if position.Descending {
+		operator = "$lt"
+	}
+	if position.SortField == DefaultPageSortField {
+		return bson.M{DefaultPageSortField: bson.M{operator: position.ID}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{position.SortField: bson.M{operator: position.Value}},
+		bson.M{position.SortField: position.Value, DefaultPageSortField: bson.M{operator: position.ID}},
+	}}
+}
+
+// lookupPageCursor gets the cursor of raw document by sort
+func lookupPageCursor(document bson.Raw, sortField string, descending bool) (string, error) {
+	id, ok := document.Lookup(DefaultPageSortField).ObjectIDOK()
+	if !ok {
+		return "", errors.New("document has no ObjectID")
+	}
+	return encodePageCursor(&pageCursor{
+		SortField:  sortField,
+		Descending: descending,
+		Value:      document.Lookup(strings.Split(sortField, ".")...),
+		ID:         id,
+	})
+}
+
+// MapPage maps items of page by the converter, cursor and total are kept
+func MapPage[D any, T any](page *Page[D], convert func(D) T) *Page[T] {
+	converted := &Page[T]{
+		Items:      make([]T, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+	for _, item := range page.Items {
+		converted.Items = append(converted.Items, convert(item))
+	}
+	return converted
+}
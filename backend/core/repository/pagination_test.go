@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestPageOptions_GetSort(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     *PageOptions
+		expected bson.D
+	}{
+		{
+			name:     "default",
+			opts:     &PageOptions{},
+			expected: bson.D{{Key: "_id", Value: 1}},
+		},
+		{
+			name:     "descending-id",
+			opts:     &PageOptions{Descending: true},
+			expected: bson.D{{Key: "_id", Value: -1}},
+		},
+		{
+			name:     "sort-field",
+			opts:     &PageOptions{SortField: "created_at", Descending: true},
+			expected: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.opts.getSort())
+		})
+	}
+}
+
+func TestPageCursor(t *testing.T) {
+	id := bson.NewObjectID()
+	createdAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	document, err := bson.Marshal(bson.M{"_id": id, "created_at": createdAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Lookup and decode cursor
+	cursor, err := lookupPageCursor(document, "created_at", true)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cursor)
+	position, err := decodePageCursor(cursor, "created_at", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at", position.SortField)
+	assert.True(t, position.Descending)
+	assert.Equal(t, id, position.ID)
+	assert.Equal(t, createdAt, position.Value.Time().UTC())
+
+	// Cursor of another sort
+	_, err = decodePageCursor(cursor, "_id", true)
+	assert.ErrorContains(t, err, "cursor is sorted by created_at descending, not _id descending")
+	_, err = decodePageCursor(cursor, "created_at", false)
+	assert.ErrorContains(t, err, "cursor is sorted by created_at descending, not created_at ascending")
+
+	// Document without ObjectID
+	document, err = bson.Marshal(bson.M{"_id": "not-object-id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = lookupPageCursor(document, "_id", false)
+	assert.Error(t, err)
+
+	// Invalid cursor
+	_, err = decodePageCursor("invalid-cursor", "_id", false)
+	assert.Error(t, err)
+}
+
+func TestNewPageCursorFilter(t *testing.T) {
+	id := bson.NewObjectID()
+	valueType, valueData, err := bson.MarshalValue(int32(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := bson.RawValue{Type: valueType, Value: valueData}
+	testCases := []struct {
+		name       string
+		sortField  string
+		descending bool
+		expected   bson.M
+	}{
+		{
+			name:      "id-ascending",
+			sortField: "_id",
+			expected:  bson.M{"_id": bson.M{"$gt": id}},
+		},
+		{
+			name:       "id-descending",
+			sortField:  "_id",
+			descending: true,
+			expected:   bson.M{"_id": bson.M{"$lt": id}},
+		},
+		{
+			name:       "sort-field-descending",
+			sortField:  "created_at",
+			descending: true,
+			expected: bson.M{"$or": bson.A{
+				bson.M{"created_at": bson.M{"$lt": value}},
+				bson.M{"created_at": value, "_id": bson.M{"$lt": id}},
+			}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter := newPageCursorFilter(&pageCursor{SortField: testCase.sortField, Descending: testCase.descending, Value: value, ID: id})
+			assert.Equal(t, testCase.expected, filter)
+		})
+	}
+}
+
+func TestMapPage(t *testing.T) {
+	total := int64(3)
+	page := &Page[int]{
+		Items:      []int{1, 2},
+		NextCursor: "test-cursor",
+		Total:      &total,
+	}
+	converted := MapPage(page, func(item int) string {
+		return string(rune('a' + item))
+	})
+	assert.Equal(t, []string{"b", "c"}, converted.Items)
+	assert.Equal(t, "test-cursor", converted.NextCursor)
+	assert.Equal(t, &total, converted.Total)
+
+	// Empty page
+	converted = MapPage(&Page[int]{}, func(item int) string {
+		return ""
+	})
+	assert.Equal(t, []string{}, converted.Items)
+	assert.Nil(t, converted.Total)
+}
@@ -2,18 +2,27 @@ package repository
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/STLeee/mediation-platform/backend/core/cache"
 	"github.com/STLeee/mediation-platform/backend/core/db"
 	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/tracing"
 )
 
 var LocalRepositoryConfigs = &RepositoryConfigs{
@@ -22,6 +31,22 @@ var LocalRepositoryConfigs = &RepositoryConfigs{
 		Collection: "user",
 	},
 	UserCache: nil, // user default cache config
+	IssueDB: &MongoDBRepositoryConfig{
+		Database:   "mediation-platform",
+		Collection: "issue",
+	},
+	CommentDB: &MongoDBRepositoryConfig{
+		Database:   "mediation-platform",
+		Collection: "comment",
+	},
+	NotificationDB: &MongoDBRepositoryConfig{
+		Database:   "mediation-platform",
+		Collection: "notification",
+	},
+	AuditLogDB: &MongoDBRepositoryConfig{
+		Database:   "mediation-platform",
+		Collection: "audit_log",
+	},
 }
 
 // RepositoryErrorType struct for repository error type
@@ -33,6 +58,8 @@ const (
 	RepositoryErrorTypeRecordNotFound RepositoryErrorType = "record_not_found"
 	RepositoryErrorTypeInvalidID      RepositoryErrorType = "invalid_id"
 	RepositoryErrorTypeInvalidData    RepositoryErrorType = "invalid_data"
+	RepositoryErrorTypeInvalidCursor  RepositoryErrorType = "invalid_cursor"
+	RepositoryErrorTypeDuplicateKey   RepositoryErrorType = "duplicate_key"
 )
 
 var RepositoryErrorDefaultMessages = map[RepositoryErrorType]string{
@@ -41,13 +68,16 @@ var RepositoryErrorDefaultMessages = map[RepositoryErrorType]string{
 	RepositoryErrorTypeRecordNotFound: "record not found",
 	RepositoryErrorTypeInvalidID:      "invalid ID",
 	RepositoryErrorTypeInvalidData:    "invalid data",
+	RepositoryErrorTypeInvalidCursor:  "invalid cursor",
+	RepositoryErrorTypeDuplicateKey:   "duplicate key",
 }
 
-// RepositoryError struct for repository error
+// RepositoryError struct for repository error, Field is the offending field of duplicate key error
 type RepositoryError struct {
 	ErrType    RepositoryErrorType
 	Database   string
 	Collection string
+	Field      string
 	Message    string
 	Err        error
 }
@@ -76,14 +106,22 @@ func (e RepositoryError) Unwrap() error {
 type RepositoryName string
 
 const (
-	RepositoryNameUserDB    RepositoryName = "user_db"
-	RepositoryNameUserCache RepositoryName = "user_cache"
+	RepositoryNameUserDB         RepositoryName = "user_db"
+	RepositoryNameUserCache      RepositoryName = "user_cache"
+	RepositoryNameIssueDB        RepositoryName = "issue_db"
+	RepositoryNameCommentDB      RepositoryName = "comment_db"
+	RepositoryNameNotificationDB RepositoryName = "notification_db"
+	RepositoryNameAuditLogDB     RepositoryName = "audit_log_db"
 )
 
 // MongoDBRepositoryConfigs struct for MongoDB repository configs
 type RepositoryConfigs struct {
-	UserDB    *MongoDBRepositoryConfig   `yaml:"user_db"`
-	UserCache *UserCacheRepositoryConfig `yaml:"user_cache"`
+	UserDB         *MongoDBRepositoryConfig   `yaml:"user_db"`
+	UserCache      *UserCacheRepositoryConfig `yaml:"user_cache"`
+	IssueDB        *MongoDBRepositoryConfig   `yaml:"issue_db"`
+	CommentDB      *MongoDBRepositoryConfig   `yaml:"comment_db"`
+	NotificationDB *MongoDBRepositoryConfig   `yaml:"notification_db"`
+	AuditLogDB     *MongoDBRepositoryConfig   `yaml:"audit_log_db"`
 }
 
 // MongoDBRepositoryConfig struct for MongoDB repository config
@@ -108,17 +146,84 @@ func NewMongoDBRepository(mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *Mo
 	}
 }
 
+// duplicateKeyIndexRegexp matches the index name in message of duplicate key error
+var duplicateKeyIndexRegexp = regexp.MustCompile(`index: (\S+) dup key`)
+
+// duplicateKeyField extracts the offending field of duplicate key error, from key pattern of the write error or
+// the index name in message for servers without key pattern, fields of compound index are joined by comma
+func duplicateKeyField(err error) string {
+	var writeException mongo.WriteException
+	if !errors.As(err, &writeException) {
+		return ""
+	}
+	for _, writeError := range writeException.WriteErrors {
+		if !mongo.IsDuplicateKeyError(mongo.WriteException{WriteErrors: mongo.WriteErrors{writeError}}) {
+			continue
+		}
+		if keyPattern, ok := writeError.Raw.Lookup("keyPattern").DocumentOK(); ok {
+			elements, err := keyPattern.Elements()
+			if err == nil && len(elements) > 0 {
+				fields := make([]string, len(elements))
+				for i, element := range elements {
+					fields[i] = element.Key()
+				}
+				return strings.Join(fields, ",")
+			}
+		}
+		if matches := duplicateKeyIndexRegexp.FindStringSubmatch(writeError.Message); matches != nil {
+			return strings.TrimSuffix(strings.TrimSuffix(matches[1], "_1"), "_-1")
+		}
+	}
+	return ""
+}
+
+// newWriteError creates an error of writing, violation of unique index is a duplicate key error with the field
+func (repo *MongoDBRepository) newWriteError(message string, err error) RepositoryError {
+	repositoryError := RepositoryError{
+		ErrType:    RepositoryErrorTypeServerError,
+		Database:   repo.cfg.Database,
+		Collection: repo.cfg.Collection,
+		Message:    message,
+		Err:        err,
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		repositoryError.ErrType = RepositoryErrorTypeDuplicateKey
+		repositoryError.Field = duplicateKeyField(err)
+	}
+	return repositoryError
+}
+
+// startOperation starts a span of the operation on the collection, the returned function ends the span with error
+// of the operation and observes latency of the operation to metrics of MongoDB, no document is not an error
+func (repo *MongoDBRepository) startOperation(ctx context.Context, operation string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := tracing.StartSpan(ctx, "MongoDB."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongoDB,
+			semconv.DBNamespace(repo.cfg.Database),
+			semconv.DBCollectionName(repo.cfg.Collection),
+			semconv.DBOperationName(operation),
+		),
+	)
+	return ctx, func(err error) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = nil
+		}
+		tracing.EndSpan(span, err)
+		if repo.mongoDB != nil {
+			repo.mongoDB.Metrics().ObserveMongoDBOperation(repo.cfg.Collection, operation, time.Since(start))
+		}
+	}
+}
+
 // InsertOne inserts one
 func (repo *MongoDBRepository) InsertOne(ctx context.Context, data model.MongoDBDocument) (string, error) {
-	res, err := repo.collection.InsertOne(ctx, data)
+	opCtx, end := repo.startOperation(ctx, "insert_one")
+	res, err := repo.collection.InsertOne(opCtx, data)
+	end(err)
 	if err != nil {
-		return "", RepositoryError{
-			ErrType:    RepositoryErrorTypeServerError,
-			Database:   repo.cfg.Database,
-			Collection: repo.cfg.Collection,
-			Message:    "failed to insert one",
-			Err:        err,
-		}
+		return "", repo.newWriteError("failed to insert one", err)
 	}
 	return res.InsertedID.(bson.ObjectID).Hex(), nil
 }
@@ -144,7 +249,10 @@ func (repo *MongoDBRepository) FindByID(ctx context.Context, id string, result m
 // FindOneByFilter finds one by filter
 func (repo *MongoDBRepository) FindOneByFilter(ctx context.Context, filter map[string]any, result model.MongoDBDocument) error {
 	// Find one
-	if err := repo.collection.FindOne(ctx, filter).Decode(result); err != nil {
+	opCtx, end := repo.startOperation(ctx, "find_one")
+	err := repo.collection.FindOne(opCtx, filter).Decode(result)
+	end(err)
+	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return RepositoryError{
 				ErrType:    RepositoryErrorTypeRecordNotFound,
@@ -163,8 +271,7 @@ func (repo *MongoDBRepository) FindOneByFilter(ctx context.Context, filter map[s
 	}
 
 	// Setup data from document
-	err := result.SetupDataFromDocument()
-	if err != nil {
+	if err := result.SetupDataFromDocument(); err != nil {
 		return RepositoryError{
 			ErrType:    RepositoryErrorTypeInvalidData,
 			Database:   repo.cfg.Database,
@@ -176,6 +283,13 @@ func (repo *MongoDBRepository) FindOneByFilter(ctx context.Context, filter map[s
 	return nil
 }
 
+// FindOptions struct for options of finding many
+type FindOptions struct {
+	Sort  bson.D
+	Limit int64
+	Skip  int64
+}
+
 func (repo *MongoDBRepository) UpdateByID(ctx context.Context, id string, data map[string]any) error {
 	// Convert ID to ObjectID
 	objectID, err := bson.ObjectIDFromHex(id)
@@ -193,16 +307,36 @@ func (repo *MongoDBRepository) UpdateByID(ctx context.Context, id string, data m
 	update := bson.M{"$set": data}
 
 	// Update one
-	res, err := repo.collection.UpdateByID(ctx, objectID, update)
+	opCtx, end := repo.startOperation(ctx, "update_one")
+	res, err := repo.collection.UpdateByID(opCtx, objectID, update)
+	end(err)
 	if err != nil {
+		return repo.newWriteError("failed to update one by ID", err)
+	}
+	if res.MatchedCount == 0 {
 		return RepositoryError{
-			ErrType:    RepositoryErrorTypeServerError,
+			ErrType:    RepositoryErrorTypeRecordNotFound,
 			Database:   repo.cfg.Database,
 			Collection: repo.cfg.Collection,
-			Message:    "failed to update one by ID",
-			Err:        err,
+			Message:    "record not found",
 		}
 	}
+	return nil
+}
+
+// UpdateOneByFilter updates one by filter
+func (repo *MongoDBRepository) UpdateOneByFilter(ctx context.Context, filter map[string]any, data map[string]any) error {
+	// Set update data
+	data[model.UpdatedTimestampFieldName] = time.Now()
+	update := bson.M{"$set": data}
+
+	// Update one
+	opCtx, end := repo.startOperation(ctx, "update_one")
+	res, err := repo.collection.UpdateOne(opCtx, filter, update)
+	end(err)
+	if err != nil {
+		return repo.newWriteError("failed to update one by filter", err)
+	}
 	if res.MatchedCount == 0 {
 		return RepositoryError{
 			ErrType:    RepositoryErrorTypeRecordNotFound,
@@ -214,6 +348,39 @@ func (repo *MongoDBRepository) UpdateByID(ctx context.Context, id string, data m
 	return nil
 }
 
+// UpdateManyByFilter updates many by filter and returns the number of modified records
+func (repo *MongoDBRepository) UpdateManyByFilter(ctx context.Context, filter map[string]any, data map[string]any) (int64, error) {
+	// Set update data
+	data[model.UpdatedTimestampFieldName] = time.Now()
+	update := bson.M{"$set": data}
+
+	// Update many
+	opCtx, end := repo.startOperation(ctx, "update_many")
+	res, err := repo.collection.UpdateMany(opCtx, filter, update)
+	end(err)
+	if err != nil {
+		return 0, repo.newWriteError("failed to update many by filter", err)
+	}
+	return res.ModifiedCount, nil
+}
+
+// CountByFilter counts by filter
+func (repo *MongoDBRepository) CountByFilter(ctx context.Context, filter map[string]any) (int64, error) {
+	opCtx, end := repo.startOperation(ctx, "count")
+	count, err := repo.collection.CountDocuments(opCtx, filter)
+	end(err)
+	if err != nil {
+		return 0, RepositoryError{
+			ErrType:    RepositoryErrorTypeServerError,
+			Database:   repo.cfg.Database,
+			Collection: repo.cfg.Collection,
+			Message:    "failed to count by filter",
+			Err:        err,
+		}
+	}
+	return count, nil
+}
+
 func (repo *MongoDBRepository) DeleteByID(ctx context.Context, id string) error {
 	// Convert ID to ObjectID
 	objectID, err := bson.ObjectIDFromHex(id)
@@ -228,7 +395,9 @@ func (repo *MongoDBRepository) DeleteByID(ctx context.Context, id string) error
 
 	// Delete one
 	filter := bson.M{"_id": objectID}
-	res, err := repo.collection.DeleteOne(ctx, filter)
+	opCtx, end := repo.startOperation(ctx, "delete_one")
+	res, err := repo.collection.DeleteOne(opCtx, filter)
+	end(err)
 	if err != nil {
 		return RepositoryError{
 			ErrType:    RepositoryErrorTypeServerError,
@@ -249,21 +418,84 @@ func (repo *MongoDBRepository) DeleteByID(ctx context.Context, id string) error
 	return nil
 }
 
-// UserCacheRepositoryConfig struct for user cache repository config
+// RedisCacheRepositoryKeyConfig struct for Redis cache repository key config
 type RedisCacheRepositoryKeyConfig struct {
-	KeyFormat string
-	TTL       *RedisCacheRepositoryKeyTTLConfig
+	KeyFormat string                            `yaml:"key_format"`
+	TTL       *RedisCacheRepositoryKeyTTLConfig `yaml:"ttl"`
+	// TTL of negative entry like failure, it should be shorter than TTL
+	NegativeTTL *RedisCacheRepositoryKeyTTLConfig  `yaml:"negative_ttl"`
+	Hash        *RedisCacheRepositoryKeyHashConfig `yaml:"hash"`
 }
 
-// GenerateCacheKey generates key
+// GenerateCacheKey generates key, values of sensitive placeholders are hashed
 func (config *RedisCacheRepositoryKeyConfig) GenerateCacheKey(args map[string]string) string {
 	cacheKey := config.KeyFormat
 	for key, value := range args {
+		if config.Hash != nil && slices.Contains(config.Hash.Placeholders, key) {
+			value = config.Hash.HashValue(value)
+		}
 		cacheKey = strings.ReplaceAll(cacheKey, key, value)
 	}
 	return cacheKey
 }
 
+// RedisCacheRepositoryKeyHashMode is a mode of hashing sensitive placeholders in Redis cache key
+type RedisCacheRepositoryKeyHashMode string
+
+const (
+	// RedisCacheRepositoryKeyHashModeNone keeps values raw, e.g. to keep reading keys written before hashing
+	RedisCacheRepositoryKeyHashModeNone   RedisCacheRepositoryKeyHashMode = "none"
+	RedisCacheRepositoryKeyHashModeSHA256 RedisCacheRepositoryKeyHashMode = "sha256"
+	RedisCacheRepositoryKeyHashModeHMAC   RedisCacheRepositoryKeyHashMode = "hmac"
+)
+
+// RedisCacheRepositoryKeyHashConfig struct for hashing sensitive placeholders in Redis cache key
+type RedisCacheRepositoryKeyHashConfig struct {
+	// Mode of hashing, use HMAC when secret is set or SHA-256 otherwise when empty
+	Mode RedisCacheRepositoryKeyHashMode `yaml:"mode"`
+	// Placeholders to be hashed, e.g. {token}
+	Placeholders []string `yaml:"placeholders"`
+	// Secret for HMAC-SHA256
+	Secret string `yaml:"secret"`
+}
+
+// getMode returns the mode of hashing, it is derived from secret when not set
+func (config *RedisCacheRepositoryKeyHashConfig) getMode() RedisCacheRepositoryKeyHashMode {
+	if config.Mode != "" {
+		return config.Mode
+	}
+	if config.Secret != "" {
+		return RedisCacheRepositoryKeyHashModeHMAC
+	}
+	return RedisCacheRepositoryKeyHashModeSHA256
+}
+
+// Validate validates the config, secret is required in HMAC mode since HMAC with empty secret is as guessable as SHA-256
+func (config *RedisCacheRepositoryKeyHashConfig) Validate() error {
+	if config.getMode() == RedisCacheRepositoryKeyHashModeHMAC && config.Secret == "" {
+		return cache.CacheError{
+			ErrType: cache.CacheErrorTypeConfigError,
+			Message: "secret is required in hmac hash mode",
+		}
+	}
+	return nil
+}
+
+// HashValue hashes value to hex string by the mode, value is kept raw in none mode
+func (config *RedisCacheRepositoryKeyHashConfig) HashValue(value string) string {
+	switch config.getMode() {
+	case RedisCacheRepositoryKeyHashModeNone:
+		return value
+	case RedisCacheRepositoryKeyHashModeHMAC:
+		mac := hmac.New(sha256.New, []byte(config.Secret))
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))
+	default:
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}
+}
+
 // RedisCacheRepositoryKeyTTLConfig struct for Redis cache repository TTL config
 type RedisCacheRepositoryKeyTTLConfig struct {
 	Expire          time.Duration `yaml:"expire"`
@@ -293,6 +525,22 @@ func NewRedisCacheRepository(redisCache *cache.RedisCache) *RedisCacheRepository
 	}
 }
 
+// startOperation starts a span of the operation on Redis, the returned function ends the span with error of the
+// operation, record not found is not an error
+func (repo *RedisCacheRepository) startOperation(ctx context.Context, operation string) (context.Context, func(err error)) {
+	ctx, span := tracing.StartSpan(ctx, "Redis."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, semconv.DBOperationName(operation)),
+	)
+	return ctx, func(err error) {
+		var repositoryError RepositoryError
+		if errors.As(err, &repositoryError) && repositoryError.ErrType == RepositoryErrorTypeRecordNotFound {
+			err = nil
+		}
+		tracing.EndSpan(span, err)
+	}
+}
+
 // ConvertToJSON converts data to JSON
 func (repo *RedisCacheRepository) ConvertToJSON(data any) (string, error) {
 	jsonBytes, err := json.Marshal(data)
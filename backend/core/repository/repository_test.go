@@ -10,14 +10,29 @@ import (
 
 	"github.com/STLeee/mediation-platform/backend/core/cache"
 	"github.com/STLeee/mediation-platform/backend/core/db"
+	"github.com/STLeee/mediation-platform/backend/core/metrics"
 	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/tracing"
+	"github.com/STLeee/mediation-platform/backend/core/tracing/tracingtest"
 	"github.com/STLeee/mediation-platform/backend/core/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 var (
-	userMongoDBRepository    *UserMongoDBRepository
-	userRedisCacheRepository *UserRedisCacheRepository
+	userMongoDBRepository         *UserMongoDBRepository
+	userRedisCacheRepository      *UserRedisCacheRepository
+	issueMongoDBRepository        *IssueMongoDBRepository
+	commentMongoDBRepository      *CommentMongoDBRepository
+	notificationMongoDBRepository *NotificationMongoDBRepository
+	auditLogMongoDBRepository     *AuditLogMongoDBRepository
 )
 
 var localUsers = []*model.User{
@@ -107,7 +122,14 @@ func TestMain(m *testing.M) {
 	defer redis.Close()
 
 	userMongoDBRepository = NewUserMongoDBRepository(mongoDB, LocalRepositoryConfigs.UserDB)
-	userRedisCacheRepository = NewUserRedisCacheRepository(redis, nil)
+	userRedisCacheRepository, err = NewUserRedisCacheRepository(redis, nil)
+	if err != nil {
+		panic(err)
+	}
+	issueMongoDBRepository = NewIssueMongoDBRepository(mongoDB, LocalRepositoryConfigs.IssueDB)
+	commentMongoDBRepository = NewCommentMongoDBRepository(mongoDB, LocalRepositoryConfigs.CommentDB)
+	notificationMongoDBRepository = NewNotificationMongoDBRepository(mongoDB, LocalRepositoryConfigs.NotificationDB)
+	auditLogMongoDBRepository = NewAuditLogMongoDBRepository(mongoDB, LocalRepositoryConfigs.AuditLogDB)
 
 	// Run tests
 	os.Exit(m.Run())
@@ -168,6 +190,15 @@ func TestRepositoryError(t *testing.T) {
 			err:        fmt.Errorf("test error"),
 			expected:   "test-db/test-collection: record not found: test error",
 		},
+		{
+			name:       "duplicate-key/no-message",
+			errType:    RepositoryErrorTypeDuplicateKey,
+			database:   "test-db",
+			collection: "test-collection",
+			message:    "",
+			err:        nil,
+			expected:   "test-db/test-collection: duplicate key",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -184,3 +215,256 @@ func TestRepositoryError(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDBRepository_newWriteError(t *testing.T) {
+	keyPatternRaw, err := bson.Marshal(bson.D{{Key: "code", Value: 11000}, {Key: "keyPattern", Value: bson.D{{Key: "email", Value: 1}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name            string
+		err             error
+		expectedErrType RepositoryErrorType
+		expectedField   string
+	}{
+		{
+			name:            "duplicate-key/key-pattern",
+			err:             mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error", Raw: keyPatternRaw}}},
+			expectedErrType: RepositoryErrorTypeDuplicateKey,
+			expectedField:   "email",
+		},
+		{
+			name:            "duplicate-key/index-name",
+			err:             mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: `E11000 duplicate key error collection: test-db.user index: phone_number_1 dup key: { phone_number: "" }`}}},
+			expectedErrType: RepositoryErrorTypeDuplicateKey,
+			expectedField:   "phone_number",
+		},
+		{
+			name:            "duplicate-key/unknown-field",
+			err:             mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}},
+			expectedErrType: RepositoryErrorTypeDuplicateKey,
+		},
+		{
+			name:            "server-error",
+			err:             fmt.Errorf("test error"),
+			expectedErrType: RepositoryErrorTypeServerError,
+		},
+	}
+
+	repo := &MongoDBRepository{cfg: &MongoDBRepositoryConfig{Database: "test-db", Collection: "test-collection"}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := repo.newWriteError("test message", testCase.err)
+			assert.Equal(t, testCase.expectedErrType, err.ErrType)
+			assert.Equal(t, testCase.expectedField, err.Field)
+			assert.Equal(t, "test-db", err.Database)
+			assert.Equal(t, "test-collection", err.Collection)
+			assert.Equal(t, testCase.err, err.Unwrap())
+		})
+	}
+}
+
+func TestMongoDBRepository_startOperation(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	provider, exporter := tracingtest.NewInMemoryTracerProvider()
+	tracing.SetTracerProvider(provider)
+
+	mongoDB, err := db.NewMongoDB(context.Background(), db.LocalMongoDBConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mongoDB.Close()
+	registry := prometheus.NewRegistry()
+	m, err := metrics.NewMetrics(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mongoDB.SetMetrics(m)
+
+	// Operations of repository are traced and observed with the collection
+	repo := NewUserMongoDBRepository(mongoDB, LocalRepositoryConfigs.UserDB)
+	_, err = repo.GetUserByID(context.Background(), localUsers[0].UserID)
+	assert.NoError(t, err)
+	count, err := testutil.GatherAndCount(registry, "mediation_platform_mongodb_operation_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "MongoDB.find_one", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, semconv.DBCollectionName(LocalRepositoryConfigs.UserDB.Collection))
+	exporter.Reset()
+
+	// No document is not an error of span, and repository without MongoDB does not observe
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatusCode codes.Code
+	}{
+		{
+			name:               "success",
+			err:                nil,
+			expectedStatusCode: codes.Unset,
+		},
+		{
+			name:               "no-documents",
+			err:                mongo.ErrNoDocuments,
+			expectedStatusCode: codes.Unset,
+		},
+		{
+			name:               "error",
+			err:                fmt.Errorf("test error"),
+			expectedStatusCode: codes.Error,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer exporter.Reset()
+			assert.NotPanics(t, func() {
+				_, end := (&MongoDBRepository{cfg: &MongoDBRepositoryConfig{}}).startOperation(context.Background(), "find_one")
+				end(testCase.err)
+			})
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, testCase.expectedStatusCode, spans[0].Status.Code)
+		})
+	}
+}
+
+func TestRedisCacheRepository_startOperation(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	provider, exporter := tracingtest.NewInMemoryTracerProvider()
+	tracing.SetTracerProvider(provider)
+
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatusCode codes.Code
+	}{
+		{
+			name:               "success",
+			err:                nil,
+			expectedStatusCode: codes.Unset,
+		},
+		{
+			name:               "record-not-found",
+			err:                RepositoryError{ErrType: RepositoryErrorTypeRecordNotFound},
+			expectedStatusCode: codes.Unset,
+		},
+		{
+			name:               "server-error",
+			err:                RepositoryError{ErrType: RepositoryErrorTypeServerError},
+			expectedStatusCode: codes.Error,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer exporter.Reset()
+			_, end := (&RedisCacheRepository{}).startOperation(context.Background(), "get_auth_token_entry")
+			end(testCase.err)
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, "Redis.get_auth_token_entry", spans[0].Name)
+			assert.Contains(t, spans[0].Attributes, semconv.DBSystemRedis)
+			assert.Equal(t, testCase.expectedStatusCode, spans[0].Status.Code)
+		})
+	}
+}
+
+func TestRedisCacheRepositoryKeyHashConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         *RedisCacheRepositoryKeyHashConfig
+		expectedErr error
+	}{
+		{
+			name: "sha256",
+			cfg:  &RedisCacheRepositoryKeyHashConfig{Placeholders: []string{"{token}"}},
+		},
+		{
+			name: "hmac-sha256",
+			cfg:  &RedisCacheRepositoryKeyHashConfig{Placeholders: []string{"{token}"}, Secret: "test-secret"},
+		},
+		{
+			name: "mode-hmac",
+			cfg:  &RedisCacheRepositoryKeyHashConfig{Mode: RedisCacheRepositoryKeyHashModeHMAC, Placeholders: []string{"{token}"}, Secret: "test-secret"},
+		},
+		{
+			name: "mode-hmac/empty-secret",
+			cfg:  &RedisCacheRepositoryKeyHashConfig{Mode: RedisCacheRepositoryKeyHashModeHMAC, Placeholders: []string{"{token}"}},
+			expectedErr: cache.CacheError{
+				ErrType: cache.CacheErrorTypeConfigError,
+				Message: "secret is required in hmac hash mode",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.cfg.Validate()
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestRedisCacheRepositoryKeyConfig_GenerateCacheKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      *RedisCacheRepositoryKeyConfig
+		expected string
+	}{
+		{
+			name:     "raw",
+			cfg:      &RedisCacheRepositoryKeyConfig{KeyFormat: "{auth_name}:{token}"},
+			expected: "firebase:test-token",
+		},
+		{
+			name: "sha256",
+			cfg: &RedisCacheRepositoryKeyConfig{
+				KeyFormat: "{auth_name}:{token}",
+				Hash:      &RedisCacheRepositoryKeyHashConfig{Placeholders: []string{"{token}"}},
+			},
+			expected: "firebase:4c5dc9b7708905f77f5e5d16316b5dfb425e68cb326dcd55a860e90a7707031e",
+		},
+		{
+			name: "hmac-sha256",
+			cfg: &RedisCacheRepositoryKeyConfig{
+				KeyFormat: "{auth_name}:{token}",
+				Hash:      &RedisCacheRepositoryKeyHashConfig{Placeholders: []string{"{token}"}, Secret: "test-secret"},
+			},
+			expected: "firebase:4bd72343ca044f8aab1d98f07606cdb1cf47df0c089ff7b5b2df44e40d869970",
+		},
+		{
+			name: "mode-hmac",
+			cfg: &RedisCacheRepositoryKeyConfig{
+				KeyFormat: "{auth_name}:{token}",
+				Hash:      &RedisCacheRepositoryKeyHashConfig{Mode: RedisCacheRepositoryKeyHashModeHMAC, Placeholders: []string{"{token}"}, Secret: "test-secret"},
+			},
+			expected: "firebase:4bd72343ca044f8aab1d98f07606cdb1cf47df0c089ff7b5b2df44e40d869970",
+		},
+		{
+			name: "mode-sha256-with-secret",
+			cfg: &RedisCacheRepositoryKeyConfig{
+				KeyFormat: "{auth_name}:{token}",
+				Hash:      &RedisCacheRepositoryKeyHashConfig{Mode: RedisCacheRepositoryKeyHashModeSHA256, Placeholders: []string{"{token}"}, Secret: "test-secret"},
+			},
+			expected: "firebase:4c5dc9b7708905f77f5e5d16316b5dfb425e68cb326dcd55a860e90a7707031e",
+		},
+		{
+			name: "mode-none",
+			cfg: &RedisCacheRepositoryKeyConfig{
+				KeyFormat: "{auth_name}:{token}",
+				Hash:      &RedisCacheRepositoryKeyHashConfig{Mode: RedisCacheRepositoryKeyHashModeNone, Placeholders: []string{"{token}"}},
+			},
+			expected: "firebase:test-token",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cacheKey := testCase.cfg.GenerateCacheKey(map[string]string{
+				"{auth_name}": "firebase",
+				"{token}":     "test-token",
+			})
+			assert.Equal(t, testCase.expected, cacheKey)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/STLeee/mediation-platform/backend/core/db"
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+// TypedMongoDBRepository is a MongoDB repository which returns typed documents, D is the document type like model.IssueInMongoDB
+type TypedMongoDBRepository[D any, PD interface {
+	*D
+	model.MongoDBDocument
+}] struct {
+	MongoDBRepository
+}
+
+// NewTypedMongoDBRepository creates a new TypedMongoDBRepository
+func NewTypedMongoDBRepository[D any, PD interface {
+	*D
+	model.MongoDBDocument
+}](mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *TypedMongoDBRepository[D, PD] {
+	return &TypedMongoDBRepository[D, PD]{
+		MongoDBRepository: *NewMongoDBRepository(mongoDB, cfg),
+	}
+}
+
+// newError creates a repository error of the collection
+func (repo *TypedMongoDBRepository[D, PD]) newError(errType RepositoryErrorType, message string, err error) RepositoryError {
+	return RepositoryError{
+		ErrType:    errType,
+		Database:   repo.cfg.Database,
+		Collection: repo.cfg.Collection,
+		Message:    message,
+		Err:        err,
+	}
+}
+
+// NewInvalidIDError creates an invalid ID error, for document which can not be built from data
+func (repo *TypedMongoDBRepository[D, PD]) NewInvalidIDError(err error) RepositoryError {
+	return repo.newError(RepositoryErrorTypeInvalidID, "", err)
+}
+
+// Insert inserts a document and returns the ID
+func (repo *TypedMongoDBRepository[D, PD]) Insert(ctx context.Context, document PD) (string, error) {
+	return repo.InsertOne(ctx, document)
+}
+
+// GetOrInsert gets a document by filter, or inserts the document when no document matches the filter. It is an
+// upsert, so concurrent calls insert only one document when fields of the filter have a unique index. Inserted
+// document which collides with another document by other unique key is a server error
+func (repo *TypedMongoDBRepository[D, PD]) GetOrInsert(ctx context.Context, filter map[string]any, document PD) (PD, error) {
+	// Upsert, the document is only set on insert
+	update := bson.M{"$setOnInsert": document}
+	opCtx, end := repo.startOperation(ctx, "upsert_one")
+	_, err := repo.collection.UpdateOne(opCtx, filter, update, options.UpdateOne().SetUpsert(true))
+	end(err)
+	if err != nil {
+		// The concurrent upsert may win the unique index, get the document it inserted
+		writeErr := repo.newWriteError("failed to upsert one by filter", err)
+		if writeErr.ErrType != RepositoryErrorTypeDuplicateKey {
+			return nil, writeErr
+		}
+		existing, getErr := repo.GetByFilter(ctx, filter)
+		if getErr != nil {
+			// No document matches the filter, so the document conflicts with another one by other unique key, it is
+			// not resolved by retrying and is not a conflict of the caller either
+			if repositoryError, ok := getErr.(RepositoryError); ok && repositoryError.ErrType == RepositoryErrorTypeRecordNotFound {
+				writeErr.ErrType = RepositoryErrorTypeServerError
+				writeErr.Message = "document conflicts with another document by " + writeErr.Field
+				writeErr.Field = ""
+			}
+			return nil, writeErr
+		}
+		return existing, nil
+	}
+
+	return repo.GetByFilter(ctx, filter)
+}
+
+// Get gets a document by ID
+func (repo *TypedMongoDBRepository[D, PD]) Get(ctx context.Context, id string) (PD, error) {
+	document := PD(new(D))
+	if err := repo.FindByID(ctx, id, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// GetByFilter gets a document by filter
+func (repo *TypedMongoDBRepository[D, PD]) GetByFilter(ctx context.Context, filter map[string]any) (PD, error) {
+	document := PD(new(D))
+	if err := repo.FindOneByFilter(ctx, filter, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// Find finds documents by filter with sort, limit and skip
+func (repo *TypedMongoDBRepository[D, PD]) Find(ctx context.Context, filter map[string]any, findOptions *FindOptions) ([]PD, error) {
+	// Set find options
+	opts := options.Find()
+	if findOptions != nil {
+		if findOptions.Sort != nil {
+			opts.SetSort(findOptions.Sort)
+		}
+		if findOptions.Limit > 0 {
+			opts.SetLimit(findOptions.Limit)
+		}
+		if findOptions.Skip > 0 {
+			opts.SetSkip(findOptions.Skip)
+		}
+	}
+
+	// Find many
+	opCtx, end := repo.startOperation(ctx, "find")
+	cursor, err := repo.collection.Find(opCtx, filter, opts)
+	end(err)
+	if err != nil {
+		return nil, repo.newError(RepositoryErrorTypeServerError, "failed to find many by filter", err)
+	}
+	defer cursor.Close(ctx)
+
+	// Decode and setup data from documents
+	documents := []PD{}
+	for cursor.Next(ctx) {
+		document, err := repo.decode(cursor)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, repo.newError(RepositoryErrorTypeServerError, "failed to iterate cursor", err)
+	}
+	return documents, nil
+}
+
+// FindMany finds a page of documents by filter, paginated by cursor or offset, nil filter matches all documents
+func (repo *TypedMongoDBRepository[D, PD]) FindMany(ctx context.Context, filter map[string]any, pageOptions *PageOptions) (*Page[PD], error) {
+	if filter == nil {
+		filter = map[string]any{}
+	}
+	if pageOptions == nil {
+		pageOptions = &PageOptions{}
+	}
+	sortField := pageOptions.getSortField()
+
+	// Set find options, fetch one more document to check if there is next page
+	opts := options.Find().SetSort(pageOptions.getSort())
+	if pageOptions.Limit > 0 {
+		opts.SetLimit(pageOptions.Limit + 1)
+	}
+	pageFilter := bson.M(filter)
+	if pageOptions.Cursor != "" {
+		position, err := decodePageCursor(pageOptions.Cursor, sortField, pageOptions.Descending)
+		if err != nil {
+			return nil, repo.newError(RepositoryErrorTypeInvalidCursor, "", err)
+		}
+		pageFilter = bson.M{"$and": bson.A{filter, newPageCursorFilter(position)}}
+	} else if pageOptions.Offset > 0 {
+		opts.SetSkip(pageOptions.Offset)
+	}
+
+	// Find many
+	opCtx, end := repo.startOperation(ctx, "find")
+	cursor, err := repo.collection.Find(opCtx, pageFilter, opts)
+	end(err)
+	if err != nil {
+		return nil, repo.newError(RepositoryErrorTypeServerError, "failed to find many by filter", err)
+	}
+	defer cursor.Close(ctx)
+
+	// Decode documents and keep cursor of the last one in page
+	page := &Page[PD]{Items: []PD{}}
+	var lastDocument bson.Raw
+	for cursor.Next(ctx) {
+		if pageOptions.Limit > 0 && int64(len(page.Items)) == pageOptions.Limit {
+			nextCursor, err := lookupPageCursor(lastDocument, sortField, pageOptions.Descending)
+			if err != nil {
+				return nil, repo.newError(RepositoryErrorTypeInvalidData, "failed to create cursor", err)
+			}
+			page.NextCursor = nextCursor
+			break
+		}
+		document, err := repo.decode(cursor)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, document)
+		lastDocument = append(bson.Raw(nil), cursor.Current...)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, repo.newError(RepositoryErrorTypeServerError, "failed to iterate cursor", err)
+	}
+
+	// Count total
+	if pageOptions.WithTotal {
+		total, err := repo.Count(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+// decode decodes current document of cursor and setups data from it
+func (repo *TypedMongoDBRepository[D, PD]) decode(cursor *mongo.Cursor) (PD, error) {
+	document := PD(new(D))
+	if err := cursor.Decode(document); err != nil {
+		return nil, repo.newError(RepositoryErrorTypeServerError, "failed to decode document", err)
+	}
+	if err := document.SetupDataFromDocument(); err != nil {
+		return nil, repo.newError(RepositoryErrorTypeInvalidData, "setup data from document failed", err)
+	}
+	return document, nil
+}
+
+// Update updates a document by ID
+func (repo *TypedMongoDBRepository[D, PD]) Update(ctx context.Context, id string, data map[string]any) error {
+	return repo.UpdateByID(ctx, id, data)
+}
+
+// Delete deletes a document by ID
+func (repo *TypedMongoDBRepository[D, PD]) Delete(ctx context.Context, id string) error {
+	return repo.DeleteByID(ctx, id)
+}
+
+// Count counts documents by filter
+func (repo *TypedMongoDBRepository[D, PD]) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	return repo.CountByFilter(ctx, filter)
+}
+
+// Exists checks if any document matches the filter
+func (repo *TypedMongoDBRepository[D, PD]) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	opCtx, end := repo.startOperation(ctx, "count")
+	count, err := repo.collection.CountDocuments(opCtx, filter, options.Count().SetLimit(1))
+	end(err)
+	if err != nil {
+		return false, repo.newError(RepositoryErrorTypeServerError, "failed to check existence by filter", err)
+	}
+	return count > 0, nil
+}
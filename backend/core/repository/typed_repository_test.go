@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/STLeee/mediation-platform/backend/core/model"
+)
+
+func TestTypedMongoDBRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := &issueMongoDBRepository.TypedMongoDBRepository
+	createdBy := "typed-repository-test-user"
+
+	// Insert documents
+	issueIDs := []string{}
+	for i := 0; i < 3; i++ {
+		issueInMongoDB, err := model.NewIssueInMongoDB(&model.Issue{
+			Title:     fmt.Sprintf("test-title-%d", i),
+			CreatedBy: createdBy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		issueID, err := repo.Insert(ctx, issueInMongoDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		issueIDs = append(issueIDs, issueID)
+	}
+	defer func() {
+		for _, issueID := range issueIDs[1:] {
+			if err := repo.Delete(ctx, issueID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+
+	// Get
+	issueInMongoDB, err := repo.Get(ctx, issueIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, issueIDs[0], issueInMongoDB.IssueID)
+	assert.Equal(t, "test-title-0", issueInMongoDB.Title)
+
+	// Get by filter
+	issueInMongoDB, err = repo.GetByFilter(ctx, map[string]any{"title": "test-title-1", "created_by": createdBy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, issueIDs[1], issueInMongoDB.IssueID)
+
+	// Find with sort, limit and skip
+	issuesInMongoDB, err := repo.Find(ctx, map[string]any{"created_by": createdBy}, &FindOptions{
+		Sort:  bson.D{{Key: "title", Value: -1}},
+		Limit: 2,
+		Skip:  1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, issuesInMongoDB, 2)
+	assert.Equal(t, issueIDs[1], issuesInMongoDB[0].IssueID)
+	assert.Equal(t, issueIDs[0], issuesInMongoDB[1].IssueID)
+
+	// Find many by cursor
+	filter := map[string]any{"created_by": createdBy}
+	page, err := repo.FindMany(ctx, filter, &PageOptions{Limit: 2, WithTotal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, issueIDs[0], page.Items[0].IssueID)
+	assert.Equal(t, issueIDs[1], page.Items[1].IssueID)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Equal(t, int64(3), *page.Total)
+	page, err = repo.FindMany(ctx, filter, &PageOptions{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, issueIDs[2], page.Items[0].IssueID)
+	assert.Empty(t, page.NextCursor)
+	assert.Nil(t, page.Total)
+
+	// Find many by sort field and offset
+	page, err = repo.FindMany(ctx, filter, &PageOptions{Limit: 1, Offset: 1, SortField: "title", Descending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, issueIDs[1], page.Items[0].IssueID)
+	titleCursor := page.NextCursor
+	page, err = repo.FindMany(ctx, filter, &PageOptions{Limit: 1, Cursor: titleCursor, SortField: "title", Descending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, issueIDs[0], page.Items[0].IssueID)
+	assert.Empty(t, page.NextCursor)
+
+	// Find many by cursor without filter
+	page, err = repo.FindMany(ctx, nil, &PageOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, page.Items, 1)
+	assert.NotEmpty(t, page.NextCursor)
+	_, err = repo.FindMany(ctx, nil, &PageOptions{Limit: 1, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+
+	// Find many by invalid cursor
+	_, err = repo.FindMany(ctx, filter, &PageOptions{Cursor: "invalid-cursor"})
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeInvalidCursor,
+		Database:   LocalRepositoryConfigs.IssueDB.Database,
+		Collection: LocalRepositoryConfigs.IssueDB.Collection,
+	}, err)
+
+	// Find many by cursor of another sort
+	_, err = repo.FindMany(ctx, filter, &PageOptions{Cursor: titleCursor, SortField: "title"})
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeInvalidCursor,
+		Database:   LocalRepositoryConfigs.IssueDB.Database,
+		Collection: LocalRepositoryConfigs.IssueDB.Collection,
+	}, err)
+
+	// Update
+	err = repo.Update(ctx, issueIDs[0], map[string]any{"title": "test-title-updated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issueInMongoDB, err = repo.Get(ctx, issueIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test-title-updated", issueInMongoDB.Title)
+
+	// Count and exists
+	count, err := repo.Count(ctx, map[string]any{"created_by": createdBy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(3), count)
+	exists, err := repo.Exists(ctx, map[string]any{"title": "test-title-updated", "created_by": createdBy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, exists)
+
+	// Delete
+	err = repo.Delete(ctx, issueIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists, err = repo.Exists(ctx, map[string]any{"title": "test-title-updated", "created_by": createdBy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, exists)
+
+	// Get not found and invalid ID
+	_, err = repo.Get(ctx, issueIDs[0])
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeRecordNotFound,
+		Database:   LocalRepositoryConfigs.IssueDB.Database,
+		Collection: LocalRepositoryConfigs.IssueDB.Collection,
+	}, err)
+	_, err = repo.Get(ctx, "invalid-id")
+	assertError(t, RepositoryError{
+		ErrType:    RepositoryErrorTypeInvalidID,
+		Database:   LocalRepositoryConfigs.IssueDB.Database,
+		Collection: LocalRepositoryConfigs.IssueDB.Collection,
+	}, err)
+}
+
+func TestTypedMongoDBRepository_NewInvalidIDError(t *testing.T) {
+	err := issueMongoDBRepository.NewInvalidIDError(fmt.Errorf("test error"))
+	assert.Equal(t, RepositoryErrorTypeInvalidID, err.ErrType)
+	assert.Equal(t, LocalRepositoryConfigs.IssueDB.Database, err.Database)
+	assert.Equal(t, LocalRepositoryConfigs.IssueDB.Collection, err.Collection)
+	assert.Equal(t, "mediation-platform/issue: invalid ID: test error", err.Error())
+}
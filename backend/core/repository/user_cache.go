@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/STLeee/mediation-platform/backend/core/auth"
@@ -13,7 +14,25 @@ import (
 // UserCacheRepository is an interface for user cache repository
 type UserCacheRepository interface {
 	SetAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string, user *model.User) error
-	GetAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string) (*model.User, error)
+	SetAuthTokenFailure(ctx context.Context, authName auth.AuthServiceName, token string, failure *AuthTokenFailure) error
+	GetAuthTokenEntry(ctx context.Context, authName auth.AuthServiceName, token string) (*AuthTokenCacheEntry, error)
+	DeleteAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string) error
+	InvalidateUser(ctx context.Context, userID string) error
+}
+
+// AuthTokenCacheEntry is a cached result of authenticating a token, either User or Failure is set
+type AuthTokenCacheEntry struct {
+	User    *model.User       `json:"user,omitempty"`
+	Failure *AuthTokenFailure `json:"failure,omitempty"`
+}
+
+// AuthTokenFailure is a failure of authenticating a token, it is cached to avoid authenticating bad token again,
+// failure of a known user like disabled user has UserID, so it is invalidated with the user
+type AuthTokenFailure struct {
+	StatusCode int    `json:"status_code"`
+	Code       string `json:"code,omitempty"`
+	Reason     string `json:"reason"`
+	UserID     string `json:"user_id,omitempty"`
 }
 
 // UserCacheKeyPrefix is a prefix for user cache key
@@ -23,27 +42,48 @@ const UserCacheKeyPrefix = "user"
 type UserCacheRepositoryKeyName string
 
 const (
-	UserCacheRepositoryKeyNameAuthTokenUser UserCacheRepositoryKeyName = "auth_token_user"
+	UserCacheRepositoryKeyNameAuthTokenUser      UserCacheRepositoryKeyName = "auth_token_user"
+	UserCacheRepositoryKeyNameUserAuthTokenIndex UserCacheRepositoryKeyName = "user_auth_token_index"
 )
 
 var UserCacheRepositoryKeyNameList = []UserCacheRepositoryKeyName{
 	UserCacheRepositoryKeyNameAuthTokenUser,
+	UserCacheRepositoryKeyNameUserAuthTokenIndex,
 }
 
 // UserCacheRepositoryConfig is a configuration for UserCacheRepository
 type UserCacheRepositoryConfig struct {
-	Keys map[UserCacheRepositoryKeyName]*RedisCacheRepositoryKeyConfig
+	Keys map[UserCacheRepositoryKeyName]*RedisCacheRepositoryKeyConfig `yaml:"keys"`
 }
 
 // DefaultUserCacheRepositoryConfig is a default configuration for UserCacheRepository
 var DefaultUserCacheRepositoryConfig = &UserCacheRepositoryConfig{
 	Keys: map[UserCacheRepositoryKeyName]*RedisCacheRepositoryKeyConfig{
+		// Token is hashed to keep live tokens out of Redis, the "hashed" segment separates keys from the legacy
+		// raw token keys, which are never read again and expire by their TTL, to keep reading legacy keys while
+		// rolling out, set hash mode to none and key format to "{auth_name}:{token}"
 		UserCacheRepositoryKeyNameAuthTokenUser: {
-			KeyFormat: "{auth_name}:{token}",
+			KeyFormat: "{auth_name}:hashed:{token}",
 			TTL: &RedisCacheRepositoryKeyTTLConfig{
 				Expire:          1 * time.Hour,
 				MaxRandomOffset: 5 * time.Minute,
 			},
+			NegativeTTL: &RedisCacheRepositoryKeyTTLConfig{
+				Expire:          5 * time.Minute,
+				MaxRandomOffset: 30 * time.Second,
+			},
+			Hash: &RedisCacheRepositoryKeyHashConfig{
+				Placeholders: []string{"{token}"},
+			},
+		},
+		// Index of auth token user keys of a user, it should live no shorter than the auth token user keys, it is
+		// a sorted set scored by expiry of keys, the "token_index" segment separates it from the legacy set index,
+		// which expires by its TTL
+		UserCacheRepositoryKeyNameUserAuthTokenIndex: {
+			KeyFormat: "token_index:{user_id}",
+			TTL: &RedisCacheRepositoryKeyTTLConfig{
+				Expire: 1*time.Hour + 5*time.Minute,
+			},
 		},
 	},
 }
@@ -71,6 +111,12 @@ func SetDefaultUserCacheRepositoryConfig(cfg *UserCacheRepositoryConfig) *UserCa
 					if cfg.Keys[key].TTL == nil {
 						cfg.Keys[key].TTL = DefaultUserCacheRepositoryConfig.Keys[key].TTL
 					}
+					if cfg.Keys[key].NegativeTTL == nil {
+						cfg.Keys[key].NegativeTTL = DefaultUserCacheRepositoryConfig.Keys[key].NegativeTTL
+					}
+					if cfg.Keys[key].Hash == nil {
+						cfg.Keys[key].Hash = DefaultUserCacheRepositoryConfig.Keys[key].Hash
+					}
 				}
 			}
 		}
@@ -78,13 +124,20 @@ func SetDefaultUserCacheRepositoryConfig(cfg *UserCacheRepositoryConfig) *UserCa
 	return cfg
 }
 
-// NewUserRedisCacheRepository creates a new UserRedisCacheRepository
-func NewUserRedisCacheRepository(redisCache *cache.RedisCache, cfg *UserCacheRepositoryConfig) *UserRedisCacheRepository {
+// NewUserRedisCacheRepository creates a new UserRedisCacheRepository, hash configs of keys are validated
+func NewUserRedisCacheRepository(redisCache *cache.RedisCache, cfg *UserCacheRepositoryConfig) (*UserRedisCacheRepository, error) {
 	cfg = SetDefaultUserCacheRepositoryConfig(cfg)
+	for _, key := range UserCacheRepositoryKeyNameList {
+		if hashCfg := cfg.Keys[key].Hash; hashCfg != nil {
+			if err := hashCfg.Validate(); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return &UserRedisCacheRepository{
 		RedisCacheRepository: *NewRedisCacheRepository(redisCache),
 		cfg:                  cfg,
-	}
+	}, nil
 }
 
 // generateAuthTokenUserCacheKey generates cache key for user by auth token
@@ -96,17 +149,46 @@ func (repo *UserRedisCacheRepository) generateAuthTokenUserCacheKey(authName aut
 	})
 }
 
-// SetAuthTokenUser sets user by auth token
-func (repo *UserRedisCacheRepository) SetAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string, user *model.User) error {
+// generateUserAuthTokenIndexCacheKey generates cache key for index of auth token user keys of a user
+func (repo *UserRedisCacheRepository) generateUserAuthTokenIndexCacheKey(userID string) string {
+	cacheKeyCfg := repo.cfg.Keys[UserCacheRepositoryKeyNameUserAuthTokenIndex]
+	return UserCacheKeyPrefix + ":" + cacheKeyCfg.GenerateCacheKey(map[string]string{
+		"{user_id}": userID,
+	})
+}
+
+// indexAuthTokenKey adds the auth token user key to index of the user scored by its expiry, and trims expired keys,
+// so the index only grows with live tokens of the user
+func (repo *UserRedisCacheRepository) indexAuthTokenKey(ctx context.Context, pipe redis.Pipeliner, userID, cacheKey string, ttl time.Duration) {
+	indexKeyCfg := repo.cfg.Keys[UserCacheRepositoryKeyNameUserAuthTokenIndex]
+	indexKey := repo.generateUserAuthTokenIndexCacheKey(userID)
+	now := time.Now()
+	pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(now.Add(ttl).UnixMilli()), Member: cacheKey})
+	pipe.ZRemRangeByScore(ctx, indexKey, "-inf", "("+strconv.FormatInt(now.UnixMilli(), 10))
+	pipe.Expire(ctx, indexKey, max(indexKeyCfg.TTL.GenerateTTL(), ttl))
+}
+
+// SetAuthTokenUser sets user by auth token, and adds the key to index of the user, keys are written by pipeline
+// rather than transaction since they can be in different slots of Redis Cluster
+func (repo *UserRedisCacheRepository) SetAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string, user *model.User) (err error) {
+	ctx, end := repo.startOperation(ctx, "set_auth_token_user")
+	defer func() { end(err) }()
+
 	cacheKeyCfg := repo.cfg.Keys[UserCacheRepositoryKeyNameAuthTokenUser]
 	cacheKey := repo.generateAuthTokenUserCacheKey(authName, token)
-	cacheValue, err := repo.ConvertToJSON(user)
+	cacheValue, err := repo.ConvertToJSON(&AuthTokenCacheEntry{User: user})
 	if err != nil {
 		return err
 	}
 	ttl := cacheKeyCfg.TTL.GenerateTTL()
 
-	err = repo.Set(ctx, cacheKey, cacheValue, ttl).Err()
+	_, err = repo.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, cacheKey, cacheValue, ttl)
+		if user.UserID != "" {
+			repo.indexAuthTokenKey(ctx, pipe, user.UserID, cacheKey, ttl)
+		}
+		return nil
+	})
 	if err != nil {
 		return RepositoryError{
 			ErrType: RepositoryErrorTypeServerError,
@@ -117,31 +199,125 @@ func (repo *UserRedisCacheRepository) SetAuthTokenUser(ctx context.Context, auth
 	return nil
 }
 
-// GetAuthTokenUser gets user by auth token
-func (repo *UserRedisCacheRepository) GetAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string) (*model.User, error) {
+// SetAuthTokenFailure sets failure by auth token with negative TTL, it is added to index of the user only if UserID is set
+// as SetAuthTokenUser does
+func (repo *UserRedisCacheRepository) SetAuthTokenFailure(ctx context.Context, authName auth.AuthServiceName, token string, failure *AuthTokenFailure) (err error) {
+	ctx, end := repo.startOperation(ctx, "set_auth_token_failure")
+	defer func() { end(err) }()
+
+	cacheKeyCfg := repo.cfg.Keys[UserCacheRepositoryKeyNameAuthTokenUser]
+	cacheKey := repo.generateAuthTokenUserCacheKey(authName, token)
+	cacheValue, err := repo.ConvertToJSON(&AuthTokenCacheEntry{Failure: failure})
+	if err != nil {
+		return err
+	}
+	ttl := cacheKeyCfg.NegativeTTL.GenerateTTL()
+
+	_, err = repo.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, cacheKey, cacheValue, ttl)
+		if failure.UserID != "" {
+			repo.indexAuthTokenKey(ctx, pipe, failure.UserID, cacheKey, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return RepositoryError{
+			ErrType: RepositoryErrorTypeServerError,
+			Message: "failed to set failure by auth token",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// GetAuthTokenEntry gets user or failure by auth token
+func (repo *UserRedisCacheRepository) GetAuthTokenEntry(ctx context.Context, authName auth.AuthServiceName, token string) (entry *AuthTokenCacheEntry, err error) {
+	ctx, end := repo.startOperation(ctx, "get_auth_token_entry")
+	defer func() { end(err) }()
+
 	cacheKey := repo.generateAuthTokenUserCacheKey(authName, token)
 	cacheValue, err := repo.Get(ctx, cacheKey).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, RepositoryError{
 				ErrType: RepositoryErrorTypeRecordNotFound,
-				Message: "user not found by auth token",
+				Message: "entry not found by auth token",
 			}
 		}
 		return nil, RepositoryError{
 			ErrType: RepositoryErrorTypeServerError,
-			Message: "failed to get user by auth token",
+			Message: "failed to get entry by auth token",
 			Err:     err,
 		}
 	}
-	var user model.User
-	err = repo.RevertFromJSON(cacheValue, &user)
+	entry = &AuthTokenCacheEntry{}
+	err = repo.RevertFromJSON(cacheValue, entry)
 	if err != nil {
 		return nil, RepositoryError{
 			ErrType: RepositoryErrorTypeServerError,
-			Message: "failed to convert user from JSON",
+			Message: "failed to convert entry from JSON",
+			Err:     err,
+		}
+	}
+	if entry.User == nil && entry.Failure == nil {
+		return nil, RepositoryError{
+			ErrType: RepositoryErrorTypeRecordNotFound,
+			Message: "entry by auth token is empty",
+		}
+	}
+	return entry, nil
+}
+
+// DeleteAuthTokenUser deletes user by auth token, the key left in index is removed when the user is invalidated
+func (repo *UserRedisCacheRepository) DeleteAuthTokenUser(ctx context.Context, authName auth.AuthServiceName, token string) (err error) {
+	ctx, end := repo.startOperation(ctx, "delete_auth_token_user")
+	defer func() { end(err) }()
+
+	cacheKey := repo.generateAuthTokenUserCacheKey(authName, token)
+	if err := repo.Del(ctx, cacheKey).Err(); err != nil {
+		return RepositoryError{
+			ErrType: RepositoryErrorTypeServerError,
+			Message: "failed to delete user by auth token",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// InvalidateUser deletes all auth token user keys of the user by index, keys are deleted one by one since they can be
+// in different slots of Redis Cluster, deleted keys are removed from index so keys indexed meanwhile are kept
+func (repo *UserRedisCacheRepository) InvalidateUser(ctx context.Context, userID string) (err error) {
+	ctx, end := repo.startOperation(ctx, "invalidate_user")
+	defer func() { end(err) }()
+
+	indexKey := repo.generateUserAuthTokenIndexCacheKey(userID)
+	cacheKeys, err := repo.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return RepositoryError{
+			ErrType: RepositoryErrorTypeServerError,
+			Message: "failed to get index of user",
 			Err:     err,
 		}
 	}
-	return &user, nil
+	if len(cacheKeys) == 0 {
+		return nil
+	}
+
+	_, err = repo.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		members := make([]any, 0, len(cacheKeys))
+		for _, cacheKey := range cacheKeys {
+			pipe.Del(ctx, cacheKey)
+			members = append(members, cacheKey)
+		}
+		pipe.ZRem(ctx, indexKey, members...)
+		return nil
+	})
+	if err != nil {
+		return RepositoryError{
+			ErrType: RepositoryErrorTypeServerError,
+			Message: "failed to invalidate user",
+			Err:     err,
+		}
+	}
+	return nil
 }
@@ -3,10 +3,17 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/STLeee/mediation-platform/backend/core/auth"
+	"github.com/STLeee/mediation-platform/backend/core/cache"
 	"github.com/STLeee/mediation-platform/backend/core/model"
+	"github.com/STLeee/mediation-platform/backend/core/tracing"
+	"github.com/STLeee/mediation-platform/backend/core/tracing/tracingtest"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestSetDefaultUserCacheRepositoryConfig(t *testing.T) {
@@ -57,6 +64,12 @@ func TestSetDefaultUserCacheRepositoryConfig(t *testing.T) {
 						} else {
 							assert.Equal(t, testCase.cfg.Keys[key].TTL.Expire, cfg.Keys[key].TTL.Expire)
 						}
+						if testCase.cfg.Keys[key].NegativeTTL == nil {
+							assert.Equal(t, DefaultUserCacheRepositoryConfig.Keys[key].NegativeTTL, cfg.Keys[key].NegativeTTL)
+						}
+						if testCase.cfg.Keys[key].Hash == nil {
+							assert.Equal(t, DefaultUserCacheRepositoryConfig.Keys[key].Hash, cfg.Keys[key].Hash)
+						}
 					}
 				}
 			}
@@ -64,10 +77,31 @@ func TestSetDefaultUserCacheRepositoryConfig(t *testing.T) {
 	}
 }
 
+func TestNewUserRedisCacheRepository_InvalidHashConfig(t *testing.T) {
+	repo, err := NewUserRedisCacheRepository(nil, &UserCacheRepositoryConfig{
+		Keys: map[UserCacheRepositoryKeyName]*RedisCacheRepositoryKeyConfig{
+			UserCacheRepositoryKeyNameAuthTokenUser: {
+				Hash: &RedisCacheRepositoryKeyHashConfig{
+					Mode:         RedisCacheRepositoryKeyHashModeHMAC,
+					Placeholders: []string{"{token}"},
+				},
+			},
+		},
+	})
+	assert.Nil(t, repo)
+	var cacheErr cache.CacheError
+	assert.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, cache.CacheErrorTypeConfigError, cacheErr.ErrType)
+}
+
 func TestGenerateCacheKey(t *testing.T) {
 	// Auth token user
 	cacheKey := userRedisCacheRepository.generateAuthTokenUserCacheKey(auth.AuthServiceNameFirebase, "test-token")
-	assert.Equal(t, "user:firebase:test-token", cacheKey)
+	assert.Equal(t, "user:firebase:hashed:4c5dc9b7708905f77f5e5d16316b5dfb425e68cb326dcd55a860e90a7707031e", cacheKey)
+
+	// User auth token index
+	cacheKey = userRedisCacheRepository.generateUserAuthTokenIndexCacheKey("test-user-id")
+	assert.Equal(t, "user:token_index:test-user-id", cacheKey)
 }
 
 func TestSetAuthTokenUser(t *testing.T) {
@@ -77,18 +111,56 @@ func TestSetAuthTokenUser(t *testing.T) {
 	userRedisCacheRepository.SetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-token", localUsers[0])
 
 	// Get user by auth token
-	user, err := userRedisCacheRepository.GetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-token")
+	entry, err := userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-token")
+	assert.Nil(t, err)
+	assertUser(t, localUsers[0], entry.User)
+	assert.Nil(t, entry.Failure)
+}
+
+func TestSetAuthTokenFailure(t *testing.T) {
+	ctx := context.Background()
+
+	// Set failure by auth token
+	failure := &AuthTokenFailure{StatusCode: 401, Reason: "token is invalid"}
+	err := userRedisCacheRepository.SetAuthTokenFailure(ctx, auth.AuthServiceNameFirebase, "test-failure-token", failure)
+	assert.Nil(t, err)
+
+	// Get failure by auth token
+	entry, err := userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-failure-token")
 	assert.Nil(t, err)
-	assertUser(t, localUsers[0], user)
+	assert.Nil(t, entry.User)
+	assert.Equal(t, failure, entry.Failure)
+
+	// Failure should expire by negative TTL
+	cacheKey := userRedisCacheRepository.generateAuthTokenUserCacheKey(auth.AuthServiceNameFirebase, "test-failure-token")
+	ttl, err := userRedisCacheRepository.TTL(ctx, cacheKey).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	negativeTTLCfg := DefaultUserCacheRepositoryConfig.Keys[UserCacheRepositoryKeyNameAuthTokenUser].NegativeTTL
+	assert.LessOrEqual(t, ttl, negativeTTLCfg.Expire+negativeTTLCfg.MaxRandomOffset)
+
+	// Failure of known user is invalidated with the user
+	userFailure := &AuthTokenFailure{StatusCode: 403, Code: "user_disabled", Reason: "User is disabled", UserID: localUsers[2].UserID}
+	err = userRedisCacheRepository.SetAuthTokenFailure(ctx, auth.AuthServiceNameFirebase, "test-user-failure-token", userFailure)
+	assert.Nil(t, err)
+	entry, err = userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-user-failure-token")
+	assert.Nil(t, err)
+	assert.Equal(t, userFailure, entry.Failure)
+	err = userRedisCacheRepository.InvalidateUser(ctx, localUsers[2].UserID)
+	assert.Nil(t, err)
+	_, err = userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-user-failure-token")
+	assertError(t, RepositoryError{ErrType: RepositoryErrorTypeRecordNotFound}, err)
 }
 
-func TestGetAuthTokenUser(t *testing.T) {
+func TestGetAuthTokenEntry(t *testing.T) {
 	ctx := context.Background()
 
 	testCases := []struct {
 		name         string
 		authName     auth.AuthServiceName
 		token        string
+		cacheValue   string
 		exceptedUser *model.User
 		exceptedErr  error
 	}{
@@ -100,10 +172,18 @@ func TestGetAuthTokenUser(t *testing.T) {
 			exceptedErr:  nil,
 		},
 		{
-			name:         "not-found",
-			authName:     auth.AuthServiceNameFirebase,
-			token:        "not-found-token",
-			exceptedUser: nil,
+			name:     "not-found",
+			authName: auth.AuthServiceNameFirebase,
+			token:    "not-found-token",
+			exceptedErr: RepositoryError{
+				ErrType: RepositoryErrorTypeRecordNotFound,
+			},
+		},
+		{
+			name:       "empty-entry",
+			authName:   auth.AuthServiceNameFirebase,
+			token:      "empty-entry-token",
+			cacheValue: `{"user_id":"000000000000000000000001"}`,
 			exceptedErr: RepositoryError{
 				ErrType: RepositoryErrorTypeRecordNotFound,
 			},
@@ -118,15 +198,158 @@ func TestGetAuthTokenUser(t *testing.T) {
 					t.Fatal(err)
 				}
 			}
+			if testCase.cacheValue != "" {
+				// Set raw value by auth token
+				cacheKey := userRedisCacheRepository.generateAuthTokenUserCacheKey(testCase.authName, testCase.token)
+				err := userRedisCacheRepository.Set(ctx, cacheKey, testCase.cacheValue, time.Minute).Err()
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
 
-			// Get user by auth token
-			user, err := userRedisCacheRepository.GetAuthTokenUser(ctx, testCase.authName, testCase.token)
+			// Get entry by auth token
+			entry, err := userRedisCacheRepository.GetAuthTokenEntry(ctx, testCase.authName, testCase.token)
 			if testCase.exceptedUser != nil {
-				assertUser(t, testCase.exceptedUser, user)
+				assertUser(t, testCase.exceptedUser, entry.User)
 			}
 			if testCase.exceptedErr != nil {
+				assert.Nil(t, entry)
 				assertError(t, testCase.exceptedErr, err)
 			}
 		})
 	}
 }
+
+func TestDeleteAuthTokenUser(t *testing.T) {
+	ctx := context.Background()
+
+	// Set user by auth token
+	err := userRedisCacheRepository.SetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-delete-token", localUsers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Delete user by auth token
+	err = userRedisCacheRepository.DeleteAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-delete-token")
+	assert.Nil(t, err)
+
+	// Get user by auth token
+	_, err = userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-delete-token")
+	assertError(t, RepositoryError{ErrType: RepositoryErrorTypeRecordNotFound}, err)
+
+	// Delete not existing token
+	err = userRedisCacheRepository.DeleteAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-delete-token")
+	assert.Nil(t, err)
+}
+
+func TestInvalidateUser(t *testing.T) {
+	ctx := context.Background()
+
+	// Set users by auth tokens
+	tokens := []string{"test-invalidate-token-1", "test-invalidate-token-2"}
+	for _, token := range tokens {
+		err := userRedisCacheRepository.SetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, token, localUsers[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := userRedisCacheRepository.SetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-invalidate-token-other", localUsers[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexKey := userRedisCacheRepository.generateUserAuthTokenIndexCacheKey(localUsers[0].UserID)
+	cacheKeys, err := userRedisCacheRepository.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, cacheKeys, len(tokens))
+
+	// Expired keys are trimmed when a key is indexed
+	err = userRedisCacheRepository.ZAdd(ctx, indexKey, redis.Z{Score: 1, Member: "user:firebase:hashed:expired"}).Err()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = userRedisCacheRepository.SetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, tokens[0], localUsers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheKeys, err = userRedisCacheRepository.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, cacheKeys, len(tokens))
+
+	// Invalidate user
+	err = userRedisCacheRepository.InvalidateUser(ctx, localUsers[0].UserID)
+	assert.Nil(t, err)
+	for _, token := range tokens {
+		_, err = userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, token)
+		assertError(t, RepositoryError{ErrType: RepositoryErrorTypeRecordNotFound}, err)
+	}
+	exists, err := userRedisCacheRepository.Exists(ctx, indexKey).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(0), exists)
+
+	// Other user is kept
+	entry, err := userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-invalidate-token-other")
+	assert.Nil(t, err)
+	assertUser(t, localUsers[1], entry.User)
+
+	// Invalidate user without cache
+	err = userRedisCacheRepository.InvalidateUser(ctx, "not-cached-user-id")
+	assert.Nil(t, err)
+}
+
+func TestUserRedisCacheRepository_Spans(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	provider, exporter := tracingtest.NewInMemoryTracerProvider()
+	tracing.SetTracerProvider(provider)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		operate      func() error
+		expectedName string
+	}{
+		{
+			name: "set-auth-token-user",
+			operate: func() error {
+				return userRedisCacheRepository.SetAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-span-token", localUsers[0])
+			},
+			expectedName: "Redis.set_auth_token_user",
+		},
+		{
+			name: "get-auth-token-entry",
+			operate: func() error {
+				_, err := userRedisCacheRepository.GetAuthTokenEntry(ctx, auth.AuthServiceNameFirebase, "test-span-token")
+				return err
+			},
+			expectedName: "Redis.get_auth_token_entry",
+		},
+		{
+			name: "delete-auth-token-user",
+			operate: func() error {
+				return userRedisCacheRepository.DeleteAuthTokenUser(ctx, auth.AuthServiceNameFirebase, "test-span-token")
+			},
+			expectedName: "Redis.delete_auth_token_user",
+		},
+		{
+			name: "invalidate-user",
+			operate: func() error {
+				return userRedisCacheRepository.InvalidateUser(ctx, localUsers[0].UserID)
+			},
+			expectedName: "Redis.invalidate_user",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer exporter.Reset()
+			assert.NoError(t, testCase.operate())
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, testCase.expectedName, spans[0].Name)
+		})
+	}
+}
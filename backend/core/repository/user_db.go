@@ -13,18 +13,47 @@ import (
 type UserDBRepository interface {
 	CreateUser(ctx context.Context, user *model.User) (string, error)
 	GetUserByAuthUID(ctx context.Context, authName auth.AuthServiceName, authUID string) (*model.User, error)
+	GetOrCreateUserByAuthUID(ctx context.Context, authName auth.AuthServiceName, authUID string, user *model.User) (*model.User, error)
 	GetUserByID(ctx context.Context, userID string) (*model.User, error)
+	UpdateUserByID(ctx context.Context, userID string, updateData map[string]any) error
+	DeleteUserByID(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context, filter *UserFilter, pageOptions *PageOptions) (*Page[*model.User], error)
+}
+
+// UserFilter is a filter of users, fields are exact match and empty fields are not filtered
+type UserFilter struct {
+	Email       string
+	PhoneNumber string
+	FirebaseUID string
+}
+
+// toMap converts the filter to filter of MongoDB
+func (filter *UserFilter) toMap() map[string]any {
+	filterMap := map[string]any{}
+	if filter == nil {
+		return filterMap
+	}
+	if filter.Email != "" {
+		filterMap["email"] = filter.Email
+	}
+	if filter.PhoneNumber != "" {
+		filterMap["phone_number"] = filter.PhoneNumber
+	}
+	if filter.FirebaseUID != "" {
+		filterMap["firebase_uid"] = filter.FirebaseUID
+	}
+	return filterMap
 }
 
 // UserMongoDBRepository is a MongoDB repository for user
 type UserMongoDBRepository struct {
-	MongoDBRepository
+	TypedMongoDBRepository[model.UserInMongoDB, *model.UserInMongoDB]
 }
 
 // NewUserMongoDBRepository creates a new UserMongoDBRepository
 func NewUserMongoDBRepository(mongoDB *db.MongoDB, cfg *MongoDBRepositoryConfig) *UserMongoDBRepository {
 	return &UserMongoDBRepository{
-		MongoDBRepository: *NewMongoDBRepository(mongoDB, cfg),
+		TypedMongoDBRepository: *NewTypedMongoDBRepository[model.UserInMongoDB](mongoDB, cfg),
 	}
 }
 
@@ -39,27 +68,59 @@ func (repo *UserMongoDBRepository) CreateUser(ctx context.Context, user *model.U
 	// Insert one
 	userInMongoDB, err := model.NewUserInMongoDB(user)
 	if err != nil {
-		return "", err
+		return "", repo.NewInvalidIDError(err)
 	}
-	return repo.InsertOne(ctx, userInMongoDB)
+	return repo.Insert(ctx, userInMongoDB)
 }
 
-// GetUserByAuthUID get a user by auth UID
-func (repo *UserMongoDBRepository) GetUserByAuthUID(ctx context.Context, authName auth.AuthServiceName, authUID string) (*model.User, error) {
-	authUIDFilter := map[string]any{}
+// newAuthUIDFilter creates a filter of user by auth UID
+func newAuthUIDFilter(authName auth.AuthServiceName, authUID string) (map[string]any, error) {
 	switch authName {
 	case auth.AuthServiceNameFirebase:
-		authUIDFilter["firebase_uid"] = authUID
+		return map[string]any{"firebase_uid": authUID}, nil
 	default:
 		return nil, RepositoryError{
 			ErrType: RepositoryErrorTypeServerError,
 			Message: "unsupported auth service",
 		}
 	}
+}
+
+// GetUserByAuthUID get a user by auth UID
+func (repo *UserMongoDBRepository) GetUserByAuthUID(ctx context.Context, authName auth.AuthServiceName, authUID string) (*model.User, error) {
+	authUIDFilter, err := newAuthUIDFilter(authName, authUID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Find by filter
-	userInMongoDB := &model.UserInMongoDB{}
-	err := repo.FindOneByFilter(ctx, authUIDFilter, userInMongoDB)
+	userInMongoDB, err := repo.GetByFilter(ctx, authUIDFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &userInMongoDB.User, nil
+}
+
+// GetOrCreateUserByAuthUID gets a user by auth UID, or creates the user when not found, it is safe for
+// concurrent first logins of the same user
+func (repo *UserMongoDBRepository) GetOrCreateUserByAuthUID(ctx context.Context, authName auth.AuthServiceName, authUID string, user *model.User) (*model.User, error) {
+	authUIDFilter, err := newAuthUIDFilter(authName, authUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set created at, updated at, and last login at
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	user.LastLoginAt = now
+
+	// Get or insert by filter
+	userInMongoDB, err := model.NewUserInMongoDB(user)
+	if err != nil {
+		return nil, repo.NewInvalidIDError(err)
+	}
+	userInMongoDB, err = repo.GetOrInsert(ctx, authUIDFilter, userInMongoDB)
 	if err != nil {
 		return nil, err
 	}
@@ -69,21 +130,31 @@ func (repo *UserMongoDBRepository) GetUserByAuthUID(ctx context.Context, authNam
 // GetUserByID get a user by user ID
 func (repo *UserMongoDBRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
 	// Find by ID
-	userInMongoDB := &model.UserInMongoDB{}
-	err := repo.FindByID(ctx, userID, userInMongoDB)
+	userInMongoDB, err := repo.Get(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 	return &userInMongoDB.User, nil
 }
 
-// UpdateUser updates a user
+// UpdateUserByID updates a user by user ID
 func (repo *UserMongoDBRepository) UpdateUserByID(ctx context.Context, userID string, updateData map[string]any) error {
-	return repo.UpdateByID(ctx, userID, updateData)
+	return repo.Update(ctx, userID, updateData)
 }
 
 // DeleteUserByID deletes a user by user ID
 func (repo *UserMongoDBRepository) DeleteUserByID(ctx context.Context, userID string) error {
 	// Delete by ID
-	return repo.DeleteByID(ctx, userID)
+	return repo.Delete(ctx, userID)
+}
+
+// ListUsers lists a page of users by filter
+func (repo *UserMongoDBRepository) ListUsers(ctx context.Context, filter *UserFilter, pageOptions *PageOptions) (*Page[*model.User], error) {
+	page, err := repo.FindMany(ctx, filter.toMap(), pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(page, func(userInMongoDB *model.UserInMongoDB) *model.User {
+		return &userInMongoDB.User
+	}), nil
 }
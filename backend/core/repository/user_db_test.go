@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/STLeee/mediation-platform/backend/core/auth"
@@ -117,7 +118,7 @@ func TestUserMongoDBRepository_CreateUser(t *testing.T) {
 			name: "insert-user-duplicate",
 			user: localUsers[0],
 			expectedErr: RepositoryError{
-				ErrType:    RepositoryErrorTypeServerError,
+				ErrType:    RepositoryErrorTypeDuplicateKey,
 				Database:   LocalRepositoryConfigs.UserDB.Database,
 				Collection: LocalRepositoryConfigs.UserDB.Collection,
 			},
@@ -166,6 +167,126 @@ func TestUserMongoDBRepository_CreateUser(t *testing.T) {
 	}
 }
 
+func TestUserMongoDBRepository_GetOrCreateUserByAuthUID(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		authName     auth.AuthServiceName
+		authUID      string
+		user         *model.User
+		concurrency  int
+		expectedUser *model.User
+		expectedErr  error
+	}{
+		{
+			name:         "existing-user",
+			authName:     auth.AuthServiceNameFirebase,
+			authUID:      localUsers[0].FirebaseUID,
+			user:         &model.User{FirebaseUID: localUsers[0].FirebaseUID, DisplayName: "ignored"},
+			concurrency:  1,
+			expectedUser: localUsers[0],
+		},
+		{
+			name:     "new-user",
+			authName: auth.AuthServiceNameFirebase,
+			authUID:  "test-get-or-create-uid",
+			user: &model.User{
+				FirebaseUID: "test-get-or-create-uid",
+				DisplayName: "test-get-or-create-user",
+				Email:       "test-get-or-create-user@mediation-platform.com",
+			},
+			concurrency: 1,
+		},
+		{
+			name:     "new-user/concurrent",
+			authName: auth.AuthServiceNameFirebase,
+			authUID:  "test-get-or-create-concurrent-uid",
+			user: &model.User{
+				FirebaseUID: "test-get-or-create-concurrent-uid",
+				DisplayName: "test-get-or-create-concurrent-user",
+				Email:       "test-get-or-create-concurrent-user@mediation-platform.com",
+			},
+			concurrency: 8,
+		},
+		{
+			name:     "new-user/email-conflict",
+			authName: auth.AuthServiceNameFirebase,
+			authUID:  "test-get-or-create-conflict-uid",
+			user: &model.User{
+				FirebaseUID: "test-get-or-create-conflict-uid",
+				DisplayName: "test-get-or-create-conflict-user",
+				Email:       localUsers[0].Email,
+			},
+			concurrency: 1,
+			expectedErr: RepositoryError{ErrType: RepositoryErrorTypeServerError},
+		},
+		{
+			name:        "unsupported-auth-service",
+			authName:    auth.AuthServiceName("unsupported"),
+			authUID:     "unsupported-uid",
+			user:        &model.User{},
+			concurrency: 1,
+			expectedErr: RepositoryError{ErrType: RepositoryErrorTypeServerError},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Get or create concurrently, each call has its own user like each request
+			users := make([]*model.User, testCase.concurrency)
+			errs := make([]error, testCase.concurrency)
+			var wg sync.WaitGroup
+			for i := 0; i < testCase.concurrency; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					user := *testCase.user
+					users[i], errs[i] = userMongoDBRepository.GetOrCreateUserByAuthUID(ctx, testCase.authName, testCase.authUID, &user)
+				}(i)
+			}
+			wg.Wait()
+
+			if testCase.expectedErr != nil {
+				for _, err := range errs {
+					assert.ErrorAs(t, err, &testCase.expectedErr)
+					assert.Equal(t, testCase.expectedErr.(RepositoryError).ErrType, err.(RepositoryError).ErrType)
+				}
+				return
+			}
+			for _, err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Defer clean up
+			if testCase.expectedUser == nil {
+				defer func() {
+					err := userMongoDBRepository.DeleteUserByID(ctx, users[0].UserID)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}()
+			}
+
+			// Check all calls get the same user
+			for _, user := range users {
+				assert.Equal(t, users[0].UserID, user.UserID)
+				assert.Equal(t, testCase.authUID, user.FirebaseUID)
+			}
+			if testCase.expectedUser != nil {
+				assertUser(t, testCase.expectedUser, users[0])
+			} else {
+				assert.Equal(t, testCase.user.DisplayName, users[0].DisplayName)
+				assert.Equal(t, testCase.user.Email, users[0].Email)
+				count, err := userMongoDBRepository.Count(ctx, map[string]any{"firebase_uid": testCase.authUID})
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
+
 func TestUserMongoDBRepository_GetUserByID(t *testing.T) {
 	ctx := context.Background()
 
@@ -266,6 +387,19 @@ func TestUserMongoDBRepository_UpdateUser(t *testing.T) {
 				Collection: LocalRepositoryConfigs.UserDB.Collection,
 			},
 		},
+		{
+			name:   "update-user-duplicate-email",
+			userID: userID,
+			updateData: map[string]any{
+				"email": localUsers[0].Email,
+			},
+			expectedErr: RepositoryError{
+				ErrType:    RepositoryErrorTypeDuplicateKey,
+				Database:   LocalRepositoryConfigs.UserDB.Database,
+				Collection: LocalRepositoryConfigs.UserDB.Collection,
+				Field:      "email",
+			},
+		},
 		{
 			name:   "invalid-id",
 			userID: "invalid-id",
@@ -299,6 +433,7 @@ func TestUserMongoDBRepository_UpdateUser(t *testing.T) {
 					assert.Equal(t, testCase.expectedErr.(RepositoryError).ErrType, err.(RepositoryError).ErrType)
 					assert.Equal(t, testCase.expectedErr.(RepositoryError).Database, err.(RepositoryError).Database)
 					assert.Equal(t, testCase.expectedErr.(RepositoryError).Collection, err.(RepositoryError).Collection)
+					assert.Equal(t, testCase.expectedErr.(RepositoryError).Field, err.(RepositoryError).Field)
 				}
 			}
 		})
@@ -379,3 +514,59 @@ func TestUserMongoDBRepository_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserMongoDBRepository_ListUsers(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name            string
+		filter          *UserFilter
+		pageOptions     *PageOptions
+		expectedUserIDs []string
+		expectedCursor  bool
+	}{
+		{
+			name:            "list-all",
+			filter:          nil,
+			pageOptions:     nil,
+			expectedUserIDs: []string{localUsers[0].UserID, localUsers[1].UserID, localUsers[2].UserID},
+		},
+		{
+			name:            "list-all/paginated",
+			filter:          &UserFilter{},
+			pageOptions:     &PageOptions{Limit: 2},
+			expectedUserIDs: []string{localUsers[0].UserID, localUsers[1].UserID},
+			expectedCursor:  true,
+		},
+		{
+			name:            "search-by-email",
+			filter:          &UserFilter{Email: localUsers[1].Email},
+			expectedUserIDs: []string{localUsers[1].UserID},
+		},
+		{
+			name:            "search-by-firebase-uid",
+			filter:          &UserFilter{FirebaseUID: localUsers[2].FirebaseUID},
+			expectedUserIDs: []string{localUsers[2].UserID},
+		},
+		{
+			name:            "search-not-found",
+			filter:          &UserFilter{Email: "not-found@mediation-platform.com"},
+			expectedUserIDs: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			page, err := userMongoDBRepository.ListUsers(ctx, testCase.filter, testCase.pageOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			userIDs := []string{}
+			for _, user := range page.Items {
+				userIDs = append(userIDs, user.UserID)
+			}
+			assert.Equal(t, testCase.expectedUserIDs, userIDs)
+			assert.Equal(t, testCase.expectedCursor, page.NextCursor != "")
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of tracer of the platform
+const TracerName = "github.com/STLeee/mediation-platform/backend/core/tracing"
+
+// TracingConfig struct for tracing configuration, spans are exported by OTLP exporter if it is configured,
+// or only propagated otherwise
+type TracingConfig struct {
+	OTLP *OTLPExporterConfig `yaml:"otlp"`
+}
+
+// OTLPExporterConfig struct for OTLP over HTTP exporter configuration, Endpoint is host and port like localhost:4318
+type OTLPExporterConfig struct {
+	Endpoint string            `yaml:"endpoint"`
+	URLPath  string            `yaml:"url_path"`
+	Insecure bool              `yaml:"insecure"`
+	Headers  map[string]string `yaml:"headers"`
+	Timeout  time.Duration     `yaml:"timeout"`
+}
+
+type TracingErrorType string
+
+const (
+	TracingErrorTypeServerError TracingErrorType = "server_error"
+	TracingErrorTypeConfigError TracingErrorType = "config_error"
+)
+
+var TracingErrorDefaultMessages = map[TracingErrorType]string{
+	TracingErrorTypeServerError: "server error",
+	TracingErrorTypeConfigError: "config error",
+}
+
+// TracingError struct for tracing error
+type TracingError struct {
+	ErrType TracingErrorType
+	Message string
+	Err     error
+}
+
+// Error returns the error message
+func (e TracingError) Error() string {
+	message := e.Message
+	if message == "" {
+		if defaultMessage, ok := TracingErrorDefaultMessages[e.ErrType]; ok {
+			message = defaultMessage
+		}
+	}
+	if e.Err != nil {
+		message = strings.Join([]string{message, e.Err.Error()}, ": ")
+	}
+	return message
+}
+
+// Unwrap returns the wrapped error
+func (e TracingError) Unwrap() error {
+	return e.Err
+}
+
+// NewTracerProvider creates a tracer provider of the service, with OTLP exporter if it is configured
+func NewTracerProvider(ctx context.Context, serviceName string, cfg *TracingConfig) (*sdktrace.TracerProvider, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	}
+	if cfg != nil && cfg.OTLP != nil {
+		exporter, err := newOTLPExporter(ctx, cfg.OTLP)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// newOTLPExporter creates an OTLP over HTTP exporter
+func newOTLPExporter(ctx context.Context, cfg *OTLPExporterConfig) (sdktrace.SpanExporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, TracingError{
+			ErrType: TracingErrorTypeConfigError,
+			Message: "OTLP endpoint is required",
+		}
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.URLPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(cfg.URLPath))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(cfg.Timeout))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, TracingError{
+			ErrType: TracingErrorTypeServerError,
+			Message: "failed to create OTLP exporter",
+			Err:     err,
+		}
+	}
+	return exporter, nil
+}
+
+// SetTracerProvider sets the tracer provider as global, with W3C trace context and baggage propagation
+func SetTracerProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// StartSpan starts a span with the global tracer provider, the span is a child of the span in ctx
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan ends the span, and records the error with error status if err is not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
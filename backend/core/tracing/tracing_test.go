@@ -0,0 +1,121 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/STLeee/mediation-platform/backend/core/tracing/tracingtest"
+)
+
+func TestTracingError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		errType  TracingErrorType
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "server-error/no-message",
+			errType:  TracingErrorTypeServerError,
+			err:      fmt.Errorf("test error"),
+			expected: "server error: test error",
+		},
+		{
+			name:     "config-error/with-message",
+			errType:  TracingErrorTypeConfigError,
+			message:  "test message",
+			expected: "test message",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := TracingError{
+				ErrType: testCase.errType,
+				Message: testCase.message,
+				Err:     testCase.err,
+			}
+			assert.Equal(t, testCase.expected, err.Error())
+			assert.Equal(t, testCase.err, err.Unwrap())
+		})
+	}
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	testCases := []struct {
+		name  string
+		cfg   *TracingConfig
+		isErr bool
+	}{
+		{
+			name: "nil-config",
+			cfg:  nil,
+		},
+		{
+			name: "without-exporter",
+			cfg:  &TracingConfig{},
+		},
+		{
+			name: "otlp",
+			cfg: &TracingConfig{
+				OTLP: &OTLPExporterConfig{
+					Endpoint: "localhost:4318",
+					URLPath:  "/v1/traces",
+					Insecure: true,
+					Headers:  map[string]string{"Authorization": "Bearer test-token"},
+				},
+			},
+		},
+		{
+			name:  "otlp/no-endpoint",
+			cfg:   &TracingConfig{OTLP: &OTLPExporterConfig{}},
+			isErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			provider, err := NewTracerProvider(context.Background(), "test-service", testCase.cfg)
+			if testCase.isErr {
+				assert.ErrorAs(t, err, &TracingError{})
+				assert.Equal(t, TracingErrorTypeConfigError, err.(TracingError).ErrType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, provider)
+			assert.NoError(t, provider.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	provider, exporter := tracingtest.NewInMemoryTracerProvider()
+	SetTracerProvider(provider)
+
+	// Child span of parent in context
+	ctx, parent := StartSpan(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := StartSpan(ctx, "child", trace.WithAttributes(semconv.DBSystemMongoDB))
+	EndSpan(child, errors.New("test error"))
+	EndSpan(parent, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "test error", spans[0].Status.Description)
+	assert.Len(t, spans[0].Events, 1)
+	assert.Contains(t, spans[0].Attributes, semconv.DBSystemMongoDB)
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, trace.SpanKindServer, spans[1].SpanKind)
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+}
@@ -0,0 +1,14 @@
+// Package tracingtest provides tracer provider for tests, it is kept out of package tracing so the service binary does
+// not link the in-memory exporter
+package tracingtest
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// NewInMemoryTracerProvider creates a tracer provider exporting spans to memory synchronously
+func NewInMemoryTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
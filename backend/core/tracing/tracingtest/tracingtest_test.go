@@ -0,0 +1,20 @@
+package tracingtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInMemoryTracerProvider(t *testing.T) {
+	provider, exporter := NewInMemoryTracerProvider()
+	_, span := provider.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+
+	// Spans are exported when they end
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "test-span", spans[0].Name)
+	assert.NoError(t, provider.Shutdown(context.Background()))
+}
@@ -3,5 +3,12 @@ conn = new Mongo();
 db = conn.getDB("mediation-platform");
 
 // create indexes
+db.user.createIndex({ "firebase_uid": 1 }, { unique: true });
 db.user.createIndex({ "email": 1 }, { unique: true });
 db.user.createIndex({ "phone_number": 1 }, { unique: true });
+db.issue.createIndex({ "created_by": 1, "created_at": -1 });
+db.issue.createIndex({ "parties": 1, "created_at": -1 });
+db.comment.createIndex({ "issue_id": 1, "created_at": 1 });
+db.notification.createIndex({ "recipient_id": 1, "created_at": -1 });
+db.audit_log.createIndex({ "target_id": 1, "_id": -1 });
+db.audit_log.createIndex({ "actor_id": 1, "_id": -1 });